@@ -2,7 +2,9 @@ package main
 
 import (
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	"marketcontrol/internal/routes"
 	"marketcontrol/pkg/config"
@@ -12,16 +14,13 @@ import (
 func main() {
 	// Initialize database
 	config.InitDB()
+	defer config.CloseDB()
 
 	// Initialize RabbitMQ (optional, will log warning if not configured)
+	// An unreachable broker doesn't stop the API; the supervisor keeps retrying in the background
 	if os.Getenv("RABBITMQ_HOST") != "" {
-		config.InitRabbitMQ()
-		defer func() {
-			if config.RabbitMQ != nil {
-				config.RabbitMQ.Close()
-			}
-		}()
-		log.Println("RabbitMQ initialized successfully")
+		config.InitRabbitMQOptional()
+		defer config.CloseRabbitMQ()
 	} else {
 		log.Println("RabbitMQ not configured, skipping initialization")
 	}
@@ -54,7 +53,30 @@ func main() {
 		port = "8080"
 	}
 
-	if err := r.Run(":" + port); err != nil {
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           r,
+		ReadHeaderTimeout: envDuration("SERVER_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       envDuration("SERVER_READ_TIMEOUT", 60*time.Second),
+		WriteTimeout:      envDuration("SERVER_WRITE_TIMEOUT", 5*time.Minute),
+		IdleTimeout:       envDuration("SERVER_IDLE_TIMEOUT", 2*time.Minute),
+	}
+
+	if err := server.ListenAndServe(); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// envDuration parses a duration such as "30s" from the environment, falling back to def
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s=%q, using default %s", key, v, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"time"
+
+	"marketcontrol/internal/models"
+	"marketcontrol/pkg/config"
+
+	logrus "github.com/sirupsen/logrus"
+	"gorm.io/gorm/clause"
+)
+
+// recordErrorState persists the current error count and the last error of an address so the
+// API can show why monitoring is failing. 写库失败只记录日志，不影响重试逻辑
+func recordErrorState(address string, count int, cause error) {
+	if config.DB == nil {
+		return
+	}
+
+	now := time.Now()
+	state := models.MonitorErrorState{
+		Address:     address,
+		ErrorCount:  count,
+		LastErrorAt: &now,
+	}
+	if cause != nil {
+		state.LastError = cause.Error()
+	}
+
+	err := config.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "address"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"error_count":   state.ErrorCount,
+			"last_error":    state.LastError,
+			"last_error_at": state.LastErrorAt,
+			"cleaned_up":    false,
+			"cleaned_up_at": nil,
+			"updated_at":    now,
+		}),
+	}).Create(&state).Error
+	if err != nil {
+		logrus.Warnf("Failed to record monitor error state for %s: %v", address, err)
+	}
+}
+
+// clearErrorState resets the stored count after a successful start; the last error is kept for triage
+func clearErrorState(address string) {
+	if config.DB == nil {
+		return
+	}
+
+	err := config.DB.Model(&models.MonitorErrorState{}).
+		Where("address = ? AND (error_count > 0 OR cleaned_up = ?)", address, true).
+		Updates(map[string]interface{}{
+			"error_count":   0,
+			"cleaned_up":    false,
+			"cleaned_up_at": nil,
+		}).Error
+	if err != nil {
+		logrus.Warnf("Failed to reset monitor error state for %s: %v", address, err)
+	}
+}
+
+// markErrorStateCleanedUp records that the address was dropped after reaching maxErrorCount
+func markErrorStateCleanedUp(address string) {
+	if config.DB == nil {
+		return
+	}
+
+	err := config.DB.Model(&models.MonitorErrorState{}).
+		Where("address = ?", address).
+		Updates(map[string]interface{}{
+			"cleaned_up":    true,
+			"cleaned_up_at": time.Now(),
+		}).Error
+	if err != nil {
+		logrus.Warnf("Failed to mark monitor error state cleaned up for %s: %v", address, err)
+	}
+}
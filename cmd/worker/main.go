@@ -3,7 +3,10 @@ package main
 import (
 	"encoding/json"
 	"log"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 
 	"marketcontrol/pkg/config"
 	"marketcontrol/pkg/solana/meteora"
@@ -28,24 +31,41 @@ func main() {
 
 	// Initialize database
 	config.InitDB()
+	defer config.CloseDB()
 
 	// Initialize RabbitMQ
 	config.InitRabbitMQ()
-	defer config.RabbitMQ.Close()
+	defer config.CloseRabbitMQ()
 
 	// Create pool monitor manager
 	manager, err := meteora.NewPoolMonitorManager()
 	if err != nil {
 		logrus.Fatal("Failed to create pool monitor manager: ", err)
 	}
+	// Stopping an address also drops its queue and its error count here
+	manager.SetCleanupFunc(cleanupRabbitMQResources)
+	serveWorkerStatus(manager)
 
 	// Create consumer for meteora pool monitoring queue
-	msgConsumer, err := config.NewConsumer("meteora_pool_monitor")
+	msgConsumer, err := config.NewConsumer(meteora.PoolMonitorQueue)
 	if err != nil {
 		logrus.Fatal("Failed to create consumer: ", err)
 	}
 	defer msgConsumer.Close()
 
+	// Flush swaps buffered by METEORA_SWAP_BATCH_SIZE before exiting
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-sigCh
+		logrus.Infof("Received %s, flushing buffered swaps and shutting down", sig)
+		manager.Shutdown()
+		msgConsumer.Close()
+		config.CloseRabbitMQ()
+		config.CloseDB()
+		os.Exit(0)
+	}()
+
 	logrus.Info("Meteora Pool Monitor Worker started, waiting for messages...")
 
 	// Start consuming messages
@@ -106,11 +126,12 @@ func main() {
 						monitorMsg.MeteoradbcAddress, err)
 
 					// Increment error count and check if we should stop
-					count := incrementErrorCount(monitorMsg.MeteoradbcAddress)
+					count := incrementErrorCount(monitorMsg.MeteoradbcAddress, err)
 					if count >= maxErrorCount {
 						logrus.Errorf("Error count exceeded threshold for %s, cleaning up RabbitMQ resources",
 							monitorMsg.MeteoradbcAddress)
 						cleanupRabbitMQResources(monitorMsg.MeteoradbcAddress)
+						markErrorStateCleanedUp(monitorMsg.MeteoradbcAddress)
 						// Don't return error, just log and continue
 						logrus.Warnf("Skipping monitoring for %s due to excessive errors", monitorMsg.MeteoradbcAddress)
 					} else {
@@ -137,11 +158,12 @@ func main() {
 						monitorMsg.MeteoracpmmAddress, err)
 
 					// Increment error count and check if we should stop
-					count := incrementErrorCount(monitorMsg.MeteoracpmmAddress)
+					count := incrementErrorCount(monitorMsg.MeteoracpmmAddress, err)
 					if count >= maxErrorCount {
 						logrus.Errorf("Error count exceeded threshold for %s, cleaning up RabbitMQ resources",
 							monitorMsg.MeteoracpmmAddress)
 						cleanupRabbitMQResources(monitorMsg.MeteoracpmmAddress)
+						markErrorStateCleanedUp(monitorMsg.MeteoracpmmAddress)
 						// Don't return error, just log and continue
 						logrus.Warnf("Skipping monitoring for %s due to excessive errors", monitorMsg.MeteoracpmmAddress)
 					} else {
@@ -172,52 +194,70 @@ func main() {
 					logrus.Infof("Stopped monitoring Meteoracpmm address: %s", monitorMsg.MeteoracpmmAddress)
 				}
 			}
+		} else if monitorMsg.Action == meteora.BackfillAction {
+			// 回填可能持续很久，不阻塞消费
+			go manager.RunBackfill(
+				monitorMsg.BackfillID,
+				monitorMsg.BaseTokenMint,
+				monitorMsg.QuoteTokenMint,
+				monitorMsg.MeteoraDbcAuthority,
+				monitorMsg.MeteoraCpmmAuthority,
+			)
+		} else if monitorMsg.Action == meteora.CancelBackfillAction {
+			// 只有运行该回填的 worker 能取消它；其他 worker 上运行的回填会在下次上报进度时发现状态已变更
+			if !meteora.CancelBackfill(monitorMsg.BackfillID) {
+				logrus.Infof("Backfill %d is not running in this worker", monitorMsg.BackfillID)
+			}
 		}
 
 		return nil
 	})
 
 	if err != nil {
+		manager.Shutdown()
 		log.Fatal("Failed to start consumer: ", err)
 	}
 }
 
-// incrementErrorCount increments the error count for an address
-func incrementErrorCount(address string) int {
+// incrementErrorCount increments the error count for an address and records cause in monitor_error_state
+func incrementErrorCount(address string, cause error) int {
 	errorCountsMu.Lock()
-	defer errorCountsMu.Unlock()
-
 	errorCounts[address]++
 	count := errorCounts[address]
+	errorCountsMu.Unlock()
+
 	logrus.Warnf("Error count for address %s: %d/%d", address, count, maxErrorCount)
+	recordErrorState(address, count, cause)
 	return count
 }
 
 // resetErrorCount resets the error count for an address
 func resetErrorCount(address string) {
 	errorCountsMu.Lock()
-	defer errorCountsMu.Unlock()
-
 	if errorCounts[address] > 0 {
 		logrus.Debugf("Resetting error count for address %s (was %d)", address, errorCounts[address])
 		errorCounts[address] = 0
 	}
+	errorCountsMu.Unlock()
+
+	clearErrorState(address)
 }
 
 // cleanupRabbitMQResources cleans up RabbitMQ resources for an address
 func cleanupRabbitMQResources(address string) {
-	if config.RabbitMQ == nil {
+	if config.CurrentRabbitMQ() == nil {
 		logrus.Debugf("RabbitMQ not initialized, skipping cleanup for address: %s", address)
 		return
 	}
 
 	// Try to delete queue named after the address (if it exists)
-	queueName := "meteora_pool_monitor_" + address
+	// DeleteQueue applies RABBITMQ_QUEUE_PREFIX itself, so pass the bare name
+	queueName := meteora.AddressQueueName(address)
 	if err := config.DeleteQueue(queueName); err != nil {
 		// Queue might not exist, which is fine - log as debug
-		logrus.Debugf("Queue %s does not exist or failed to delete: %v", queueName, err)
+		logrus.Debugf("Queue %s does not exist or failed to delete: %v", config.QueueName(queueName), err)
 	} else {
-		logrus.Infof("Deleted RabbitMQ queue for address %s: %s", address, queueName)
+		logrus.Infof("Deleted RabbitMQ queue for address %s: %s", address, config.QueueName(queueName))
 	}
 
 	// Reset error count after cleanup
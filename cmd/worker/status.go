@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"marketcontrol/pkg/solana/meteora"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// workerStatusAddrEnv is the listen address of the worker status endpoint (e.g. ":8091");
+// the endpoint is disabled when it is empty
+const workerStatusAddrEnv = "WORKER_STATUS_ADDR"
+
+// workerStatus is the body of GET /status: the live connection state of every address this worker monitors
+type workerStatus struct {
+	Hostname    string            `json:"hostname"`
+	Connections map[string]string `json:"connections"`
+	// LastMessageAt is the unix time of the last notification or pong per address; addresses that have
+	// not received anything yet are omitted
+	LastMessageAt map[string]int64 `json:"last_message_at"`
+	Timestamp     int64            `json:"timestamp"`
+}
+
+// serveWorkerStatus exposes the manager's connections on WORKER_STATUS_ADDR so the API can show live state
+func serveWorkerStatus(manager *meteora.PoolMonitorManager) {
+	addr := os.Getenv(workerStatusAddrEnv)
+	if addr == "" {
+		return
+	}
+	hostname, _ := os.Hostname()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		infos := manager.GetConnectionInfos()
+		connections := make(map[string]string, len(infos))
+		lastMessageAt := make(map[string]int64, len(infos))
+		for address, info := range infos {
+			connections[address] = info.Status
+			if !info.LastMessage.IsZero() {
+				lastMessageAt[address] = info.LastMessage.Unix()
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(workerStatus{
+			Hostname:      hostname,
+			Connections:   connections,
+			LastMessageAt: lastMessageAt,
+			Timestamp:     time.Now().Unix(),
+		})
+	})
+
+	go func() {
+		logrus.Infof("Worker status endpoint listening on %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logrus.Errorf("Worker status endpoint stopped: %v", err)
+		}
+	}()
+}
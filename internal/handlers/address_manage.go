@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
@@ -10,7 +11,9 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"marketcontrol/internal/models"
 	dbconfig "marketcontrol/pkg/config"
@@ -25,16 +28,115 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// ListAddresses returns a list of all managed addresses
+// ListAddresses returns the managed addresses as a plain array, as it always has; passing page or page_size
+// returns that page wrapped in {data, pagination} instead.
+// Newest first. Supports an address prefix search; private keys are omitted unless include_private_key=true.
 func ListAddresses(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 100
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 1000 {
+			pageSize = parsed
+		}
+	}
+	includePrivateKey := c.Query("include_private_key") == "true"
+
+	query := dbconfig.DB.Model(&models.AddressManage{})
+	if prefix := strings.TrimSpace(c.Query("prefix")); prefix != "" {
+		// 转义 LIKE 通配符，确保按字面前缀匹配
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+		query = query.Where("address LIKE ?", escaped+"%")
+	}
+	if !includePrivateKey {
+		query = query.Omit("private_key")
+	}
+
+	// 未传 page/page_size 时保持原有的数组返回
+	if c.Query("page") == "" && c.Query("page_size") == "" {
+		addresses := []models.AddressManage{}
+		if !findBounded(c, query.Order("id desc"), &addresses) {
+			return
+		}
+		c.JSON(http.StatusOK, addresses)
+		return
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	var addresses []models.AddressManage
-	if err := dbconfig.DB.Find(&addresses).Error; err != nil {
+	offset := (page - 1) * pageSize
+	if err := query.Order("id desc").Offset(offset).Limit(pageSize).Find(&addresses).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, addresses)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       addresses,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// OrphanAddress is a managed address without a role; it deliberately has no private key field
+type OrphanAddress struct {
+	ID        uint      `json:"id"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListOrphanAddresses returns managed addresses that are not attached to any role, paginated.
+// Private keys are never included.
+func ListOrphanAddresses(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 100
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 1000 {
+			pageSize = parsed
+		}
+	}
+
+	query := dbconfig.DB.Model(&models.AddressManage{}).
+		Joins("LEFT JOIN role_address ON role_address.address = address_manages.address").
+		Where("role_address.address IS NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	addresses := []OrphanAddress{}
+	offset := (page - 1) * pageSize
+	if err := query.Select("address_manages.id, address_manages.address, address_manages.created_at, address_manages.updated_at").
+		Order("address_manages.id asc").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&addresses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       addresses,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
 }
 
 // GetAddress returns a specific managed address by address string
@@ -294,6 +396,7 @@ func ListAddressesByRole(c *gin.Context) {
 type ExportPasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required"`
+	Concurrency int    `json:"concurrency"` // 可选，并发解密/重新加密的 worker 数量
 }
 
 // ExportAddress represents an address entry in the export file
@@ -302,7 +405,59 @@ type ExportAddress struct {
 	PrivateKey string `json:"private_key"`
 }
 
-// ExportWithNewPassword exports all addresses with re-encrypted private keys using a new password
+// ExportFailure represents an address that could not be re-encrypted during export
+type ExportFailure struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
+
+const (
+	defaultExportConcurrency = 8
+	maxExportConcurrency     = 64
+)
+
+// reencryptAddress decrypts an address's private key with the old password, verifies it and re-encrypts it with the new password
+func reencryptAddress(km *solana.KeyManager, addr models.AddressManage, oldPassword, newPassword string) (ExportAddress, error) {
+	account, err := verifyAddressKey(km, addr.Address, addr.PrivateKey, oldPassword)
+	if err != nil {
+		return ExportAddress{}, err
+	}
+
+	// Re-encrypt with new password
+	newEncryptedKey, err := km.EncryptPrivateKey(account.PrivateKey, newPassword)
+	if err != nil {
+		return ExportAddress{}, fmt.Errorf("failed to re-encrypt: %v", err)
+	}
+
+	return ExportAddress{
+		Address:    addr.Address,
+		PrivateKey: newEncryptedKey,
+	}, nil
+}
+
+// verifyAddressKey decrypts an encrypted private key and checks that it belongs to address
+func verifyAddressKey(km *solana.KeyManager, address, encryptedKey, password string) (types.Account, error) {
+	// Decrypt with password
+	decryptedKey, err := km.DecryptPrivateKey(encryptedKey, password)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decrypt: %v", err)
+	}
+
+	// Convert decrypted key to account
+	account, err := types.AccountFromBytes(decryptedKey)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to create account: %v", err)
+	}
+
+	// Verify address matches
+	if account.PublicKey.ToBase58() != address {
+		return types.Account{}, fmt.Errorf("address mismatch")
+	}
+	return account, nil
+}
+
+// ExportWithNewPassword exports all addresses with re-encrypted private keys using a new password.
+// Addresses are processed by a bounded worker pool; per-address failures are reported instead of aborting the export.
 func ExportWithNewPassword(c *gin.Context) {
 	var request ExportPasswordRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -310,9 +465,17 @@ func ExportWithNewPassword(c *gin.Context) {
 		return
 	}
 
+	concurrency := request.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultExportConcurrency
+	}
+	if concurrency > maxExportConcurrency {
+		concurrency = maxExportConcurrency
+	}
+
 	// Get all addresses from the database
 	var addresses []models.AddressManage
-	if err := dbconfig.DB.Find(&addresses).Error; err != nil {
+	if err := dbconfig.DB.Order("id asc").Find(&addresses).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch addresses: " + err.Error()})
 		return
 	}
@@ -320,41 +483,37 @@ func ExportWithNewPassword(c *gin.Context) {
 	// Create a new key manager
 	km := solana.NewKeyManager()
 
-	// Process each address
-	exportAddresses := make([]ExportAddress, 0)
-	for _, addr := range addresses {
-		// Decrypt with old password
-		decryptedKey, err := km.DecryptPrivateKey(addr.PrivateKey, request.OldPassword)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to decrypt address %s: %v", addr.Address, err)})
-			return
-		}
+	// 结果按下标写入，保持与数据库顺序一致
+	exported := make([]*ExportAddress, len(addresses))
+	failed := make([]*ExportFailure, len(addresses))
 
-		// Convert decrypted key to account
-		account, err := types.AccountFromBytes(decryptedKey)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create account for address %s: %v", addr.Address, err)})
-			return
-		}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range addresses {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-		// Verify address matches
-		if account.PublicKey.ToBase58() != addr.Address {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Address mismatch for %s", addr.Address)})
-			return
-		}
+			exportAddress, err := reencryptAddress(km, addresses[i], request.OldPassword, request.NewPassword)
+			if err != nil {
+				failed[i] = &ExportFailure{Address: addresses[i].Address, Error: err.Error()}
+				return
+			}
+			exported[i] = &exportAddress
+		}(i)
+	}
+	wg.Wait()
 
-		// Re-encrypt with new password
-		newEncryptedKey, err := km.EncryptPrivateKey(account.PrivateKey, request.NewPassword)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to re-encrypt address %s: %v", addr.Address, err)})
-			return
+	exportAddresses := make([]ExportAddress, 0, len(addresses))
+	failures := make([]ExportFailure, 0)
+	for i := range addresses {
+		if exported[i] != nil {
+			exportAddresses = append(exportAddresses, *exported[i])
+		} else if failed[i] != nil {
+			failures = append(failures, *failed[i])
 		}
-
-		// Add to export list
-		exportAddresses = append(exportAddresses, ExportAddress{
-			Address:    addr.Address,
-			PrivateKey: newEncryptedKey,
-		})
 	}
 
 	// Set headers for file download
@@ -363,8 +522,12 @@ func ExportWithNewPassword(c *gin.Context) {
 
 	// Send the JSON response
 	c.JSON(http.StatusOK, gin.H{
-		"message":   fmt.Sprintf("Successfully exported %d addresses", len(exportAddresses)),
-		"addresses": exportAddresses,
+		"message":       fmt.Sprintf("Successfully exported %d addresses", len(exportAddresses)),
+		"total_count":   len(addresses),
+		"success_count": len(exportAddresses),
+		"failure_count": len(failures),
+		"addresses":     exportAddresses,
+		"failures":      failures,
 	})
 }
 
@@ -374,26 +537,85 @@ type ImportRequest struct {
 	Addresses []ExportAddress `json:"addresses" binding:"required"`
 }
 
-// ImportAndVerifyPassword handles the import and verification of addresses from a JSON file
-func ImportAndVerifyPassword(c *gin.Context) {
+const (
+	defaultImportMaxUploadBytes = 10 << 20
+	// defaultImportPasswordSampleSize 未配置 ENCRYPTPASSWORD 时抽样校验的已有地址数量，避免每次导入都解密整张表
+	defaultImportPasswordSampleSize = 20
+	// importAddressLookupChunkSize bounds the bind parameters of one "address IN" existence lookup
+	importAddressLookupChunkSize = 900
+)
+
+// importMaxUploadBytes returns the upload limit of the file import endpoints, configurable via IMPORT_MAX_UPLOAD_BYTES.
+// The import routes are exempt from MAX_BODY_BYTES, so this is the only limit they enforce.
+func importMaxUploadBytes() int64 {
+	if v := os.Getenv("IMPORT_MAX_UPLOAD_BYTES"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultImportMaxUploadBytes
+}
+
+// importPasswordSampleSize returns IMPORT_PASSWORD_SAMPLE_SIZE, the number of existing addresses the import
+// password is checked against when ENCRYPTPASSWORD is not set
+func importPasswordSampleSize() int {
+	if v := os.Getenv("IMPORT_PASSWORD_SAMPLE_SIZE"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultImportPasswordSampleSize
+}
+
+// parseImportForm caps the request body at importMaxUploadBytes and parses the multipart form.
+// On failure the error response has already been written and it returns false.
+func parseImportForm(c *gin.Context) bool {
+	maxUploadBytes := importMaxUploadBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadBytes)
+	if err := c.Request.ParseMultipartForm(maxUploadBytes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error(), "max_upload_bytes": maxUploadBytes})
+		return false
+	}
+	return true
+}
+
+// ImportFailure represents an imported address that failed verification or could not be stored
+type ImportFailure struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
+
+// readImportForm reads the password, concurrency (default defaultExportConcurrency, max maxExportConcurrency)
+// and JSON file fields of an import request.
+// On failure the error response has already been written and ok is false.
+func readImportForm(c *gin.Context) (password string, concurrency int, addresses []ExportAddress, ok bool) {
 	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error()})
-		return
+	if !parseImportForm(c) {
+		return "", 0, nil, false
 	}
 
 	// Get password from form
-	password := c.Request.FormValue("password")
+	password = c.Request.FormValue("password")
 	if password == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Password is required"})
-		return
+		return "", 0, nil, false
+	}
+
+	concurrency = defaultExportConcurrency
+	if v := c.Request.FormValue("concurrency"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			concurrency = parsed
+		}
+	}
+	if concurrency > maxExportConcurrency {
+		concurrency = maxExportConcurrency
 	}
 
 	// Get uploaded file
 	file, _, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to get file: " + err.Error()})
-		return
+		return "", 0, nil, false
 	}
 	defer file.Close()
 
@@ -403,94 +625,151 @@ func ImportAndVerifyPassword(c *gin.Context) {
 	}
 	if err := json.NewDecoder(file).Decode(&importData); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse JSON file: " + err.Error()})
-		return
+		return "", 0, nil, false
 	}
+	return password, concurrency, importData.Addresses, true
+}
 
-	// Create key manager
-	km := solana.NewKeyManager()
+// checkImportPassword confirms that password is the one the keys of model's table are encrypted with.
+// On failure the error response has already been written and it returns false.
+func checkImportPassword(c *gin.Context, km *solana.KeyManager, password string, model interface{}) bool {
+	// 配置了 ENCRYPTPASSWORD 时直接比较（对整张表成立），否则随机抽样已有地址解密校验
+	if encryptPassword := os.Getenv("ENCRYPTPASSWORD"); encryptPassword != "" {
+		if subtle.ConstantTimeCompare([]byte(password), []byte(encryptPassword)) != 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Password does not match the stored addresses' password"})
+			return false
+		}
+		return true
+	}
 
-	// First verify existing addresses in database
-	var existingAddresses []models.AddressManage
-	if err := dbconfig.DB.Find(&existingAddresses).Error; err != nil {
+	var sample []ExportAddress
+	if err := dbconfig.DB.Model(model).Select("address, private_key").Order("random()").Limit(importPasswordSampleSize()).Scan(&sample).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch existing addresses: " + err.Error()})
-		return
+		return false
 	}
-
-	// Verify existing addresses
-	for _, addr := range existingAddresses {
-		decryptedKey, err := km.DecryptPrivateKey(addr.PrivateKey, password)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to decrypt existing address %s: %v", addr.Address, err)})
-			return
-		}
-
-		account, err := types.AccountFromBytes(decryptedKey)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create account for existing address %s: %v", addr.Address, err)})
-			return
-		}
-
-		if account.PublicKey.ToBase58() != addr.Address {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Address mismatch for existing address %s", addr.Address)})
-			return
+	for _, addr := range sample {
+		if _, err := verifyAddressKey(km, addr.Address, addr.PrivateKey, password); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Password does not match existing address %s: %v", addr.Address, err)})
+			return false
 		}
 	}
+	return true
+}
 
-	// (moved) ExportWithNewPasswordFromRole will be defined after ImportAndVerifyPassword
-
-	// Verify imported addresses
+// newImportAddresses drops the addresses that already exist in model's table (soft-deleted rows included)
+// or repeat within the file, looking up only the addresses of the file.
+// On failure the error response has already been written and ok is false.
+func newImportAddresses(c *gin.Context, model interface{}, addresses []ExportAddress) (toVerify []ExportAddress, skipped int, ok bool) {
+	candidates := make([]string, 0, len(addresses))
+	for _, importAddr := range addresses {
+		candidates = append(candidates, importAddr.Address)
+	}
 	existingAddressMap := make(map[string]bool)
-	for _, addr := range existingAddresses {
-		existingAddressMap[addr.Address] = true
+	for start := 0; start < len(candidates); start += importAddressLookupChunkSize {
+		end := start + importAddressLookupChunkSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		var existing []string
+		if err := dbconfig.DB.Unscoped().Model(model).Where("address IN ?", candidates[start:end]).Pluck("address", &existing).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch existing addresses: " + err.Error()})
+			return nil, 0, false
+		}
+		for _, address := range existing {
+			existingAddressMap[address] = true
+		}
 	}
 
-	// Track new addresses to import
-	var newAddresses []models.AddressManage
-
-	// Verify and prepare imported addresses
-	for _, importAddr := range importData.Addresses {
-		// Skip if address already exists
+	for _, importAddr := range addresses {
 		if existingAddressMap[importAddr.Address] {
+			skipped++
 			continue
 		}
+		existingAddressMap[importAddr.Address] = true
+		toVerify = append(toVerify, importAddr)
+	}
+	return toVerify, skipped, true
+}
 
-		// Verify the imported address
-		decryptedKey, err := km.DecryptPrivateKey(importAddr.PrivateKey, password)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to decrypt imported address %s: %v", importAddr.Address, err)})
-			return
-		}
+// verifyImportAddresses verifies the imported keys with a bounded worker pool; both results keep the file order
+func verifyImportAddresses(km *solana.KeyManager, toVerify []ExportAddress, password string, concurrency int) ([]ExportAddress, []ImportFailure) {
+	verified := make([]bool, len(toVerify))
+	failed := make([]*ImportFailure, len(toVerify))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range toVerify {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-		account, err := types.AccountFromBytes(decryptedKey)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create account for imported address %s: %v", importAddr.Address, err)})
-			return
-		}
+			if _, err := verifyAddressKey(km, toVerify[i].Address, toVerify[i].PrivateKey, password); err != nil {
+				failed[i] = &ImportFailure{Address: toVerify[i].Address, Error: err.Error()}
+				return
+			}
+			verified[i] = true
+		}(i)
+	}
+	wg.Wait()
 
-		if account.PublicKey.ToBase58() != importAddr.Address {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Address mismatch for imported address %s", importAddr.Address)})
-			return
+	valid := make([]ExportAddress, 0, len(toVerify))
+	failures := make([]ImportFailure, 0)
+	for i := range toVerify {
+		if verified[i] {
+			valid = append(valid, toVerify[i])
+		} else if failed[i] != nil {
+			failures = append(failures, *failed[i])
 		}
+	}
+	return valid, failures
+}
 
-		// Add to new addresses list
-		newAddresses = append(newAddresses, models.AddressManage{
-			Address:    importAddr.Address,
-			PrivateKey: importAddr.PrivateKey,
-		})
+// ImportAndVerifyPassword handles the import and verification of addresses from a JSON file.
+// The password is compared with ENCRYPTPASSWORD (or, when unset, checked against a random sample of existing
+// addresses, IMPORT_PASSWORD_SAMPLE_SIZE), then imported addresses are verified
+// by a bounded worker pool (form field concurrency); invalid entries are reported instead of aborting the import.
+func ImportAndVerifyPassword(c *gin.Context) {
+	password, concurrency, addresses, ok := readImportForm(c)
+	if !ok {
+		return
+	}
+
+	km := solana.NewKeyManager()
+	if !checkImportPassword(c, km, password, &models.AddressManage{}) {
+		return
+	}
+	toVerify, skipped, ok := newImportAddresses(c, &models.AddressManage{}, addresses)
+	if !ok {
+		return
+	}
+	valid, failures := verifyImportAddresses(km, toVerify, password, concurrency)
+
+	newAddresses := make([]models.AddressManage, len(valid))
+	for i, addr := range valid {
+		newAddresses[i] = models.AddressManage{Address: addr.Address, PrivateKey: addr.PrivateKey}
 	}
 
 	// Import new addresses
+	imported := int64(0)
 	if len(newAddresses) > 0 {
-		if err := dbconfig.DB.Create(&newAddresses).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import new addresses: " + err.Error()})
+		result := dbconfig.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&newAddresses, 500)
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import new addresses: " + result.Error.Error(), "failures": failures})
 			return
 		}
+		imported = result.RowsAffected
+		// 并发导入时可能已被其他请求写入
+		skipped += len(newAddresses) - int(imported)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":        fmt.Sprintf("Successfully imported %d new addresses", len(newAddresses)),
-		"imported_count": len(newAddresses),
-		"skipped_count":  len(importData.Addresses) - len(newAddresses),
+		"message":        fmt.Sprintf("Successfully imported %d new addresses", imported),
+		"total_count":    len(addresses),
+		"imported_count": imported,
+		"skipped_count":  skipped,
+		"failed_count":   len(failures),
+		"failures":       failures,
 	})
 }
 
@@ -590,6 +869,114 @@ func ExportWithNewPasswordFromRole(c *gin.Context) {
 	})
 }
 
+// UpgradeEncryptionRequest represents the request body for upgrading stored private key encryption
+type UpgradeEncryptionRequest struct {
+	Password          string `json:"password" binding:"required"`
+	BatchSize         int    `json:"batch_size"`
+	IncludeDisposable bool   `json:"include_disposable"`
+}
+
+// upgradeEncryptionResult 记录单张表的升级结果
+type upgradeEncryptionResult struct {
+	Upgraded int      `json:"upgraded"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors"`
+}
+
+// upgradeTableEncryption re-encrypts legacy private_key blobs of one table in id-ordered batches
+func upgradeTableEncryption(km *solana.KeyManager, model interface{}, password string, batchSize int) (upgradeEncryptionResult, error) {
+	result := upgradeEncryptionResult{Errors: []string{}}
+	currentPrefix := fmt.Sprintf("v%d:%%", solana.CurrentKeyVersion)
+
+	type keyRow struct {
+		ID         uint
+		Address    string
+		PrivateKey string
+	}
+
+	var lastID uint
+	for {
+		var rows []keyRow
+		if err := dbconfig.DB.Model(model).
+			Select("id, address, private_key").
+			Where("id > ? AND private_key NOT LIKE ?", lastID, currentPrefix).
+			Order("id asc").
+			Limit(batchSize).
+			Scan(&rows).Error; err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			lastID = row.ID
+			upgraded, changed, err := km.UpgradeEncryptedKey(row.PrivateKey, password)
+			if err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", row.Address, err))
+				continue
+			}
+			if !changed {
+				continue
+			}
+			// 仅在原值未被并发修改时覆盖
+			res := dbconfig.DB.Model(model).
+				Where("id = ? AND private_key = ?", row.ID, row.PrivateKey).
+				Update("private_key", upgraded)
+			if res.Error != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", row.Address, res.Error))
+				continue
+			}
+			if res.RowsAffected > 0 {
+				result.Upgraded++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// UpgradeAddressEncryption re-encrypts stored private keys that still use an older KDF version
+func UpgradeAddressEncryption(c *gin.Context) {
+	var request UpgradeEncryptionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.BatchSize <= 0 {
+		request.BatchSize = 200
+	}
+	if request.BatchSize > 1000 {
+		request.BatchSize = 1000
+	}
+
+	km := solana.NewKeyManager()
+
+	addressResult, err := upgradeTableEncryption(km, &models.AddressManage{}, request.Password, request.BatchSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade address_manage: " + err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"key_version":    solana.CurrentKeyVersion,
+		"address_manage": addressResult,
+	}
+
+	if request.IncludeDisposable {
+		disposableResult, err := upgradeTableEncryption(km, &models.DisposableAddressManage{}, request.Password, request.BatchSize)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade disposable_address_manage: " + err.Error()})
+			return
+		}
+		response["disposable_address_manage"] = disposableResult
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // AddressRoleInfo represents the response structure for address with role information
 type AddressRoleInfo struct {
 	Address   string               `json:"address"`
@@ -710,6 +1097,7 @@ func ReviewAddressesByRoleCount(c *gin.Context) {
 		"page_size":    pageSize,
 		"order":        order,
 		"data":         pageData,
+		"pagination":   paginationMeta(page, pageSize, int64(total)),
 	})
 }
 
@@ -762,7 +1150,7 @@ func ReviewAddressesByTokenStat(c *gin.Context) {
 		request.OrderBy = "sol"
 	}
 	if len(request.Tokens) == 0 {
-		request.Tokens = []string{"sol", "So11111111111111111111111111111111111111112"}
+		request.Tokens = solana.SolMints()
 	}
 
 	// Remove duplicates from tokens array
@@ -812,6 +1200,7 @@ func ReviewAddressesByTokenStat(c *gin.Context) {
 			"order_by":       request.OrderBy,
 			"data":           []interface{}{},
 			"aggregate_data": []interface{}{},
+			"pagination":     paginationMeta(request.Page, request.PageSize, 0),
 		})
 		return
 	}
@@ -832,7 +1221,7 @@ func ReviewAddressesByTokenStat(c *gin.Context) {
 
 	// Calculate aggregate data
 	aggregateTokenMap := AggregateTokenStats(stats)
-	var aggregateData []AggregateTokenStat
+	aggregateData := []AggregateTokenStat{}
 	for _, mint := range request.Tokens {
 		if agg, ok := aggregateTokenMap[mint]; ok {
 			aggregateData = append(aggregateData, *agg)
@@ -954,6 +1343,7 @@ func ReviewAddressesByTokenStat(c *gin.Context) {
 		"order_by":       request.OrderBy,
 		"data":           pageData,
 		"aggregate_data": aggregateData,
+		"pagination":     paginationMeta(request.Page, request.PageSize, int64(total)),
 	})
 }
 
@@ -1139,7 +1529,7 @@ func ListAddressConfigs(c *gin.Context) {
 	}
 
 	var addressConfigs []models.AddressConfig
-	if err := query.Find(&addressConfigs).Error; err != nil {
+	if err := query.Order("id desc").Find(&addressConfigs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1324,7 +1714,7 @@ func ListAddressConfigByRole(c *gin.Context) {
 type FilterRequest struct {
 	RoleID       int    `json:"role_id" binding:"required"`
 	Mint         string `json:"mint" binding:"required"`
-	PoolPlatform string `json:"pool_platform" binding:"omitempty"`
+	PoolPlatform models.PoolPlatform `json:"pool_platform" binding:"omitempty"`
 }
 
 // GetAddressConfigByFilter filters address configurations by role_id and mint
@@ -1364,7 +1754,7 @@ func GetAddressConfigByFilter(c *gin.Context) {
 	txCounts := make(map[string]uint)
 
 	switch request.PoolPlatform {
-	case "pumpfun_amm":
+	case models.PoolPlatformPumpfunAmm:
 		var holders []models.PumpfunAmmpoolHolder
 		if err := dbconfig.DB.Where("address IN ? AND (base_mint = ? OR quote_mint = ?)",
 			addresses, request.Mint, request.Mint).
@@ -1377,7 +1767,7 @@ func GetAddressConfigByFilter(c *gin.Context) {
 		for _, holder := range holders {
 			txCounts[holder.Address] = holder.TxCount
 		}
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var holders []models.PumpfuninternalHolder
 		if err := dbconfig.DB.Where("address IN ? AND mint = ?",
 			addresses, request.Mint).
@@ -1493,7 +1883,7 @@ type BatchUpdateDisposableAddressRequest struct {
 // ListDisposableAddresses returns a list of all disposable managed addresses
 func ListDisposableAddresses(c *gin.Context) {
 	var addresses []models.DisposableAddressManage
-	if err := dbconfig.DB.Find(&addresses).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&addresses).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1873,121 +2263,50 @@ func ExportWithNewPasswordInDisposableAddressManage(c *gin.Context) {
 	})
 }
 
-// ImportAndVerifyPasswordInDisposableAddressManage handles the import and verification of disposable addresses from a JSON file
+// ImportAndVerifyPasswordInDisposableAddressManage handles the import and verification of disposable addresses
+// from a JSON file, the same way as ImportAndVerifyPassword: the password is checked against ENCRYPTPASSWORD or a
+// sample of existing disposable addresses, and invalid entries are reported instead of aborting the import.
 func ImportAndVerifyPasswordInDisposableAddressManage(c *gin.Context) {
-	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error()})
-		return
-	}
-
-	// Get password from form
-	password := c.Request.FormValue("password")
-	if password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Password is required"})
-		return
-	}
-
-	// Get uploaded file
-	file, _, err := c.Request.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to get file: " + err.Error()})
-		return
-	}
-	defer file.Close()
-
-	// Read and parse the JSON file
-	var importData struct {
-		Addresses []ExportAddress `json:"addresses"`
-	}
-	if err := json.NewDecoder(file).Decode(&importData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse JSON file: " + err.Error()})
+	password, concurrency, addresses, ok := readImportForm(c)
+	if !ok {
 		return
 	}
 
-	// Create key manager
 	km := solana.NewKeyManager()
-
-	// First verify existing disposable addresses in database
-	var existingAddresses []models.DisposableAddressManage
-	if err := dbconfig.DB.Find(&existingAddresses).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch existing disposable addresses: " + err.Error()})
+	if !checkImportPassword(c, km, password, &models.DisposableAddressManage{}) {
 		return
 	}
-
-	// Verify existing addresses
-	for _, addr := range existingAddresses {
-		decryptedKey, err := km.DecryptPrivateKey(addr.PrivateKey, password)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to decrypt existing disposable address %s: %v", addr.Address, err)})
-			return
-		}
-
-		account, err := types.AccountFromBytes(decryptedKey)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create account for existing disposable address %s: %v", addr.Address, err)})
-			return
-		}
-
-		if account.PublicKey.ToBase58() != addr.Address {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Address mismatch for existing disposable address %s", addr.Address)})
-			return
-		}
-	}
-
-	// Verify imported addresses
-	existingAddressMap := make(map[string]bool)
-	for _, addr := range existingAddresses {
-		existingAddressMap[addr.Address] = true
+	toVerify, skipped, ok := newImportAddresses(c, &models.DisposableAddressManage{}, addresses)
+	if !ok {
+		return
 	}
+	valid, failures := verifyImportAddresses(km, toVerify, password, concurrency)
 
-	// Track new addresses to import
-	var newAddresses []models.DisposableAddressManage
-
-	// Verify and prepare imported addresses
-	for _, importAddr := range importData.Addresses {
-		// Skip if address already exists
-		if existingAddressMap[importAddr.Address] {
-			continue
-		}
-
-		// Verify the imported address
-		decryptedKey, err := km.DecryptPrivateKey(importAddr.PrivateKey, password)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to decrypt imported disposable address %s: %v", importAddr.Address, err)})
-			return
-		}
-
-		account, err := types.AccountFromBytes(decryptedKey)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to create account for imported disposable address %s: %v", importAddr.Address, err)})
-			return
-		}
-
-		if account.PublicKey.ToBase58() != importAddr.Address {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Address mismatch for imported disposable address %s", importAddr.Address)})
-			return
-		}
-
-		// Add to new addresses list
-		newAddresses = append(newAddresses, models.DisposableAddressManage{
-			Address:    importAddr.Address,
-			PrivateKey: importAddr.PrivateKey,
-		})
+	newAddresses := make([]models.DisposableAddressManage, len(valid))
+	for i, addr := range valid {
+		newAddresses[i] = models.DisposableAddressManage{Address: addr.Address, PrivateKey: addr.PrivateKey}
 	}
 
 	// Import new addresses
+	imported := int64(0)
 	if len(newAddresses) > 0 {
-		if err := dbconfig.DB.Create(&newAddresses).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import new disposable addresses: " + err.Error()})
+		result := dbconfig.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&newAddresses, 500)
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import new disposable addresses: " + result.Error.Error(), "failures": failures})
 			return
 		}
+		imported = result.RowsAffected
+		// 并发导入时可能已被其他请求写入
+		skipped += len(newAddresses) - int(imported)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":        fmt.Sprintf("Successfully imported %d new disposable addresses", len(newAddresses)),
-		"imported_count": len(newAddresses),
-		"skipped_count":  len(importData.Addresses) - len(newAddresses),
+		"message":        fmt.Sprintf("Successfully imported %d new disposable addresses", imported),
+		"total_count":    len(addresses),
+		"imported_count": imported,
+		"skipped_count":  skipped,
+		"failed_count":   len(failures),
+		"failures":       failures,
 	})
 }
 
@@ -2202,7 +2521,7 @@ func MultiTransferSol(c *gin.Context) {
 	}()
 
 	// Collect results
-	var results []TransferSolResult
+	results := []TransferSolResult{}
 	for res := range resultCh {
 		results = append(results, res)
 	}
@@ -2309,8 +2628,7 @@ func executeSolTransfer(client *rpc.Client, task SolTransferTask) TransferSolRes
 // ImportCsv handles the import of addresses from a CSV file
 func ImportCsv(c *gin.Context) {
 	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error()})
+	if !parseImportForm(c) {
 		return
 	}
 
@@ -2451,8 +2769,7 @@ func ImportCsv(c *gin.Context) {
 // Private keys will be encrypted before storing in the database
 func ImportCsvWithBase58(c *gin.Context) {
 	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error()})
+	if !parseImportForm(c) {
 		return
 	}
 
@@ -2617,8 +2934,7 @@ func ImportCsvWithBase58(c *gin.Context) {
 // ImportCsvInDisposableAddressManage handles the import of disposable addresses from a CSV file
 func ImportCsvInDisposableAddressManage(c *gin.Context) {
 	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form: " + err.Error()})
+	if !parseImportForm(c) {
 		return
 	}
 
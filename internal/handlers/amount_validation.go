@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// maxAbsAmount bounds the magnitude of amounts accepted by the swap/holder handlers. Larger values
+// are not real token or SOL amounts and would dominate every SUM over the table.
+const maxAbsAmount = 1e18
+
+// validateAmounts rejects NaN, ±Inf and out-of-range values in the float64 fields of a request struct,
+// so a single poison row cannot corrupt the aggregation endpoints. Errors name the JSON field.
+func validateAmounts(req interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Float64 && field.Kind() != reflect.Float32 {
+			continue
+		}
+
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		amount := field.Float()
+		if math.IsNaN(amount) || math.IsInf(amount, 0) {
+			return fmt.Errorf("%s must be a finite number", name)
+		}
+		if math.Abs(amount) > maxAbsAmount {
+			return fmt.Errorf("%s is out of range (|value| must not exceed %g)", name, maxAbsAmount)
+		}
+	}
+	return nil
+}
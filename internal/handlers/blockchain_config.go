@@ -19,7 +19,7 @@ type BlockchainConfigRequest struct {
 // ListBlockchainConfigs returns a list of all blockchain configurations
 func ListBlockchainConfigs(c *gin.Context) {
 	var configs []models.BlockchainConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
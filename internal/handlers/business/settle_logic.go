@@ -7,6 +7,7 @@ import (
 
 	"marketcontrol/internal/models"
 	dbconfig "marketcontrol/pkg/config"
+	mcsolana "marketcontrol/pkg/solana"
 	"marketcontrol/pkg/utils"
 
 	"github.com/sirupsen/logrus"
@@ -49,13 +50,17 @@ type SettleStats struct {
 	SolByRetailInvestors             float64 `json:"sol_by_retail_investors"`
 }
 
-// getProjectInitialFunds 获取项目初始资金
+// getProjectInitialFunds 获取项目初始资金；SOL 同时统计 "sol" 与 WSOL 两种 mint 的记录
 func getProjectInitialFunds(projectID uint, mint string, targetName string) (float64, error) {
 	var transferRecords []models.ProjectFundTransferRecord
 	var initialAmount float64 = 0
 
-	if err := dbconfig.DB.Where("project_id = ? AND mint = ? AND target_name = ?",
-		projectID, mint, targetName).Find(&transferRecords).Error; err != nil {
+	mints := []string{mint}
+	if mcsolana.IsSolMint(mint) {
+		mints = mcsolana.SolMints()
+	}
+	if err := dbconfig.DB.Where("project_id = ? AND mint IN ? AND target_name = ?",
+		projectID, mints, targetName).Find(&transferRecords).Error; err != nil {
 		return 0, err
 	}
 
@@ -0,0 +1,7 @@
+package handlers
+
+import "marketcontrol/pkg/clock"
+
+// handlerClock supplies "now" for the relative time windows computed by handlers (last N hours/days,
+// retention cutoffs); tests replace it with a clock.Fake
+var handlerClock clock.Clock = clock.Real{}
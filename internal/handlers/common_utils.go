@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sort"
+	"sync"
 	"time"
 
+	"marketcontrol/internal/middleware"
 	"marketcontrol/internal/models"
 	"marketcontrol/pkg/config"
 	dbconfig "marketcontrol/pkg/config"
@@ -216,7 +220,7 @@ func GetTokenInfoHandler(c *gin.Context) {
 	mintPubkey := solana.MustPublicKeyFromBase58(req.Mint)
 
 	// Get token metadata
-	metadata, err := mcsolana.GetTokenMetadata(solanaClient, mintPubkey)
+	metadata, err := mcsolana.CachedTokenMetadata(solanaClient, mintPubkey)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get token metadata: %v", err)})
 		return
@@ -581,7 +585,7 @@ func GetLaunchpadPoolInfo(c *gin.Context) {
 	}
 
 	// Get launchpad pool data
-	poolData, err := mcsolana.GetLaunchpadPoolInfo(req.RpcEndpoint, poolId)
+	poolData, err := mcsolana.CachedLaunchpadPoolInfo(req.RpcEndpoint, poolId)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get launchpad pool data: %v", err)})
 		return
@@ -643,21 +647,20 @@ func GetCpmmPoolInfo(c *gin.Context) {
 		return
 	}
 
-	// Get CPMM pool info using batch function with single pool ID
-	poolInfos, err := mcsolana.GetCpmmPoolInfoBatch([]string{req.PoolId})
+	// Get CPMM pool info (cached briefly to absorb dashboard refreshes)
+	poolInfo, err := mcsolana.CachedCpmmPoolInfo(req.PoolId)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get CPMM pool info: %v", err)})
 		return
 	}
 
 	// Check if pool was found
-	if len(poolInfos) == 0 {
+	if poolInfo == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Pool not found"})
 		return
 	}
 
-	// Return the first (and should be only) pool info
-	c.JSON(http.StatusOK, poolInfos[0])
+	c.JSON(http.StatusOK, poolInfo)
 }
 
 // GetPumpFunPDARequest represents the request body for getting PumpFun PDAs
@@ -861,7 +864,7 @@ func GetAccountInfo(c *gin.Context) {
 	client := rpc.New(solanaRPC)
 
 	// Get SOL balance
-	solBalance, solUpdateTime, err := mcsolana.GetSolBalance(client, ownerPubkey)
+	solBalance, solUpdateTime, err := mcsolana.CachedSolBalance(client, ownerPubkey)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get SOL balance: %v", err)})
 		return
@@ -875,9 +878,9 @@ func GetAccountInfo(c *gin.Context) {
 	}
 
 	// Get token balances for each token config
-	var tokenBalances []TokenBalanceItem
+	tokenBalances := []TokenBalanceItem{}
 	for _, tokenConfig := range tokenConfigs {
-		balance, _, err := mcsolana.GetTokenBalance(dbconfig.DB, client, ownerPubkey, tokenConfig.Mint)
+		balance, _, err := mcsolana.CachedTokenBalance(dbconfig.DB, client, ownerPubkey, tokenConfig.Mint)
 		if err != nil {
 			// Log error but continue with other tokens
 			fmt.Printf("Failed to get balance for token %s (%s): %v\n", tokenConfig.Symbol, tokenConfig.Mint, err)
@@ -986,16 +989,17 @@ func ControlPoolMonitor(c *gin.Context) {
 	}
 
 	// Check if RabbitMQ is initialized
-	if config.RabbitMQ == nil {
+	if config.CurrentRabbitMQ() == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "RabbitMQ not initialized"})
 		return
 	}
 
-	// Handle in goroutine to avoid blocking
+	// Handle in goroutine to avoid blocking; the logger carries the request ID
+	logger := middleware.RequestLogger(c)
 	go func() {
 		publisher, err := config.NewPublisher()
 		if err != nil {
-			log.Errorf("Failed to create RabbitMQ publisher: %v", err)
+			logger.Errorf("Failed to create RabbitMQ publisher: %v", err)
 			return
 		}
 		defer publisher.Close()
@@ -1020,10 +1024,10 @@ func ControlPoolMonitor(c *gin.Context) {
 		}
 
 		// Publish message
-		if err := publisher.Publish("meteora_pool_monitor", monitorMsg); err != nil {
-			log.Errorf("Failed to publish monitoring message: %v", err)
+		if err := publisher.Publish(meteora.PoolMonitorQueue, monitorMsg); err != nil {
+			logger.Errorf("Failed to publish monitoring message: %v", err)
 		} else {
-			log.Infof("Published %s monitoring task for pool: %s",
+			logger.Infof("Published %s monitoring task for pool: %s",
 				request.Action, request.PoolAddress)
 		}
 	}()
@@ -1036,6 +1040,252 @@ func ControlPoolMonitor(c *gin.Context) {
 	})
 }
 
+// monitorPublishAttempts bounds how many times a monitoring message publication is retried
+const monitorPublishAttempts = 3
+
+// publishMeteoraMonitoring publishes a start_monitoring message for a Meteora project, logging through
+// logger (tagged with the originating request ID).
+func publishMeteoraMonitoring(logger *log.Entry, projectID uint, dbcCfg *models.MeteoradbcConfig, cpmmCfg *models.MeteoracpmmConfig) error {
+	if config.CurrentRabbitMQ() == nil {
+		logger.Warn("RabbitMQ not initialized, skipping monitoring task publication")
+		return nil
+	}
+
+	monitorMsg := meteora.PoolMonitorMessage{
+		Action:               "start_monitoring",
+		MeteoradbcAddress:    dbcCfg.PoolAddress,
+		ProjectID:            projectID,
+		BaseTokenMint:        dbcCfg.BaseMint,
+		QuoteTokenMint:       dbcCfg.QuoteMint,
+		MeteoraDbcAuthority:  meteora.DbcAuthority(),
+		MeteoraCpmmAuthority: meteora.CpmmAuthority(),
+	}
+
+	meteoracpmmAddr := ""
+	if cpmmCfg != nil {
+		meteoracpmmAddr = cpmmCfg.PoolAddress
+		monitorMsg.MeteoracpmmAddress = cpmmCfg.PoolAddress
+		// Use Meteoracpmm token info if available
+		if cpmmCfg.BaseMint != "" {
+			monitorMsg.BaseTokenMint = cpmmCfg.BaseMint
+		}
+		if cpmmCfg.QuoteMint != "" {
+			monitorMsg.QuoteTokenMint = cpmmCfg.QuoteMint
+		}
+	}
+
+	if err := publishMonitorMessage(monitorMsg); err != nil {
+		logger.Errorf("Giving up publishing monitoring task for project %d after %d attempts: %v", projectID, monitorPublishAttempts, err)
+		return err
+	}
+	logger.Infof("Published monitoring task for project %d: Meteoradbc=%s, Meteoracpmm=%s",
+		projectID, dbcCfg.PoolAddress, meteoracpmmAddr)
+	return nil
+}
+
+// publishMeteoraStopMonitoring publishes a stop_monitoring message for the given DBC/CPMM addresses
+func publishMeteoraStopMonitoring(dbcAddress, cpmmAddress string) error {
+	if config.CurrentRabbitMQ() == nil {
+		return fmt.Errorf("RabbitMQ not initialized")
+	}
+	return publishMonitorMessage(meteora.PoolMonitorMessage{
+		Action:             "stop_monitoring",
+		MeteoradbcAddress:  dbcAddress,
+		MeteoracpmmAddress: cpmmAddress,
+	})
+}
+
+// errPlatformNotMonitored is returned by publishMonitorToggle for pools the worker does not monitor
+var errPlatformNotMonitored = errors.New("platform is not monitored by the worker")
+
+// publishMonitorToggle publishes start_monitoring (enabled) or stop_monitoring for a single monitored address,
+// resolving its platform through the pool configs. Only meteora pools are monitored by the worker.
+func publishMonitorToggle(address string, enabled bool) error {
+	if config.CurrentRabbitMQ() == nil {
+		return fmt.Errorf("RabbitMQ not initialized")
+	}
+
+	platform, _, pool, err := resolvePoolByAddress(dbconfig.DB, address)
+	if err != nil {
+		return err
+	}
+
+	monitorMsg := meteora.PoolMonitorMessage{Action: "stop_monitoring"}
+	if enabled {
+		monitorMsg = meteora.PoolMonitorMessage{
+			Action:               "start_monitoring",
+			MeteoraDbcAuthority:  meteora.DbcAuthority(),
+			MeteoraCpmmAuthority: meteora.CpmmAuthority(),
+		}
+	}
+	switch platform {
+	case models.PoolPlatformMeteoraDbc:
+		dbcCfg := pool.(*models.MeteoradbcConfig)
+		monitorMsg.MeteoradbcAddress = dbcCfg.PoolAddress
+		if enabled {
+			monitorMsg.BaseTokenMint, monitorMsg.QuoteTokenMint = dbcCfg.BaseMint, dbcCfg.QuoteMint
+		}
+	case models.PoolPlatformMeteoraCpmm:
+		cpmmCfg := pool.(*models.MeteoracpmmConfig)
+		monitorMsg.MeteoracpmmAddress = cpmmCfg.PoolAddress
+		if enabled {
+			monitorMsg.BaseTokenMint, monitorMsg.QuoteTokenMint = cpmmCfg.BaseMint, cpmmCfg.QuoteMint
+		}
+	case "":
+		return fmt.Errorf("no pool config found for address %s", address)
+	default:
+		return fmt.Errorf("platform %s: %w", platform, errPlatformNotMonitored)
+	}
+
+	return publishMonitorMessage(monitorMsg)
+}
+
+// publishMonitorMessage publishes a message to the pool monitor queue with retries.
+// Each attempt uses a fresh publisher so a dropped channel doesn't poison the retries.
+func publishMonitorMessage(monitorMsg meteora.PoolMonitorMessage) error {
+	var lastErr error
+	for attempt := 1; attempt <= monitorPublishAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * time.Second)
+		}
+
+		publisher, err := config.NewPublisher()
+		if err != nil {
+			lastErr = err
+			log.Warnf("Failed to create RabbitMQ publisher (attempt %d/%d): %v", attempt, monitorPublishAttempts, err)
+			continue
+		}
+		err = publisher.Publish(meteora.PoolMonitorQueue, monitorMsg)
+		publisher.Close()
+		if err != nil {
+			lastErr = err
+			log.Warnf("Failed to publish monitoring message (attempt %d/%d): %v", attempt, monitorPublishAttempts, err)
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// republishMonitoringConcurrency bounds how many projects are published in parallel
+const republishMonitoringConcurrency = 5
+
+// RepublishFailure records a project whose monitoring task could not be re-published
+type RepublishFailure struct {
+	ProjectID uint                `json:"project_id"`
+	Platform  models.PoolPlatform `json:"platform"`
+	Error     string              `json:"error"`
+}
+
+// loadMeteoraMonitorPools resolves the DBC and (optional) CPMM configs for a meteora project
+func loadMeteoraMonitorPools(project models.ProjectConfig) (*models.MeteoradbcConfig, *models.MeteoracpmmConfig, error) {
+	switch project.PoolPlatform {
+	case models.PoolPlatformMeteoraDbc:
+		var dbcCfg models.MeteoradbcConfig
+		if err := dbconfig.DB.First(&dbcCfg, project.PoolID).Error; err != nil {
+			return nil, nil, fmt.Errorf("meteoradbc config %d: %w", project.PoolID, err)
+		}
+		var cpmmCfgs []models.MeteoracpmmConfig
+		if err := dbconfig.DB.Where("dbc_pool_address = ?", dbcCfg.PoolAddress).Order("id desc").Limit(1).Find(&cpmmCfgs).Error; err != nil {
+			return nil, nil, err
+		}
+		if len(cpmmCfgs) > 0 {
+			return &dbcCfg, &cpmmCfgs[0], nil
+		}
+		return &dbcCfg, nil, nil
+	case models.PoolPlatformMeteoraCpmm:
+		var cpmmCfg models.MeteoracpmmConfig
+		if err := dbconfig.DB.First(&cpmmCfg, project.PoolID).Error; err != nil {
+			return nil, nil, fmt.Errorf("meteoracpmm config %d: %w", project.PoolID, err)
+		}
+		// 跳过 DBC 直接创建的 CPMM 池子没有对应的 DBC 配置
+		dbcCfg := &models.MeteoradbcConfig{BaseMint: cpmmCfg.BaseMint, QuoteMint: cpmmCfg.QuoteMint}
+		if cpmmCfg.DbcPoolAddress != "" {
+			var dbcCfgs []models.MeteoradbcConfig
+			if err := dbconfig.DB.Where("pool_address = ?", cpmmCfg.DbcPoolAddress).Limit(1).Find(&dbcCfgs).Error; err != nil {
+				return nil, nil, err
+			}
+			if len(dbcCfgs) > 0 {
+				dbcCfg = &dbcCfgs[0]
+			}
+		}
+		return dbcCfg, &cpmmCfg, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported pool platform: %s", project.PoolPlatform)
+}
+
+// RepublishAllMonitoring re-publishes start_monitoring messages for every active meteora_dbc/meteora_cpmm project
+// Query parameters: platform (optional, meteora_dbc or meteora_cpmm)
+func RepublishAllMonitoring(c *gin.Context) {
+	if config.CurrentRabbitMQ() == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "RabbitMQ not initialized"})
+		return
+	}
+
+	platforms := []models.PoolPlatform{models.PoolPlatformMeteoraDbc, models.PoolPlatformMeteoraCpmm}
+	if platform := models.PoolPlatform(c.Query("platform")); platform != "" {
+		if !platform.IsMeteora() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "platform must be meteora_dbc or meteora_cpmm"})
+			return
+		}
+		platforms = []models.PoolPlatform{platform}
+	}
+
+	var projects []models.ProjectConfig
+	if err := dbconfig.DB.Where("is_active = ? AND pool_platform IN ?", true, platforms).
+		Order("id asc").Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	logger := middleware.RequestLogger(c)
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		published []uint
+	)
+	failures := []RepublishFailure{}
+	sem := make(chan struct{}, republishMonitoringConcurrency)
+
+	for _, project := range projects {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(project models.ProjectConfig) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			dbcCfg, cpmmCfg, err := loadMeteoraMonitorPools(project)
+			if err == nil {
+				err = publishMeteoraMonitoring(logger, project.ID, dbcCfg, cpmmCfg)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, RepublishFailure{ProjectID: project.ID, Platform: project.PoolPlatform, Error: err.Error()})
+				return
+			}
+			published = append(published, project.ID)
+		}(project)
+	}
+	wg.Wait()
+
+	sort.Slice(published, func(i, j int) bool { return published[i] < published[j] })
+	sort.Slice(failures, func(i, j int) bool { return failures[i].ProjectID < failures[j].ProjectID })
+	if published == nil {
+		published = []uint{}
+	}
+
+	log.Infof("Republished monitoring for %d/%d meteora projects", len(published), len(projects))
+	c.JSON(http.StatusOK, gin.H{
+		"total":           len(projects),
+		"published_count": len(published),
+		"failed_count":    len(failures),
+		"published":       published,
+		"failures":        failures,
+	})
+}
+
 // GetMultiAccountsInfoRequest represents the request body for getting multiple accounts information
 type GetMultiAccountsInfoRequest struct {
 	Accounts []string `json:"accounts" binding:"required,min=1"`
@@ -1281,3 +1531,54 @@ func VpnControllerChangeProxy(c *gin.Context) {
 		"proxy_name": req.ProxyName,
 	})
 }
+
+// solanaRPCAttempts bounds how many times each RPC endpoint is tried before failing over
+const solanaRPCAttempts = 2
+
+// solanaRPCEndpoints returns DEFAULT_SOLANA_RPC followed by the active rpc_configs endpoints, de-duplicated.
+func solanaRPCEndpoints() []string {
+	var endpoints []string
+	seen := make(map[string]bool)
+	add := func(endpoint string) {
+		if endpoint == "" || seen[endpoint] {
+			return
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+
+	add(os.Getenv("DEFAULT_SOLANA_RPC"))
+
+	var configs []models.RpcConfig
+	if err := dbconfig.DB.Where("is_active = ?", true).Order("id").Find(&configs).Error; err != nil {
+		log.Warnf("Failed to load rpc configs for failover: %v", err)
+	}
+	for _, cfg := range configs {
+		add(cfg.Endpoint)
+	}
+	return endpoints
+}
+
+// withSolanaRPCFailover runs fn against each endpoint in turn, retrying each one
+// with a short backoff, and returns the first success or the last error.
+func withSolanaRPCFailover(endpoints []string, fn func(client *rpc.Client) error) error {
+	if len(endpoints) == 0 {
+		return fmt.Errorf("Solana RPC endpoint not configured")
+	}
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		client := rpc.New(endpoint)
+		for attempt := 1; attempt <= solanaRPCAttempts; attempt++ {
+			if attempt > 1 {
+				time.Sleep(time.Duration(attempt-1) * 500 * time.Millisecond)
+			}
+			if lastErr = fn(client); lastErr == nil {
+				return nil
+			}
+			// endpoint URLs may embed API keys, so only the index is logged
+			log.Warnf("Solana RPC call failed on endpoint %d/%d (attempt %d/%d): %v", i+1, len(endpoints), attempt, solanaRPCAttempts, lastErr)
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// fieldsParam is the query parameter selecting which columns a list endpoint returns (?fields=slot,signature)
+const fieldsParam = "fields"
+
+// selectableColumns caches the allowlist of each model type, keyed by reflect.Type
+var selectableColumns sync.Map
+
+// selectableColumnsOf returns the columns of T that may be requested through ?fields=:
+// every column whose JSON key equals its column name, so the projected output keeps the usual keys.
+func selectableColumnsOf[T any]() (map[string]bool, error) {
+	var model T
+	typ := reflect.TypeOf(model)
+	if cached, ok := selectableColumns.Load(typ); ok {
+		return cached.(map[string]bool), nil
+	}
+
+	stmt := &gorm.Statement{DB: dbconfig.DB}
+	if err := stmt.Parse(&model); err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]bool)
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if jsonKey := strings.Split(field.Tag.Get("json"), ",")[0]; jsonKey == field.DBName {
+			allowed[field.DBName] = true
+		}
+	}
+	selectableColumns.Store(typ, allowed)
+	return allowed, nil
+}
+
+// parseFields validates ?fields= against the allowlist of T. It returns nil when the parameter is absent.
+// On failure the error response has already been written and ok is false.
+func parseFields[T any](c *gin.Context) ([]string, bool) {
+	raw := strings.TrimSpace(c.Query(fieldsParam))
+	if raw == "" {
+		return nil, true
+	}
+
+	allowed, err := selectableColumnsOf[T]()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	seen := make(map[string]bool)
+	var fields, unknown []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		if !allowed[field] {
+			unknown = append(unknown, field)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(unknown) > 0 || len(fields) == 0 {
+		columns := make([]string, 0, len(allowed))
+		for column := range allowed {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":          fmt.Sprintf("Invalid fields: %s", strings.Join(unknown, ", ")),
+			"allowed_fields": columns,
+		})
+		return nil, false
+	}
+	return fields, true
+}
+
+// findWithFields runs query over the table of T. Without ?fields= it returns []T as before;
+// with it only the requested columns are selected and each row is returned as a column map.
+// Rows are paginated by page/page_size, or capped by MAX_UNBOUNDED_ROWS without them (see findBounded).
+// On failure the error response has already been written and ok is false.
+func findWithFields[T any](c *gin.Context, query *gorm.DB) (interface{}, bool) {
+	fields, ok := parseFields[T](c)
+	if !ok {
+		return nil, false
+	}
+
+	var model T
+	if fields == nil {
+		var rows []T
+		if !findBounded(c, query.Model(&model), &rows) {
+			return nil, false
+		}
+		return rows, true
+	}
+
+	rows := []map[string]interface{}{}
+	if !findBounded(c, query.Model(&model).Select(fields), &rows) {
+		return nil, false
+	}
+	return rows, true
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz reports database and RabbitMQ connectivity.
+// Returns 503 when the database is unreachable; a disconnected RabbitMQ only marks the service degraded
+// since the API keeps serving and the broker is re-dialed in the background.
+func Healthz(c *gin.Context) {
+	status := http.StatusOK
+	overall := "ok"
+
+	database := gin.H{"connected": false}
+	if dbconfig.DB != nil {
+		if sqlDB, err := dbconfig.DB.DB(); err != nil {
+			database["error"] = err.Error()
+		} else {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+			defer cancel()
+			if err := sqlDB.PingContext(ctx); err != nil {
+				database["error"] = err.Error()
+			} else {
+				database["connected"] = true
+			}
+		}
+	}
+	if database["connected"] != true {
+		status = http.StatusServiceUnavailable
+		overall = "unavailable"
+	}
+
+	rabbitmq := gin.H{"configured": os.Getenv("RABBITMQ_HOST") != ""}
+	if rabbitmq["configured"] == true {
+		state := dbconfig.RabbitMQStatus()
+		rabbitmq["state"] = state
+		if !state.Connected && overall == "ok" {
+			overall = "degraded"
+		}
+	}
+
+	c.JSON(status, gin.H{
+		"status":   overall,
+		"database": database,
+		"rabbitmq": rabbitmq,
+	})
+}
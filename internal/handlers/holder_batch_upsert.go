@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// holderUpsertReplace overwrites the stored aggregates with the request values (default)
+	holderUpsertReplace = "replace"
+	// holderUpsertIncrement adds the request changes to the stored aggregates and widens the slot range
+	holderUpsertIncrement = "increment"
+
+	holderUpsertBatchSize = 500
+	maxBatchUpsertHolders = 10000
+)
+
+// BatchUpsertHoldersRequest is the body of the holder batch-upsert endpoints.
+// R is the platform's single-holder request type.
+type BatchUpsertHoldersRequest[R any] struct {
+	Mode    string `json:"mode"`
+	Holders []R    `json:"holders" binding:"required,min=1,dive"`
+}
+
+// HolderUpsertFailure describes a batch that failed to write
+type HolderUpsertFailure struct {
+	Offset int    `json:"offset"`
+	Count  int    `json:"count"`
+	Error  string `json:"error"`
+}
+
+// holderUpsertSpec describes the conflict key and aggregate columns of a holder table
+type holderUpsertSpec struct {
+	table      string
+	keyColumns []string
+	// sumColumns are added together in increment mode, tx_count included
+	sumColumns []string
+}
+
+var (
+	pairHolderKey = []string{"address", "pool_address", "base_mint", "quote_mint"}
+	pairHolderSum = []string{"base_change", "quote_change", "sol_change", "tx_count"}
+)
+
+// onConflict builds the ON CONFLICT clause for mode. Slot range columns are kept consistent with
+// the per-swap worker logic: start_* follow the smallest non-zero start_slot, last_* the largest last_slot.
+func (s holderUpsertSpec) onConflict(mode string) clause.OnConflict {
+	keys := make([]clause.Column, len(s.keyColumns))
+	for i, column := range s.keyColumns {
+		keys[i] = clause.Column{Name: column}
+	}
+
+	if mode == holderUpsertReplace {
+		columns := []string{"holder_type", "last_slot", "start_slot", "last_timestamp", "start_timestamp", "end_signature", "start_signature"}
+		columns = append(columns, s.sumColumns...)
+		columns = append(columns, "updated_at")
+		return clause.OnConflict{Columns: keys, DoUpdates: clause.AssignmentColumns(columns)}
+	}
+
+	t := s.table
+	set := clause.Set{
+		{Column: clause.Column{Name: "holder_type"}, Value: gorm.Expr(fmt.Sprintf("COALESCE(NULLIF(EXCLUDED.holder_type, ''), %s.holder_type)", t))},
+		{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("EXCLUDED.updated_at")},
+	}
+	earlier := fmt.Sprintf("EXCLUDED.start_slot > 0 AND (%[1]s.start_slot = 0 OR EXCLUDED.start_slot < %[1]s.start_slot)", t)
+	for _, column := range []string{"start_slot", "start_timestamp", "start_signature"} {
+		set = append(set, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr(fmt.Sprintf("CASE WHEN %s THEN EXCLUDED.%s ELSE %s.%s END", earlier, column, t, column)),
+		})
+	}
+	later := fmt.Sprintf("EXCLUDED.last_slot > %s.last_slot", t)
+	for _, column := range []string{"last_slot", "last_timestamp", "end_signature"} {
+		set = append(set, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr(fmt.Sprintf("CASE WHEN %s THEN EXCLUDED.%s ELSE %s.%s END", later, column, t, column)),
+		})
+	}
+	for _, column := range s.sumColumns {
+		set = append(set, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr(fmt.Sprintf("%s.%s + EXCLUDED.%s", t, column, column)),
+		})
+	}
+	return clause.OnConflict{Columns: keys, DoUpdates: set}
+}
+
+// parseHolderUpsertMode validates the mode and the holder amounts of a batch-upsert request.
+// On failure the error response has already been written and ok is false.
+func parseHolderUpsertMode[R any](c *gin.Context, mode string, holders []R) (string, bool) {
+	if mode == "" {
+		mode = holderUpsertReplace
+	}
+	if mode != holderUpsertReplace && mode != holderUpsertIncrement {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode 必须是 replace 或 increment"})
+		return "", false
+	}
+	if len(holders) > maxBatchUpsertHolders {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("最多一次提交 %d 条 holder", maxBatchUpsertHolders)})
+		return "", false
+	}
+	for i, h := range holders {
+		if err := validateAmounts(h); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("holders[%d]: %s", i, err.Error())})
+			return "", false
+		}
+	}
+	return mode, true
+}
+
+// upsertHolders writes holders in batches of holderUpsertBatchSize, one transaction per batch.
+// Postgres rejects a statement touching the same row twice, so duplicate keys are refused up front.
+func upsertHolders[T any](c *gin.Context, spec holderUpsertSpec, mode string, holders []T, key func(T) string) {
+	seen := make(map[string]int, len(holders))
+	for i, holder := range holders {
+		k := key(holder)
+		if first, ok := seen[k]; ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("holders[%d] 与 holders[%d] 的主键重复", i, first)})
+			return
+		}
+		seen[k] = i
+	}
+	// ON CONFLICT 依赖唯一索引，索引缺失时每个批次都会失败，提前返回明确的错误
+	if !checkUniqueKeyIndex(c, spec.table) {
+		return
+	}
+
+	conflict := spec.onConflict(mode)
+	var upserted int64
+	failed := 0
+	failures := []HolderUpsertFailure{}
+	for offset := 0; offset < len(holders); offset += holderUpsertBatchSize {
+		end := offset + holderUpsertBatchSize
+		if end > len(holders) {
+			end = len(holders)
+		}
+		batch := holders[offset:end]
+
+		var affected int64
+		err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(conflict).Create(&batch)
+			affected = result.RowsAffected
+			return result.Error
+		})
+		if isMissingConflictTarget(err) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":    "unique index missing: " + err.Error(),
+				"upserted": upserted,
+				"failed":   len(holders) - offset + failed,
+			})
+			return
+		}
+		if err != nil {
+			failed += len(batch)
+			failures = append(failures, HolderUpsertFailure{Offset: offset, Count: len(batch), Error: err.Error()})
+			continue
+		}
+		upserted += affected
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mode":     mode,
+		"total":    len(holders),
+		"upserted": upserted,
+		"failed":   failed,
+		"failures": failures,
+	})
+}
+
+// isMissingConflictTarget reports whether err is Postgres' "no unique or exclusion constraint matching the
+// ON CONFLICT specification" (SQLSTATE 42P10), i.e. the unique index was dropped after the up-front check
+func isMissingConflictTarget(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	return errors.As(err, &sqlErr) && sqlErr.SQLState() == "42P10"
+}
+
+func pairHolderKeyOf(address, poolAddress, baseMint, quoteMint string) string {
+	return address + "|" + poolAddress + "|" + baseMint + "|" + quoteMint
+}
+
+// BatchUpsertMeteoradbcHolders inserts or updates many Meteoradbc holders keyed by
+// (address, pool_address, base_mint, quote_mint)
+func BatchUpsertMeteoradbcHolders(c *gin.Context) {
+	var req BatchUpsertHoldersRequest[MeteoradbcHolderRequest]
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := parseHolderUpsertMode(c, req.Mode, req.Holders)
+	if !ok {
+		return
+	}
+
+	holders := make([]models.MeteoradbcHolder, len(req.Holders))
+	for i, h := range req.Holders {
+		holders[i] = models.MeteoradbcHolder{
+			Address:        h.Address,
+			HolderType:     h.HolderType,
+			PoolAddress:    h.PoolAddress,
+			BaseMint:       h.BaseMint,
+			QuoteMint:      h.QuoteMint,
+			LastSlot:       h.LastSlot,
+			StartSlot:      h.StartSlot,
+			LastTimestamp:  h.LastTimestamp,
+			StartTimestamp: h.StartTimestamp,
+			EndSignature:   h.EndSignature,
+			StartSignature: h.StartSignature,
+			BaseChange:     h.BaseChange,
+			QuoteChange:    h.QuoteChange,
+			SolChange:      h.SolChange,
+			TxCount:        h.TxCount,
+		}
+	}
+
+	spec := holderUpsertSpec{table: models.MeteoradbcHolder{}.TableName(), keyColumns: pairHolderKey, sumColumns: pairHolderSum}
+	upsertHolders(c, spec, mode, holders, func(h models.MeteoradbcHolder) string {
+		return pairHolderKeyOf(h.Address, h.PoolAddress, h.BaseMint, h.QuoteMint)
+	})
+}
+
+// BatchUpsertMeteoracpmmHolders inserts or updates many Meteoracpmm holders keyed by
+// (address, pool_address, base_mint, quote_mint)
+func BatchUpsertMeteoracpmmHolders(c *gin.Context) {
+	var req BatchUpsertHoldersRequest[MeteoracpmmHolderRequest]
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := parseHolderUpsertMode(c, req.Mode, req.Holders)
+	if !ok {
+		return
+	}
+
+	holders := make([]models.MeteoracpmmHolder, len(req.Holders))
+	for i, h := range req.Holders {
+		holders[i] = models.MeteoracpmmHolder{
+			Address:        h.Address,
+			HolderType:     h.HolderType,
+			PoolAddress:    h.PoolAddress,
+			BaseMint:       h.BaseMint,
+			QuoteMint:      h.QuoteMint,
+			LastSlot:       h.LastSlot,
+			StartSlot:      h.StartSlot,
+			LastTimestamp:  h.LastTimestamp,
+			StartTimestamp: h.StartTimestamp,
+			EndSignature:   h.EndSignature,
+			StartSignature: h.StartSignature,
+			BaseChange:     h.BaseChange,
+			QuoteChange:    h.QuoteChange,
+			SolChange:      h.SolChange,
+			TxCount:        h.TxCount,
+		}
+	}
+
+	spec := holderUpsertSpec{table: models.MeteoracpmmHolder{}.TableName(), keyColumns: pairHolderKey, sumColumns: pairHolderSum}
+	upsertHolders(c, spec, mode, holders, func(h models.MeteoracpmmHolder) string {
+		return pairHolderKeyOf(h.Address, h.PoolAddress, h.BaseMint, h.QuoteMint)
+	})
+}
+
+// BatchUpsertRaydiumPoolHolders inserts or updates many Raydium pool holders keyed by
+// (address, pool_address, base_mint, quote_mint)
+func BatchUpsertRaydiumPoolHolders(c *gin.Context) {
+	var req BatchUpsertHoldersRequest[RaydiumPoolHolderRequest]
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := parseHolderUpsertMode(c, req.Mode, req.Holders)
+	if !ok {
+		return
+	}
+
+	holders := make([]models.RaydiumPoolHolder, len(req.Holders))
+	for i, h := range req.Holders {
+		holders[i] = models.RaydiumPoolHolder{
+			Address:        h.Address,
+			HolderType:     h.HolderType,
+			PoolAddress:    h.PoolAddress,
+			BaseMint:       h.BaseMint,
+			QuoteMint:      h.QuoteMint,
+			LastSlot:       h.LastSlot,
+			StartSlot:      h.StartSlot,
+			LastTimestamp:  h.LastTimestamp,
+			StartTimestamp: h.StartTimestamp,
+			EndSignature:   h.EndSignature,
+			StartSignature: h.StartSignature,
+			BaseChange:     h.BaseChange,
+			QuoteChange:    h.QuoteChange,
+			SolChange:      h.SolChange,
+			TxCount:        h.TxCount,
+		}
+	}
+
+	spec := holderUpsertSpec{table: models.RaydiumPoolHolder{}.TableName(), keyColumns: pairHolderKey, sumColumns: pairHolderSum}
+	upsertHolders(c, spec, mode, holders, func(h models.RaydiumPoolHolder) string {
+		return pairHolderKeyOf(h.Address, h.PoolAddress, h.BaseMint, h.QuoteMint)
+	})
+}
+
+// BatchUpsertPumpfunAmmpoolHolders inserts or updates many PumpFun AMM pool holders keyed by
+// (address, pool_address, base_mint, quote_mint)
+func BatchUpsertPumpfunAmmpoolHolders(c *gin.Context) {
+	var req BatchUpsertHoldersRequest[PumpfunAmmpoolHolderRequest]
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := parseHolderUpsertMode(c, req.Mode, req.Holders)
+	if !ok {
+		return
+	}
+
+	holders := make([]models.PumpfunAmmpoolHolder, len(req.Holders))
+	for i, h := range req.Holders {
+		holders[i] = models.PumpfunAmmpoolHolder{
+			Address:           h.Address,
+			HolderType:        h.HolderType,
+			PoolAddress:       h.PoolAddress,
+			BaseMint:          h.BaseMint,
+			QuoteMint:         h.QuoteMint,
+			LastSlot:          h.LastSlot,
+			StartSlot:         h.StartSlot,
+			LastTimestamp:     h.LastTimestamp,
+			StartTimestamp:    h.StartTimestamp,
+			EndSignature:      h.EndSignature,
+			StartSignature:    h.StartSignature,
+			BaseChange:        h.BaseChange,
+			QuoteChange:       h.QuoteChange,
+			SolChange:         h.SolChange,
+			TraderBaseVolume:  h.TraderBaseVolume,
+			TraderQuoteVolume: h.TraderQuoteVolume,
+			TraderSolVolume:   h.TraderSolVolume,
+			TxCount:           h.TxCount,
+		}
+	}
+
+	spec := holderUpsertSpec{
+		table:      models.PumpfunAmmpoolHolder{}.TableName(),
+		keyColumns: pairHolderKey,
+		sumColumns: append([]string{"trader_base_volume", "trader_quote_volume", "trader_sol_volume"}, pairHolderSum...),
+	}
+	upsertHolders(c, spec, mode, holders, func(h models.PumpfunAmmpoolHolder) string {
+		return pairHolderKeyOf(h.Address, h.PoolAddress, h.BaseMint, h.QuoteMint)
+	})
+}
+
+// BatchUpsertPumpfuninternalHolders inserts or updates many PumpFun bonding-curve holders keyed by
+// (address, bonding_curve_pda, mint)
+func BatchUpsertPumpfuninternalHolders(c *gin.Context) {
+	var req BatchUpsertHoldersRequest[PumpfuninternalHolderRequest]
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := parseHolderUpsertMode(c, req.Mode, req.Holders)
+	if !ok {
+		return
+	}
+
+	holders := make([]models.PumpfuninternalHolder, len(req.Holders))
+	for i, h := range req.Holders {
+		holders[i] = models.PumpfuninternalHolder{
+			Address:         h.Address,
+			HolderType:      h.HolderType,
+			BondingCurvePda: h.BondingCurvePda,
+			Mint:            h.Mint,
+			LastSlot:        h.LastSlot,
+			StartSlot:       h.StartSlot,
+			LastTimestamp:   h.LastTimestamp,
+			StartTimestamp:  h.StartTimestamp,
+			EndSignature:    h.EndSignature,
+			StartSignature:  h.StartSignature,
+			MintChange:      h.MintChange,
+			SolChange:       h.SolChange,
+			MintVolume:      h.MintVolume,
+			SolVolume:       h.SolVolume,
+			TxCount:         h.TxCount,
+		}
+	}
+
+	spec := holderUpsertSpec{
+		table:      models.PumpfuninternalHolder{}.TableName(),
+		keyColumns: []string{"address", "bonding_curve_pda", "mint"},
+		sumColumns: []string{"mint_change", "sol_change", "mint_volume", "sol_volume", "tx_count"},
+	}
+	upsertHolders(c, spec, mode, holders, func(h models.PumpfuninternalHolder) string {
+		return h.Address + "|" + h.BondingCurvePda + "|" + h.Mint
+	})
+}
@@ -27,7 +27,7 @@ type MeteoracpmmConfigRequest struct {
 // ListMeteoracpmmConfigs returns a list of all meteoracpmm configurations
 func ListMeteoracpmmConfigs(c *gin.Context) {
 	var configs []models.MeteoracpmmConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -184,6 +184,7 @@ func UpdateMeteoracpmmConfig(c *gin.Context) {
 	config.PoolQuoteTokenAccount = request.PoolQuoteTokenAccount
 	config.IsSkipDbc = request.IsSkipDbc
 	config.IsReverse = request.IsReverse
+	previousStatus := config.Status
 	if request.Status != "" {
 		config.Status = request.Status
 	}
@@ -192,6 +193,7 @@ func UpdateMeteoracpmmConfig(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformMeteoraCpmm, config.ID, config.PoolAddress, previousStatus, config.Status, "UpdateMeteoracpmmConfig")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -210,6 +212,22 @@ func DeleteMeteoracpmmConfig(c *gin.Context) {
 		return
 	}
 
+	// Check if any project is using this pool
+	projectIDs, err := findProjectIDsByPool(dbconfig.DB, models.PoolPlatformMeteoraCpmm, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
+		return
+	}
+
+	if len(projectIDs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
+		})
+		return
+	}
+
 	if err := dbconfig.DB.Delete(&config).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -243,11 +261,13 @@ func UpdateMeteoracpmmConfigStatus(c *gin.Context) {
 		return
 	}
 
+	previousStatus := config.Status
 	config.Status = request.Status
 	if err := dbconfig.DB.Save(&config).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformMeteoraCpmm, config.ID, config.PoolAddress, previousStatus, config.Status, "UpdateMeteoracpmmConfigStatus")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -306,19 +326,9 @@ func ListMeteoracpmmConfigsBySlice(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
-
 	response := gin.H{
-		"data": configs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       configs,
+		"pagination": paginationMeta(page, pageSize, total),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -28,7 +28,7 @@ type MeteoradbcConfigRequest struct {
 // ListMeteoradbcConfigs returns a list of all meteoradbc configurations
 func ListMeteoradbcConfigs(c *gin.Context) {
 	var configs []models.MeteoradbcConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -171,6 +171,7 @@ func UpdateMeteoradbcConfig(c *gin.Context) {
 	config.FirstBuyer = request.FirstBuyer
 	config.DammV2PoolAddress = request.DammV2PoolAddress
 	config.IsMigrated = request.IsMigrated
+	previousStatus := config.Status
 	if request.Status != "" {
 		config.Status = request.Status
 	}
@@ -179,6 +180,7 @@ func UpdateMeteoradbcConfig(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformMeteoraDbc, config.ID, config.PoolAddress, previousStatus, config.Status, "UpdateMeteoradbcConfig")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -197,6 +199,22 @@ func DeleteMeteoradbcConfig(c *gin.Context) {
 		return
 	}
 
+	// Check if any project is using this pool
+	projectIDs, err := findProjectIDsByPool(dbconfig.DB, models.PoolPlatformMeteoraDbc, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
+		return
+	}
+
+	if len(projectIDs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
+		})
+		return
+	}
+
 	if err := dbconfig.DB.Delete(&config).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -230,11 +248,13 @@ func UpdateMeteoradbcConfigStatus(c *gin.Context) {
 		return
 	}
 
+	previousStatus := config.Status
 	config.Status = request.Status
 	if err := dbconfig.DB.Save(&config).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformMeteoraDbc, config.ID, config.PoolAddress, previousStatus, config.Status, "UpdateMeteoradbcConfigStatus")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -293,19 +313,9 @@ func ListMeteoradbcConfigsBySlice(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
-
 	response := gin.H{
-		"data": configs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       configs,
+		"pagination": paginationMeta(page, pageSize, total),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// paginationMeta builds the standard pagination envelope returned by paginated list endpoints
+func paginationMeta(page, pageSize int, total int64) gin.H {
+	totalPages := int64(0)
+	if pageSize > 0 {
+		totalPages = (total + int64(pageSize) - 1) / int64(pageSize)
+	}
+	return gin.H{
+		"current_page": page,
+		"page_size":    pageSize,
+		"total_pages":  totalPages,
+		"total_count":  total,
+		"has_next":     int64(page) < totalPages,
+		"has_prev":     page > 1,
+	}
+}
+
+// recordFilter maps column names to the values a Filter request asked for; empty values are ignored
+type recordFilter map[string]string
+
+// filterRecords runs an equality filter over the table of T, shared by the Filter... handlers so they
+// all behave the same: at least one filter value is required (400 otherwise), every non-empty value
+// becomes a "column = ?" condition, and scopes can add handler-specific conditions. Rows come newest first (id desc)
+// and are read through findBounded, so a broad filter (e.g. only a mint) must be paginated.
+// On failure the error response has already been written and ok is false.
+func filterRecords[T any](c *gin.Context, filter recordFilter, scopes ...func(*gorm.DB) *gorm.DB) ([]T, bool) {
+	columns := make([]string, 0, len(filter))
+	allColumns := make([]string, 0, len(filter))
+	for column, value := range filter {
+		allColumns = append(allColumns, column)
+		if value != "" {
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+	sort.Strings(allColumns)
+
+	if len(columns) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At least one filter parameter (%s) is required", strings.Join(allColumns, ", "))})
+		return nil, false
+	}
+
+	var model T
+	query := dbconfig.DB.Model(&model)
+	for _, column := range columns {
+		query = query.Where(column+" = ?", filter[column])
+	}
+
+	var records []T
+	if !findBounded(c, query.Scopes(scopes...).Order("id desc"), &records) {
+		return nil, false
+	}
+	return records, true
+}
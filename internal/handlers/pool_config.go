@@ -1,10 +1,18 @@
 package handlers
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"marketcontrol/internal/middleware"
 	"marketcontrol/internal/models"
 	dbconfig "marketcontrol/pkg/config"
 )
@@ -26,8 +34,7 @@ type PoolConfigRequest struct {
 // ListPoolConfigs returns a list of all pool configs
 func ListPoolConfigs(c *gin.Context) {
 	var pools []models.PoolConfig
-	if err := dbconfig.DB.Preload("BaseMint").Preload("QuoteMint").Find(&pools).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Preload("BaseMint").Preload("QuoteMint").Order("id desc"), &pools) {
 		return
 	}
 	c.JSON(http.StatusOK, pools)
@@ -49,6 +56,1010 @@ func GetPoolConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, pool)
 }
 
+// GetPoolConfigByAddress looks up a pool by on-chain address across all supported platforms
+// and returns it tagged with its platform
+func GetPoolConfigByAddress(c *gin.Context) {
+	address := c.Param("address")
+
+	platform, poolID, pool, err := resolvePoolByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":       address,
+		"pool_platform": platform,
+		"pool_id":       poolID,
+		"pool":          pool,
+	})
+}
+
+// PoolFeeBucket is the fee total of a pool over the whole range or one interval.
+// Fields a platform doesn't record stay 0: pumpfun_internal swaps have no fee column but
+// carry fee-recipient and creator SOL changes instead.
+type PoolFeeBucket struct {
+	BucketStart           *uint   `json:"bucket_start,omitempty"`
+	SwapCount             int64   `json:"swap_count"`
+	TotalFee              float64 `json:"total_fee"`
+	FeeRecipientSolChange float64 `json:"fee_recipient_sol_change"`
+	CreatorSolChange      float64 `json:"creator_sol_change"`
+}
+
+// GetPoolFeeSummary sums swap fees of a pool within an optional time range.
+// Query params: start_time, end_time (unix seconds), interval (seconds, optional) for a per-interval breakdown.
+func GetPoolFeeSummary(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds := map[string]uint64{}
+	for _, key := range []string{"start_time", "end_time", "interval"} {
+		if v := c.Query(key); v != "" {
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + key})
+				return
+			}
+			bounds[key] = parsed
+		}
+	}
+	interval, breakdown := bounds["interval"]
+	if breakdown && interval < 60 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be at least 60 seconds"})
+		return
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	columns := "COUNT(*) AS swap_count"
+	if platform == models.PoolPlatformPumpfunInternal {
+		columns += ", COALESCE(SUM(fee_recipient_sol_change), 0) AS fee_recipient_sol_change, COALESCE(SUM(creator_sol_change), 0) AS creator_sol_change"
+	} else {
+		columns += ", COALESCE(SUM(fee), 0) AS total_fee"
+	}
+
+	query := func() *gorm.DB {
+		q := dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue)
+		if v, ok := bounds["start_time"]; ok {
+			q = q.Where("timestamp >= ?", v)
+		}
+		if v, ok := bounds["end_time"]; ok {
+			q = q.Where("timestamp <= ?", v)
+		}
+		return q
+	}
+
+	var total PoolFeeBucket
+	if err := query().Select(columns).Scan(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"total":         total,
+	}
+
+	if breakdown {
+		var buckets []PoolFeeBucket
+		if err := query().
+			Select("(timestamp / ?) * ? AS bucket_start, "+columns, interval, interval).
+			Group("bucket_start").
+			Order("bucket_start asc").
+			Limit(10000).
+			Scan(&buckets).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response["interval"] = interval
+		response["buckets"] = buckets
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseSwapWindow reads the start_time, end_time (unix seconds), start_slot and end_slot (inclusive)
+// query params plus any extra unsigned params. On failure the error response has already been written.
+func parseSwapWindow(c *gin.Context, extra ...string) (map[string]uint64, bool) {
+	bounds := map[string]uint64{}
+	for _, key := range append([]string{"start_time", "end_time", "start_slot", "end_slot"}, extra...) {
+		if v := c.Query(key); v != "" {
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + key})
+				return nil, false
+			}
+			bounds[key] = parsed
+		}
+	}
+	return bounds, true
+}
+
+// applySwapWindow restricts a swap query to the window parsed by parseSwapWindow
+func applySwapWindow(q *gorm.DB, bounds map[string]uint64) *gorm.DB {
+	if v, ok := bounds["start_time"]; ok {
+		q = q.Where("timestamp >= ?", v)
+	}
+	if v, ok := bounds["end_time"]; ok {
+		q = q.Where("timestamp <= ?", v)
+	}
+	if v, ok := bounds["start_slot"]; ok {
+		q = q.Where("slot >= ?", v)
+	}
+	if v, ok := bounds["end_slot"]; ok {
+		q = q.Where("slot <= ?", v)
+	}
+	return q
+}
+
+// applyCreatedAtRange restricts q by ingestion time from the created_after (inclusive) and created_before
+// (exclusive) query params, given as RFC3339 or unix seconds. Unlike the on-chain timestamp, created_at
+// is when the row was written, so backfilled rows fall in the window they were ingested in.
+// On failure the error response has already been written.
+func applyCreatedAtRange(c *gin.Context, q *gorm.DB) (*gorm.DB, bool) {
+	for _, key := range []string{"created_after", "created_before"} {
+		v := c.Query(key)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			seconds, parseErr := strconv.ParseInt(v, 10, 64)
+			if parseErr != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + key + ", expected RFC3339 or unix seconds"})
+				return nil, false
+			}
+			t = time.Unix(seconds, 0)
+		}
+		if key == "created_after" {
+			q = q.Where("created_at >= ?", t)
+		} else {
+			q = q.Where("created_at < ?", t)
+		}
+	}
+	return q, true
+}
+
+// ActiveTraderBucket is the number of distinct trading addresses of a pool over one interval
+type ActiveTraderBucket struct {
+	BucketStart uint  `json:"bucket_start"`
+	TraderCount int64 `json:"trader_count"`
+	SwapCount   int64 `json:"swap_count"`
+}
+
+// GetActiveTraderCount counts the distinct addresses that swapped in a pool, computed in SQL.
+// Query params: start_time, end_time (unix seconds), start_slot, end_slot (inclusive),
+// interval (seconds, optional, at least 60) for a per-interval series such as daily active traders (86400).
+func GetActiveTraderCount(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c, "interval")
+	if !ok {
+		return
+	}
+	interval, breakdown := bounds["interval"]
+	if breakdown && interval < 60 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be at least 60 seconds"})
+		return
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	query := func() *gorm.DB {
+		return applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds)
+	}
+
+	const columns = "COUNT(DISTINCT address) AS trader_count, COUNT(*) AS swap_count"
+
+	var total struct {
+		TraderCount int64 `json:"trader_count"`
+		SwapCount   int64 `json:"swap_count"`
+	}
+	if err := query().Select(columns).Scan(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"trader_count":  total.TraderCount,
+		"swap_count":    total.SwapCount,
+	}
+
+	if breakdown {
+		var buckets []ActiveTraderBucket
+		if err := query().
+			Select("(timestamp / ?) * ? AS bucket_start, "+columns, interval, interval).
+			Group("bucket_start").
+			Order("bucket_start asc").
+			Limit(10000).
+			Scan(&buckets).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response["interval"] = interval
+		if buckets == nil {
+			buckets = []ActiveTraderBucket{}
+		}
+		response["buckets"] = buckets
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// traderChangeColumns returns the swap columns holding the trader's base (token) and quote (SOL) change
+func traderChangeColumns(platform models.PoolPlatform) (string, string) {
+	if platform == models.PoolPlatformPumpfunInternal {
+		return "trader_mint_change", "trader_sol_change"
+	}
+	return "trader_base_change", "trader_quote_change"
+}
+
+// BuySellSide aggregates the swaps of one side
+type BuySellSide struct {
+	Count       int64   `json:"count"`
+	QuoteVolume float64 `json:"quote_volume"`
+}
+
+// GetBuySellPressure splits a pool's swaps into buys (trader base change > 0) and sells (< 0) and
+// returns the count and summed absolute quote volume of each side, aggregated in SQL.
+// Query params: start_time, end_time (unix seconds), start_slot, end_slot (inclusive).
+// volume_ratio is buy/sell quote volume and is null when there are no sells.
+func GetBuySellPressure(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c)
+	if !ok {
+		return
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	base, quote := traderChangeColumns(platform)
+	var row struct {
+		BuyCount        int64
+		BuyQuoteVolume  float64
+		SellCount       int64
+		SellQuoteVolume float64
+	}
+	err = applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds).
+		Select(fmt.Sprintf("COUNT(*) FILTER (WHERE %[1]s > 0) AS buy_count, "+
+			"COALESCE(SUM(ABS(%[2]s)) FILTER (WHERE %[1]s > 0), 0) AS buy_quote_volume, "+
+			"COUNT(*) FILTER (WHERE %[1]s < 0) AS sell_count, "+
+			"COALESCE(SUM(ABS(%[2]s)) FILTER (WHERE %[1]s < 0), 0) AS sell_quote_volume", base, quote)).
+		Scan(&row).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var volumeRatio, countRatio *float64
+	if row.SellQuoteVolume > 0 {
+		ratio := row.BuyQuoteVolume / row.SellQuoteVolume
+		volumeRatio = &ratio
+	}
+	if row.SellCount > 0 {
+		ratio := float64(row.BuyCount) / float64(row.SellCount)
+		countRatio = &ratio
+	}
+	buyShare := 0.0
+	if total := row.BuyQuoteVolume + row.SellQuoteVolume; total > 0 {
+		buyShare = row.BuyQuoteVolume / total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"buy":           BuySellSide{Count: row.BuyCount, QuoteVolume: row.BuyQuoteVolume},
+		"sell":          BuySellSide{Count: row.SellCount, QuoteVolume: row.SellQuoteVolume},
+		"volume_ratio":  volumeRatio,
+		"count_ratio":   countRatio,
+		"buy_share":     buyShare,
+	})
+}
+
+// defaultTradeSizeBuckets and maxTradeSizeBuckets bound the histogram of GetTradeSizeDistribution
+const (
+	defaultTradeSizeBuckets = 10
+	maxTradeSizeBuckets     = 100
+)
+
+// TradeSizeBucket counts the swaps whose absolute quote volume falls in [min, max); the last bucket includes max
+type TradeSizeBucket struct {
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Count       int64   `json:"count"`
+	QuoteVolume float64 `json:"quote_volume"`
+}
+
+// TradeSizePercentiles are the p50/p90/p99 absolute quote volume per swap
+type TradeSizePercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+}
+
+// GetTradeSizeDistribution characterizes the trade sizes (absolute quote volume per swap) of a pool:
+// count, average, min/max, median and p50/p90/p99, plus a linear histogram, all computed in SQL.
+// Percentiles use percentile_cont; if the database rejects it they are estimated from the histogram
+// and percentiles_estimated is true. Swaps without quote change are ignored.
+// Query params: start_time, end_time (unix seconds), start_slot, end_slot (inclusive),
+// buckets (default 10, max 100), min_size, max_size (histogram range, default: observed min/max).
+// Swaps outside the histogram range are reported as below_range / above_range.
+func GetTradeSizeDistribution(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c, "buckets")
+	if !ok {
+		return
+	}
+	bucketCount := defaultTradeSizeBuckets
+	if v, set := bounds["buckets"]; set {
+		if v < 1 || v > maxTradeSizeBuckets {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("buckets must be between 1 and %d", maxTradeSizeBuckets)})
+			return
+		}
+		bucketCount = int(v)
+	}
+	rangeBounds := map[string]float64{}
+	for _, key := range []string{"min_size", "max_size"} {
+		if v := c.Query(key); v != "" {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil || parsed < 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + key})
+				return
+			}
+			rangeBounds[key] = parsed
+		}
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	_, quote := traderChangeColumns(platform)
+	size := "ABS(" + quote + ")"
+	query := func() *gorm.DB {
+		return applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds).
+			Where(quote + " <> 0")
+	}
+
+	var stats struct {
+		Count       int64
+		QuoteVolume float64
+		Avg         float64
+		Min         float64
+		Max         float64
+	}
+	if err := query().Select(fmt.Sprintf("COUNT(*) AS count, COALESCE(SUM(%[1]s), 0) AS quote_volume, "+
+		"COALESCE(AVG(%[1]s), 0) AS avg, COALESCE(MIN(%[1]s), 0) AS min, COALESCE(MAX(%[1]s), 0) AS max", size)).
+		Scan(&stats).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rangeMin, rangeMax := stats.Min, stats.Max
+	if v, set := rangeBounds["min_size"]; set {
+		rangeMin = v
+	}
+	if v, set := rangeBounds["max_size"]; set {
+		rangeMax = v
+	}
+	if rangeMax < rangeMin {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_size must not be less than min_size"})
+		return
+	}
+
+	// 直方图：按 [rangeMin, rangeMax] 等宽分桶，最后一个桶包含上界
+	width := (rangeMax - rangeMin) / float64(bucketCount)
+	buckets := make([]TradeSizeBucket, bucketCount)
+	for i := range buckets {
+		buckets[i].Min = rangeMin + float64(i)*width
+		buckets[i].Max = rangeMin + float64(i+1)*width
+	}
+	if bucketCount > 0 {
+		buckets[bucketCount-1].Max = rangeMax
+	}
+	var belowRange, aboveRange int64
+	if stats.Count > 0 {
+		bucketExpr := clause.Expr{SQL: "0"}
+		if width > 0 {
+			bucketExpr = clause.Expr{SQL: fmt.Sprintf("LEAST(FLOOR((%s - ?) / ?), %d)", size, bucketCount-1), Vars: []interface{}{rangeMin, width}}
+		}
+		var rows []struct {
+			Bucket      int
+			Count       int64
+			QuoteVolume float64
+		}
+		if err := query().Where(size+" BETWEEN ? AND ?", rangeMin, rangeMax).
+			Select("? AS bucket, COUNT(*) AS count, COALESCE(SUM("+size+"), 0) AS quote_volume", bucketExpr).
+			Group("bucket").Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, row := range rows {
+			if row.Bucket >= 0 && row.Bucket < bucketCount {
+				buckets[row.Bucket].Count += row.Count
+				buckets[row.Bucket].QuoteVolume += row.QuoteVolume
+			}
+		}
+
+		var outside struct {
+			Below int64
+			Above int64
+		}
+		if err := query().Select(fmt.Sprintf("COUNT(*) FILTER (WHERE %[1]s < ?) AS below, COUNT(*) FILTER (WHERE %[1]s > ?) AS above", size),
+			rangeMin, rangeMax).Scan(&outside).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		belowRange, aboveRange = outside.Below, outside.Above
+	}
+
+	var percentiles TradeSizePercentiles
+	estimated := false
+	if stats.Count > 0 {
+		err := query().Select(fmt.Sprintf("percentile_cont(0.5) WITHIN GROUP (ORDER BY %[1]s) AS p50, "+
+			"percentile_cont(0.9) WITHIN GROUP (ORDER BY %[1]s) AS p90, "+
+			"percentile_cont(0.99) WITHIN GROUP (ORDER BY %[1]s) AS p99", size)).Scan(&percentiles).Error
+		if err != nil {
+			log.Warnf("percentile_cont unavailable for pool %s, estimating trade size percentiles from histogram: %v", address, err)
+			estimated = true
+			percentiles = TradeSizePercentiles{
+				P50: estimateBucketPercentile(buckets, belowRange, aboveRange, rangeMin, rangeMax, 0.5),
+				P90: estimateBucketPercentile(buckets, belowRange, aboveRange, rangeMin, rangeMax, 0.9),
+				P99: estimateBucketPercentile(buckets, belowRange, aboveRange, rangeMin, rangeMax, 0.99),
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":          address,
+		"pool_platform":         platform,
+		"swap_count":            stats.Count,
+		"quote_volume":          stats.QuoteVolume,
+		"average":               stats.Avg,
+		"median":                percentiles.P50,
+		"min":                   stats.Min,
+		"max":                   stats.Max,
+		"percentiles":           percentiles,
+		"percentiles_estimated": estimated,
+		"histogram":             buckets,
+		"below_range":           belowRange,
+		"above_range":           aboveRange,
+	})
+}
+
+// estimateBucketPercentile interpolates percentile p (0-1) linearly within the histogram bucket holding it.
+// Swaps below/above the histogram range are placed at its edges.
+func estimateBucketPercentile(buckets []TradeSizeBucket, below, above int64, rangeMin, rangeMax, p float64) float64 {
+	total := below + above
+	for _, b := range buckets {
+		total += b.Count
+	}
+	if total == 0 {
+		return 0
+	}
+	target := p * float64(total)
+	cumulative := float64(below)
+	if target <= cumulative {
+		return rangeMin
+	}
+	for _, b := range buckets {
+		if b.Count > 0 && target <= cumulative+float64(b.Count) {
+			return b.Min + (target-cumulative)/float64(b.Count)*(b.Max-b.Min)
+		}
+		cumulative += float64(b.Count)
+	}
+	return rangeMax
+}
+
+// PoolGenesisSwap is the earliest recorded swap of a pool. Price is quote per base token and is null when
+// the swap moved no base token; side is buy when the trader received base tokens, sell when it gave them.
+type PoolGenesisSwap struct {
+	ID          uint     `json:"id"`
+	Slot        uint     `json:"slot"`
+	Timestamp   uint     `json:"timestamp"`
+	Signature   string   `json:"signature"`
+	Trader      string   `json:"trader"`
+	BaseChange  float64  `json:"base_change"`
+	QuoteChange float64  `json:"quote_change"`
+	Price       *float64 `json:"price"`
+	Side        string   `json:"side"`
+}
+
+// poolLaunchParties returns the creator and first buyer configured on a pool, where the platform records them
+// (pumpfun AMM pools have no first buyer but carry the coin creator instead)
+func poolLaunchParties(pool interface{}) (creator, firstBuyer, coinCreator string) {
+	switch pool := pool.(type) {
+	case *models.MeteoradbcConfig:
+		return pool.Creator, pool.FirstBuyer, ""
+	case *models.MeteoracpmmConfig:
+		return pool.Creator, "", ""
+	case *models.PumpfunAmmPoolConfig:
+		return pool.Creator, "", pool.CoinCreator
+	case *models.RaydiumLaunchpadPoolConfig:
+		return pool.Creator, "", ""
+	}
+	return "", "", ""
+}
+
+// GetPoolGenesis returns the launch metadata of a pool in one read: the earliest swap (lowest slot) with its
+// derived price and trader, and the pool's configured creator / first buyer. first_swap is null when the pool
+// has no swaps yet; first_trader_is_first_buyer is only set when the pool records a first buyer.
+func GetPoolGenesis(c *gin.Context) {
+	address := c.Param("address")
+
+	platform, poolID, pool, err := resolvePoolByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	tables, ok := resolvePoolDataTables(reflect.Indirect(reflect.ValueOf(pool)).Interface())
+	if !ok || tables.PoolValue == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	base, quote := traderChangeColumns(platform)
+	var swaps []PoolGenesisSwap
+	if err := dbconfig.DB.Model(tables.SwapModel).
+		Select(fmt.Sprintf("id, slot, timestamp, signature, address AS trader, %[1]s AS base_change, %[2]s AS quote_change", base, quote)).
+		Where(tables.PoolColumn+" = ?", tables.PoolValue).
+		Order("slot asc, id asc").
+		Limit(1).
+		Scan(&swaps).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	creator, firstBuyer, coinCreator := poolLaunchParties(pool)
+	response := gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"pool_id":       poolID,
+		"creator":       creator,
+		"first_buyer":   firstBuyer,
+		"first_swap":    nil,
+	}
+	if coinCreator != "" {
+		response["coin_creator"] = coinCreator
+	}
+
+	if len(swaps) > 0 {
+		first := swaps[0]
+		if first.BaseChange != 0 {
+			price := math.Abs(first.QuoteChange) / math.Abs(first.BaseChange)
+			first.Price = &price
+		}
+		switch {
+		case first.BaseChange > 0:
+			first.Side = "buy"
+		case first.BaseChange < 0:
+			first.Side = "sell"
+		default:
+			first.Side = "unknown"
+		}
+		response["first_swap"] = first
+		if firstBuyer != "" {
+			response["first_trader_is_first_buyer"] = first.Trader == firstBuyer
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PoolCandle is the OHLC price and volume of a pool over one interval. Prices are quote per base token;
+// time is the bucket start in unix seconds, as expected by most charting libraries.
+type PoolCandle struct {
+	Time        uint    `json:"time"`
+	Open        float64 `json:"open"`
+	High        float64 `json:"high"`
+	Low         float64 `json:"low"`
+	Close       float64 `json:"close"`
+	Volume      float64 `json:"volume"`
+	QuoteVolume float64 `json:"quote_volume"`
+	SwapCount   int64   `json:"swap_count"`
+}
+
+// maxPoolCandles bounds the number of candles returned by one request
+const maxPoolCandles = 10000
+
+// GetPoolCandles returns per-interval OHLC candles of a pool computed in SQL from its swaps, taking
+// abs(quote change) / abs(base change) of each swap as its price; open/close are the first/last swap of
+// the bucket by slot. Swaps without a base change are skipped.
+// Query params: interval (seconds, required, at least 60), start_time, end_time (unix seconds),
+// start_slot, end_slot (inclusive).
+func GetPoolCandles(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c, "interval")
+	if !ok {
+		return
+	}
+	interval := bounds["interval"]
+	if interval < 60 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval is required and must be at least 60 seconds"})
+		return
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	base, quote := traderChangeColumns(platform)
+	prices := applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds).
+		Where(base+" <> 0").
+		Select(fmt.Sprintf("id, slot, (timestamp / %[3]d) * %[3]d AS bucket_start, "+
+			"ABS(%[2]s) / ABS(%[1]s) AS price, ABS(%[1]s) AS base_volume, ABS(%[2]s) AS quote_volume", base, quote, interval))
+
+	var candles []PoolCandle
+	if err := dbconfig.DB.Table("(?) AS s", prices).
+		Select("bucket_start AS time, " +
+			"(ARRAY_AGG(price ORDER BY slot ASC, id ASC))[1] AS open, " +
+			"MAX(price) AS high, MIN(price) AS low, " +
+			"(ARRAY_AGG(price ORDER BY slot DESC, id DESC))[1] AS close, " +
+			"SUM(base_volume) AS volume, SUM(quote_volume) AS quote_volume, COUNT(*) AS swap_count").
+		Group("bucket_start").
+		Order("bucket_start asc").
+		Limit(maxPoolCandles).
+		Scan(&candles).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if candles == nil {
+		candles = []PoolCandle{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"interval":      interval,
+		"candles":       candles,
+	})
+}
+
+// defaultSwapSlotGapThreshold is the default min_gap_slots of DetectSwapSlotGaps (~10 minutes of slots)
+const defaultSwapSlotGapThreshold = 1500
+
+// SwapSlotGap is a window between two consecutive swaps of a pool with no swap recorded in it
+type SwapSlotGap struct {
+	StartSlot      uint  `json:"start_slot"` // slot of the swap before the gap
+	EndSlot        uint  `json:"end_slot"`   // slot of the swap after the gap
+	GapSlots       int64 `json:"gap_slots"`
+	StartTimestamp uint  `json:"start_timestamp"`
+	EndTimestamp   uint  `json:"end_timestamp"`
+	GapSeconds     int64 `json:"gap_seconds"`
+}
+
+// DetectSwapSlotGaps finds windows where consecutive swaps of a pool are at least min_gap_slots apart, which
+// usually means the monitor missed them. Gaps are computed with LAG over the swaps in the window, oldest first.
+// Query params: min_gap_slots (default 1500), start_time, end_time (unix seconds), start_slot, end_slot
+// (inclusive), limit (default 100, max 1000).
+func DetectSwapSlotGaps(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c, "min_gap_slots")
+	if !ok {
+		return
+	}
+	minGap := uint64(defaultSwapSlotGapThreshold)
+	if v, ok := bounds["min_gap_slots"]; ok {
+		if v == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_gap_slots must be positive"})
+			return
+		}
+		minGap = v
+	}
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 1000 {
+			limit = parsed
+		}
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	consecutive := applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds).
+		Select("slot, timestamp, LAG(slot) OVER (ORDER BY slot, id) AS prev_slot, " +
+			"LAG(timestamp) OVER (ORDER BY slot, id) AS prev_timestamp")
+	gaps := dbconfig.DB.Table("(?) AS s", consecutive).
+		Where("prev_slot IS NOT NULL AND slot - prev_slot >= ?", minGap)
+
+	var total int64
+	if err := gaps.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	result := []SwapSlotGap{}
+	if err := gaps.Select("prev_slot AS start_slot, slot AS end_slot, slot - prev_slot AS gap_slots, " +
+		"prev_timestamp AS start_timestamp, timestamp AS end_timestamp, " +
+		"CAST(timestamp AS BIGINT) - CAST(prev_timestamp AS BIGINT) AS gap_seconds").
+		Order("prev_slot ASC").
+		Limit(limit).
+		Scan(&result).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if total > 0 {
+		middleware.RequestLogger(c).WithFields(log.Fields{
+			"pool_address":  address,
+			"pool_platform": platform,
+			"gap_count":     total,
+			"min_gap_slots": minGap,
+		}).Warn("Swap slot gaps detected, the monitor may have missed swaps")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"min_gap_slots": minGap,
+		"gap_count":     total,
+		"truncated":     total > int64(len(result)),
+		"data":          result,
+	})
+}
+
+// GetPumpfunFeeAndCreatorRevenue sums fee_recipient_sol_change and creator_sol_change over the swaps of a
+// pumpfun internal pool (addressed by bonding curve PDA or associated bonding curve), computed in SQL.
+// Query params: start_time, end_time (unix seconds), start_slot, end_slot (inclusive).
+func GetPumpfunFeeAndCreatorRevenue(c *gin.Context) {
+	address := c.Param("address")
+
+	bounds, ok := parseSwapWindow(c)
+	if !ok {
+		return
+	}
+
+	platform, tables, _, err := resolvePoolTablesByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	// 只有 pumpfun 内盘的 swap 记录了 fee recipient / creator 的 SOL 变化
+	if platform != models.PoolPlatformPumpfunInternal {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fee and creator revenue is only recorded for pumpfun_internal pools", "pool_platform": platform})
+		return
+	}
+
+	var totals struct {
+		FeeRecipientSol float64
+		CreatorSol      float64
+		SwapCount       int64
+	}
+	if err := applySwapWindow(dbconfig.DB.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue), bounds).
+		Select("COALESCE(SUM(fee_recipient_sol_change), 0) AS fee_recipient_sol, " +
+			"COALESCE(SUM(creator_sol_change), 0) AS creator_sol, COUNT(*) AS swap_count").
+		Scan(&totals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":      address,
+		"pool_platform":     platform,
+		"bonding_curve_pda": tables.PoolValue,
+		"fee_recipient_sol": totals.FeeRecipientSol,
+		"creator_sol":       totals.CreatorSol,
+		"total_sol":         totals.FeeRecipientSol + totals.CreatorSol,
+		"swap_count":        totals.SwapCount,
+	})
+}
+
+// ClassifyUntypedHoldersRequest represents the request body for backfilling holder_type
+type ClassifyUntypedHoldersRequest struct {
+	BatchSize int `json:"batch_size"`
+}
+
+// ClassifyUntypedHolders assigns holder_type to a pool's holders whose holder_type is empty, using the
+// same rules as the transaction processors: the pool's own addresses are "pool"; managed addresses,
+// project extra addresses and role addresses of the pool's projects are "project"; everything else is
+// "retail_investors". Rows are updated in batches and the counts per assigned type are returned.
+func ClassifyUntypedHolders(c *gin.Context) {
+	address := c.Param("address")
+
+	var request ClassifyUntypedHoldersRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	batchSize := request.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	if batchSize > 5000 {
+		batchSize = 5000
+	}
+
+	platform, poolID, pool, err := resolvePoolByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+	tables, ok := resolvePoolDataTables(reflect.Indirect(reflect.ValueOf(pool)).Interface())
+	if !ok || tables.PoolValue == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
+		return
+	}
+
+	// 池子自身地址（pumpfun 内盘还包括手续费接收者和创建者金库）
+	poolAddresses := map[string]bool{tables.PoolValue: true}
+	if cfg, ok := pool.(*models.PumpfuninternalConfig); ok {
+		for _, a := range []string{cfg.FeeRecipient, cfg.CreatorVaultPda} {
+			if a != "" {
+				poolAddresses[a] = true
+			}
+		}
+	}
+
+	// 该池子所属项目的角色地址
+	roleAddresses := dbconfig.DB.Model(&models.RoleAddress{}).
+		Select("role_address.address").
+		Joins("JOIN role_config_relation ON role_config_relation.role_id = role_address.role_id").
+		Joins("JOIN project_config ON project_config.id = role_config_relation.project_id").
+		Where("project_config.pool_platform = ? AND project_config.pool_id = ?", platform, poolID)
+
+	counts := map[string]int64{"pool": 0, "project": 0, "retail_investors": 0}
+	for {
+		var batch []struct {
+			ID      uint
+			Address string
+		}
+		if err := dbconfig.DB.Model(tables.HolderModel).
+			Select("id, address").
+			Where(tables.PoolColumn+" = ? AND (holder_type IS NULL OR holder_type = '')", tables.PoolValue).
+			Order("id asc").
+			Limit(batchSize).
+			Scan(&batch).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "classified": counts})
+			return
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		addresses := make([]string, 0, len(batch))
+		for _, h := range batch {
+			addresses = append(addresses, h.Address)
+		}
+		var projectAddresses []string
+		if err := dbconfig.DB.Raw(`
+			SELECT address FROM address_manages WHERE address IN (?) AND deleted_at IS NULL
+			UNION SELECT address FROM project_extra_address WHERE address IN (?)
+			UNION SELECT address FROM (?) AS ra WHERE address IN (?)`,
+			addresses, addresses, roleAddresses, addresses).Scan(&projectAddresses).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "classified": counts})
+			return
+		}
+		isProject := make(map[string]bool, len(projectAddresses))
+		for _, a := range projectAddresses {
+			isProject[a] = true
+		}
+
+		idsByType := map[string][]uint{}
+		for _, h := range batch {
+			holderType := "retail_investors"
+			if isProject[h.Address] {
+				holderType = "project"
+			} else if poolAddresses[h.Address] {
+				holderType = "pool"
+			}
+			idsByType[holderType] = append(idsByType[holderType], h.ID)
+		}
+
+		err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+			for holderType, ids := range idsByType {
+				if err := tx.Model(tables.HolderModel).Where("id IN ?", ids).Update("holder_type", holderType).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "classified": counts})
+			return
+		}
+		for holderType, ids := range idsByType {
+			counts[holderType] += int64(len(ids))
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":  address,
+		"pool_platform": platform,
+		"classified":    counts,
+		"total":         counts["pool"] + counts["project"] + counts["retail_investors"],
+	})
+}
+
 // CreatePoolConfig creates a new pool config
 func CreatePoolConfig(c *gin.Context) {
 	var request PoolConfigRequest
@@ -142,20 +1153,19 @@ func DeletePoolConfig(c *gin.Context) {
 	}
 
 	// Check if any project is using this pool
-	var projectCount int64
-	if err := tx.Model(&models.ProjectConfig{}).
-		Where("pool_platform = ? AND pool_id = ?", "raydium", id).
-		Count(&projectCount).Error; err != nil {
+	projectIDs, err := findProjectIDsByPool(tx, models.PoolPlatformRaydium, uint(id))
+	if err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
 		return
 	}
 
-	if projectCount > 0 {
+	if len(projectIDs) > 0 {
 		tx.Rollback()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot delete pool: there are projects using this pool",
-			"project_count": projectCount,
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
 		})
 		return
 	}
@@ -188,3 +1198,107 @@ func DeletePoolConfig(c *gin.Context) {
 		"deleted_stats_count": deletedStatsCount,
 	})
 }
+
+// poolStatusAuditModule is the SystemLog module under which pool status transitions are recorded
+const poolStatusAuditModule = "PoolStatus"
+
+// recordPoolStatusChange writes a SystemLog audit entry for a pool config status transition.
+// Unchanged statuses are not recorded; a failed write is only logged so it never blocks the status change.
+func recordPoolStatusChange(db *gorm.DB, platform models.PoolPlatform, poolID uint, poolAddress, from, to, source string) {
+	if from == to {
+		return
+	}
+	entry := models.SystemLog{
+		Level:   "INFO",
+		Message: fmt.Sprintf("池子状态变更: %s -> %s", from, to),
+		Module:  poolStatusAuditModule,
+		Meta: models.JSONMap{
+			"pool_platform": string(platform),
+			"pool_id":       poolID,
+			"pool_address":  poolAddress,
+			"field":         "status",
+			"from":          from,
+			"to":            to,
+			"source":        source,
+		},
+	}
+	if err := db.Create(&entry).Error; err != nil {
+		log.Warnf("Failed to record status change of %s pool %d: %v", platform, poolID, err)
+	}
+}
+
+// PoolStatusTransition is one recorded change of a pool config's status
+type PoolStatusTransition struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Source    string    `json:"source"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// PoolInactivePeriod is a span during which the pool was not active; EndedAt is nil while it still is
+type PoolInactivePeriod struct {
+	Status    string     `json:"status"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// GetPoolStatusHistory returns the ordered status transitions of a pool config, read from the SystemLog
+// audit entries written whenever its status changes, together with the inactive periods they imply.
+// Only changes made after auditing was added are known.
+func GetPoolStatusHistory(c *gin.Context) {
+	address := c.Param("address")
+
+	platform, poolID, pool, err := resolvePoolByAddress(dbconfig.DB, address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pool config found for address"})
+		return
+	}
+
+	var entries []models.SystemLog
+	if err := dbconfig.DB.
+		Where("module = ? AND meta->>'pool_platform' = ? AND meta->>'pool_id' = ?",
+			poolStatusAuditModule, string(platform), strconv.FormatUint(uint64(poolID), 10)).
+		Order("created_at asc, id asc").
+		Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	transitions := make([]PoolStatusTransition, 0, len(entries))
+	periods := []PoolInactivePeriod{}
+	for _, entry := range entries {
+		t := PoolStatusTransition{ChangedAt: entry.CreatedAt}
+		t.From, _ = entry.Meta["from"].(string)
+		t.To, _ = entry.Meta["to"].(string)
+		t.Source, _ = entry.Meta["source"].(string)
+		transitions = append(transitions, t)
+
+		// 离开 active 时开启一个非活跃区间，回到 active 时结束它
+		open := len(periods) > 0 && periods[len(periods)-1].EndedAt == nil
+		switch {
+		case t.To == "active" && open:
+			changedAt := t.ChangedAt
+			periods[len(periods)-1].EndedAt = &changedAt
+		case t.To != "active" && !open:
+			periods = append(periods, PoolInactivePeriod{Status: t.To, StartedAt: t.ChangedAt})
+		}
+	}
+
+	var status string
+	if field := reflect.ValueOf(pool).Elem().FieldByName("Status"); field.IsValid() && field.Kind() == reflect.String {
+		status = field.String()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":     address,
+		"pool_platform":    platform,
+		"pool_id":          poolID,
+		"status":           status,
+		"transitions":      transitions,
+		"inactive_periods": periods,
+	})
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"marketcontrol/internal/middleware"
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+)
+
+// maxBulkImportItems bounds the number of projects imported by one request
+const maxBulkImportItems = 200
+
+// bulkImportKind is one auto-create flow usable from the bulk import: newRequest returns the request
+// type of the flow so items can be validated before create runs it
+type bulkImportKind struct {
+	newRequest func() interface{}
+	create     func(logger *log.Entry, req interface{}) (*models.ProjectConfig, error)
+}
+
+var bulkImportKinds = map[string]bulkImportKind{
+	"pumpfuninternal": {
+		func() interface{} { return &AutoCreatePumpfuninternalProjectRequest{} },
+		func(logger *log.Entry, req interface{}) (*models.ProjectConfig, error) {
+			project, _, err := autoCreatePumpfuninternalProject(logger, *req.(*AutoCreatePumpfuninternalProjectRequest))
+			return project, err
+		},
+	},
+	"pumpfunamm": {
+		func() interface{} { return &AutoCreatePumpfunAmmProjectRequest{} },
+		func(logger *log.Entry, req interface{}) (*models.ProjectConfig, error) {
+			project, _, err := autoCreatePumpfunAmmProject(logger, *req.(*AutoCreatePumpfunAmmProjectRequest))
+			return project, err
+		},
+	},
+	"meteoradbc": {
+		func() interface{} { return &AutoCreateMeteoradbcProjectRequest{} },
+		func(logger *log.Entry, req interface{}) (*models.ProjectConfig, error) {
+			project, _, err := autoCreateMeteoradbcProject(logger, *req.(*AutoCreateMeteoradbcProjectRequest))
+			return project, err
+		},
+	},
+	"meteoradbc_v2": {
+		func() interface{} { return &AutoCreateMeteoradbcProjectRequestV2{} },
+		func(logger *log.Entry, req interface{}) (*models.ProjectConfig, error) {
+			project, _, err := autoCreateMeteoradbcProjectV2(logger, *req.(*AutoCreateMeteoradbcProjectRequestV2))
+			return project, err
+		},
+	},
+}
+
+// bulkImportAddressFields are the request fields (by JSON name, at any depth) that must be valid pubkeys
+var bulkImportAddressFields = map[string]bool{
+	"mint": true, "pool_address": true, "creator": true, "pool_config": true, "base_mint": true,
+	"quote_mint": true, "lp_mint": true, "pool_base_token_account": true, "pool_quote_token_account": true,
+	"first_buyer": true, "coin_creator": true, "fee_recipient": true, "damm_v2_pool_address": true,
+	"dbc_pool_address": true,
+}
+
+// BulkImportProjectItem is one project definition: kind selects the auto-create flow and payload is
+// exactly the body that flow's endpoint accepts
+type BulkImportProjectItem struct {
+	Kind    string          `json:"kind" binding:"required"` // pumpfuninternal, pumpfunamm, meteoradbc, meteoradbc_v2
+	Payload json.RawMessage `json:"payload" binding:"required"`
+}
+
+// BulkImportProjectsRequest represents the request body for BulkImportProjects
+type BulkImportProjectsRequest struct {
+	Items []BulkImportProjectItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// BulkImportResult reports the outcome of one item
+type BulkImportResult struct {
+	Index     int    `json:"index"`
+	Kind      string `json:"kind"`
+	Success   bool   `json:"success"`
+	Status    int    `json:"status"`
+	ProjectID uint   `json:"project_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkImportProjects validates and creates many projects through the existing auto-create flows.
+// Each item is checked first (payload shape, pubkeys, role and token metadata existence, pool not yet
+// configured) and then created by its flow in its own transaction, so a bad item never aborts the others.
+// Items are processed in order; the response lists every item's outcome and the created project IDs.
+func BulkImportProjects(c *gin.Context) {
+	var request BulkImportProjectsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(request.Items) > maxBulkImportItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("最多一次导入 %d 个项目", maxBulkImportItems)})
+		return
+	}
+
+	logger := middleware.RequestLogger(c)
+	results := make([]BulkImportResult, 0, len(request.Items))
+	projectIDs := []uint{}
+	for i, item := range request.Items {
+		result := BulkImportResult{Index: i, Kind: item.Kind}
+		kind, ok := bulkImportKinds[item.Kind]
+		if !ok {
+			result.Status = http.StatusBadRequest
+			result.Error = "unsupported kind: " + item.Kind
+			results = append(results, result)
+			continue
+		}
+		req, status, err := validateBulkImportItem(dbconfig.DB, kind, item.Payload)
+		if err != nil {
+			result.Status = status
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		project, err := kind.create(logger, req)
+		if err != nil {
+			result.Status = http.StatusInternalServerError
+			var createErr *autoCreateError
+			if errors.As(err, &createErr) {
+				result.Status = createErr.status
+			}
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Status = http.StatusCreated
+		result.ProjectID = project.ID
+		result.Success = true
+		projectIDs = append(projectIDs, project.ID)
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":         len(request.Items),
+		"created_count": len(projectIDs),
+		"failed_count":  len(request.Items) - len(projectIDs),
+		"project_ids":   projectIDs,
+		"results":       results,
+	})
+}
+
+// validateBulkImportItem decodes payload into the flow's request type, applies its binding rules and checks
+// the pubkeys and the referenced rows. It returns the decoded request, or the HTTP status describing the failure.
+func validateBulkImportItem(db *gorm.DB, kind bulkImportKind, payload json.RawMessage) (interface{}, int, error) {
+	req := kind.newRequest()
+	if err := json.Unmarshal(payload, req); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid payload: %w", err)
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err := validatePubkeyFields(reflect.ValueOf(req).Elem(), ""); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var refs struct {
+		RoleID          uint   `json:"role_id"`
+		TokenMetadataID uint   `json:"token_metadata_id"`
+		Mint            string `json:"mint"`
+		PoolConfig      struct {
+			PoolAddress string `json:"pool_address"`
+		} `json:"pool_config"`
+	}
+	_ = json.Unmarshal(payload, &refs)
+
+	if refs.RoleID != 0 {
+		if err := db.First(&models.RoleConfig{}, refs.RoleID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, http.StatusBadRequest, fmt.Errorf("role %d not found", refs.RoleID)
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+	if refs.TokenMetadataID != 0 {
+		if err := db.First(&models.TokenMetadata{}, refs.TokenMetadataID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, http.StatusBadRequest, fmt.Errorf("token metadata %d not found", refs.TokenMetadataID)
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	if address := refs.PoolConfig.PoolAddress; address != "" {
+		platform, _, _, err := resolvePoolByAddress(db, address)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if platform != "" {
+			return nil, http.StatusConflict, fmt.Errorf("pool %s is already configured as %s", address, platform)
+		}
+	} else if refs.Mint != "" {
+		// pumpfun 内盘没有池子地址，按 mint 判断是否已配置
+		var count int64
+		if err := db.Model(&models.PumpfuninternalConfig{}).Where("mint = ?", refs.Mint).Count(&count).Error; err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if count > 0 {
+			return nil, http.StatusConflict, fmt.Errorf("pumpfun internal pool for mint %s is already configured", refs.Mint)
+		}
+	}
+	return req, http.StatusOK, nil
+}
+
+// validatePubkeyFields walks a request struct and checks that every non-empty address field is a valid pubkey
+func validatePubkeyFields(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := validatePubkeyFields(field, prefix+name+"."); err != nil {
+				return err
+			}
+		case reflect.String:
+			if !bulkImportAddressFields[name] || field.String() == "" {
+				continue
+			}
+			if _, err := solana.PublicKeyFromBase58(field.String()); err != nil {
+				return fmt.Errorf("%s%s is not a valid address: %v", prefix, name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -1,18 +1,21 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	// "log"
 	"os"
 	"time"
 
+	"marketcontrol/internal/middleware"
 	"marketcontrol/internal/models"
-	"marketcontrol/pkg/config"
 	dbconfig "marketcontrol/pkg/config"
 	pumpsolana "marketcontrol/pkg/solana"
 	"marketcontrol/pkg/solana/meteora"
@@ -22,70 +25,66 @@ import (
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectConfigRequest represents the request body for creating/updating a project config
 type ProjectConfigRequest struct {
-	Name              *string         `json:"name"`
-	PoolPlatform      *string         `json:"pool_platform"`
-	PoolID            *uint           `json:"pool_id"`
-	TokenID           *uint           `json:"token_id"`
-	TokenMetadataID   *uint           `json:"token_metadata_id"`
-	SnapshotEnabled   *bool           `json:"snapshot_enabled"`
-	SnapshotCount     *int            `json:"snapshot_count"`
-	IsActive          *bool           `json:"is_active"`
-	UpdateStatEnabled *bool           `json:"update_stat_enabled"`
-	IsMigrated        *bool           `json:"is_migrated"`
-	IsLocked          *bool           `json:"is_locked"`
-	AssetsBalance     *float64        `json:"assets_balance"`
-	RetailSolAmount   *float64        `json:"retail_sol_amount"`
-	PoolConfig        *string         `json:"pool_config"`
-	Event             json.RawMessage `json:"event"`
-	Vesting           json.RawMessage `json:"vesting"`
+	Name              *string              `json:"name"`
+	PoolPlatform      *models.PoolPlatform `json:"pool_platform"`
+	PoolID            *uint                `json:"pool_id"`
+	TokenID           *uint                `json:"token_id"`
+	TokenMetadataID   *uint                `json:"token_metadata_id"`
+	SnapshotEnabled   *bool                `json:"snapshot_enabled"`
+	SnapshotCount     *int                 `json:"snapshot_count"`
+	IsActive          *bool                `json:"is_active"`
+	UpdateStatEnabled *bool                `json:"update_stat_enabled"`
+	IsMigrated        *bool                `json:"is_migrated"`
+	IsLocked          *bool                `json:"is_locked"`
+	AssetsBalance     *float64             `json:"assets_balance"`
+	RetailSolAmount   *float64             `json:"retail_sol_amount"`
+	PoolConfig        *string              `json:"pool_config"`
+	Event             json.RawMessage      `json:"event"`
+	Vesting           json.RawMessage      `json:"vesting"`
 }
 
 // ProjectConfigResp represents the response structure for a project config
 type ProjectConfigResp struct {
-	ID              uint                 `json:"id"`
-	Name            string               `json:"name"`
-	PoolPlatform    string               `json:"pool_platform"`
-	PoolID          uint                 `json:"pool_id"`
-	TokenID         uint                 `json:"token_id"`
-	TokenMetadataID uint                 `json:"token_metadata_id"`
-	IsActive        bool                 `json:"is_active"`
-	IsMigrated      bool                 `json:"is_migrated"`
-	IsLocked        bool                 `json:"is_locked"`
-	AssetsBalance   float64              `json:"assets_balance"`
-	RetailSolAmount float64              `json:"retail_sol_amount"`
-	PoolConfig      string               `json:"pool_config"`
-	Event           json.RawMessage      `json:"event"`
-	Vesting         json.RawMessage      `json:"vesting"`
-	ProjectProfit   float64              `json:"project_profit"`
-	CreatedAt       time.Time            `json:"created_at"`
-	UpdatedAt       time.Time            `json:"updated_at"`
-	Pool            interface{}          `json:"pool,omitempty"`
-	Token           *models.TokenConfig  `json:"token,omitempty"`
-	PoolRelation    interface{}          `json:"pool_relation"`
-	Status          *models.ProjecStatus `json:"status,omitempty"`
+	ID               uint                 `json:"id"`
+	Name             string               `json:"name"`
+	PoolPlatform     models.PoolPlatform  `json:"pool_platform"`
+	PoolID           uint                 `json:"pool_id"`
+	TokenID          uint                 `json:"token_id"`
+	TokenMetadataID  uint                 `json:"token_metadata_id"`
+	IsActive         bool                 `json:"is_active"`
+	IsMigrated       bool                 `json:"is_migrated"`
+	IsLocked         bool                 `json:"is_locked"`
+	AssetsBalance    float64              `json:"assets_balance"`
+	RetailSolAmount  float64              `json:"retail_sol_amount"`
+	PoolConfig       string               `json:"pool_config"`
+	Event            json.RawMessage      `json:"event"`
+	Vesting          json.RawMessage      `json:"vesting"`
+	ProjectProfit    float64              `json:"project_profit"`
+	ProfitBaselineID uint                 `json:"profit_baseline_id"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+	Pool             interface{}          `json:"pool,omitempty"`
+	Token            *models.TokenConfig  `json:"token,omitempty"`
+	PoolRelation     interface{}          `json:"pool_relation"`
+	Status           *models.ProjecStatus `json:"status,omitempty"`
+	SwapCount        *int64               `json:"swap_count,omitempty"`
+	HolderCount      *int64               `json:"holder_count,omitempty"`
+	AddressCount     *int64               `json:"address_count,omitempty"`
 }
 
 // ListProjectConfigs returns a list of all project configs
 func ListProjectConfigs(c *gin.Context) {
 	var projects []models.ProjectConfig
-	if err := dbconfig.DB.Find(&projects).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &projects) {
 		return
 	}
 
-	var respList []ProjectConfigResp
-	for _, project := range projects {
-		proj := project // Create a new variable to avoid pointer issues
-		if resp := buildProjectConfigResp(&proj); resp != nil {
-			respList = append(respList, *resp)
-		}
-	}
-
-	c.JSON(http.StatusOK, respList)
+	c.JSON(http.StatusOK, buildProjectConfigResps(projects))
 }
 
 // GetProjectConfig returns a specific project config by ID
@@ -108,9 +107,206 @@ func GetProjectConfig(c *gin.Context) {
 		return
 	}
 
+	if c.Query("with_counts") == "true" {
+		if err := attachProjectCounts(dbconfig.DB, resp); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
+// poolDataTables describes where a pool's swaps and holders are stored
+type poolDataTables struct {
+	SwapModel     interface{}
+	HolderModel   interface{}
+	PoolColumn    string
+	PoolValue     string
+	BalanceColumn string // holder column carrying the token position
+}
+
+// resolvePoolDataTables maps a pool config (as returned in ProjectConfigResp.Pool) to its swap/holder tables
+func resolvePoolDataTables(pool interface{}) (poolDataTables, bool) {
+	switch pool := pool.(type) {
+	case models.PumpfuninternalConfig:
+		return poolDataTables{&models.PumpfuninternalSwap{}, &models.PumpfuninternalHolder{}, "bonding_curve_pda", pool.BondingCurvePda, "mint_change"}, true
+	case models.PumpfunAmmPoolConfig:
+		return poolDataTables{&models.PumpfunAmmPoolSwap{}, &models.PumpfunAmmpoolHolder{}, "pool_address", pool.PoolAddress, "base_change"}, true
+	case models.RaydiumLaunchpadPoolConfig:
+		return poolDataTables{&models.RaydiumPoolSwap{}, &models.RaydiumPoolHolder{}, "pool_address", pool.PoolAddress, "base_change"}, true
+	case models.RaydiumCpmmPoolConfig:
+		return poolDataTables{&models.RaydiumPoolSwap{}, &models.RaydiumPoolHolder{}, "pool_address", pool.PoolAddress, "base_change"}, true
+	case models.MeteoradbcConfig:
+		return poolDataTables{&models.MeteoradbcSwap{}, &models.MeteoradbcHolder{}, "pool_address", pool.PoolAddress, "base_change"}, true
+	case models.MeteoracpmmConfig:
+		return poolDataTables{&models.MeteoracpmmSwap{}, &models.MeteoracpmmHolder{}, "pool_address", pool.PoolAddress, "base_change"}, true
+	}
+	return poolDataTables{}, false
+}
+
+// attachProjectCounts fills swap_count, holder_count and address_count on a project response,
+// counting against the swap/holder tables of the pool the response resolved to.
+func attachProjectCounts(db *gorm.DB, resp *ProjectConfigResp) error {
+	var swapCount, holderCount, addressCount int64
+	if tables, ok := resolvePoolDataTables(resp.Pool); ok && tables.PoolValue != "" {
+		if err := db.Model(tables.SwapModel).Where(tables.PoolColumn+" = ?", tables.PoolValue).Count(&swapCount).Error; err != nil {
+			return err
+		}
+		if err := db.Model(tables.HolderModel).Where(tables.PoolColumn+" = ?", tables.PoolValue).Count(&holderCount).Error; err != nil {
+			return err
+		}
+	}
+
+	// 与 GetAddressCountByProjectID 一致：项目下角色的去重地址数
+	if err := db.Model(&models.RoleAddress{}).
+		Distinct("address").
+		Joins("JOIN role_config ON role_address.role_id = role_config.id").
+		Where("role_config.project_id = ?", resp.ID).
+		Count(&addressCount).Error; err != nil {
+		return err
+	}
+
+	resp.SwapCount = &swapCount
+	resp.HolderCount = &holderCount
+	resp.AddressCount = &addressCount
+	return nil
+}
+
+// GetHolderGrowth returns holder adoption for a project over a time window
+// Query parameters: start_timestamp / end_timestamp (unix seconds), or hours (default: 24) ending now
+func GetHolderGrowth(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	endTimestamp := uint64(handlerClock.Now().Unix())
+	if et := c.Query("end_timestamp"); et != "" {
+		if endTimestamp, err = strconv.ParseUint(et, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_timestamp"})
+			return
+		}
+	}
+	hours := 24
+	if h := c.Query("hours"); h != "" {
+		if parsed, err := strconv.Atoi(h); err == nil && parsed > 0 {
+			hours = parsed
+		}
+	}
+	startTimestamp := endTimestamp - uint64(hours)*3600
+	if st := c.Query("start_timestamp"); st != "" {
+		if startTimestamp, err = strconv.ParseUint(st, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_timestamp"})
+			return
+		}
+	}
+	if startTimestamp >= endTimestamp {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_timestamp must be before end_timestamp"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+	resp := buildProjectConfigResp(&project)
+	tables, ok := resolvePoolDataTables(resp.Pool)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform: " + resp.PoolPlatform})
+		return
+	}
+
+	holders := func() *gorm.DB {
+		return dbconfig.DB.Model(tables.HolderModel).Where(tables.PoolColumn+" = ?", tables.PoolValue)
+	}
+
+	// 新增：首次交易落在窗口内的持有人
+	var newHolders int64
+	if err := holders().Where("start_timestamp >= ? AND start_timestamp < ?", startTimestamp, endTimestamp).
+		Count(&newHolders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 回流：窗口前已入场、窗口内再次交易且仍持仓
+	var returningHolders int64
+	if err := holders().Where("start_timestamp < ? AND last_timestamp >= ? AND last_timestamp < ? AND "+tables.BalanceColumn+" > 0",
+		startTimestamp, startTimestamp, endTimestamp).
+		Count(&returningHolders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 流失：窗口内最后一次交易后已清仓
+	var exitedHolders int64
+	if err := holders().Where("last_timestamp >= ? AND last_timestamp < ? AND "+tables.BalanceColumn+" <= 0", startTimestamp, endTimestamp).
+		Count(&exitedHolders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":        resp.ID,
+		"pool_platform":     resp.PoolPlatform,
+		"pool_address":      tables.PoolValue,
+		"start_timestamp":   startTimestamp,
+		"end_timestamp":     endTimestamp,
+		"new_holders":       newHolders,
+		"returning_holders": returningHolders,
+		"exited_holders":    exitedHolders,
+		"net_holder_change": newHolders - exitedHolders,
+	})
+}
+
+// GetProjectsByIDsRequest represents the request body for fetching several projects at once
+type GetProjectsByIDsRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=500"`
+}
+
+// GetProjectsByIDs returns the requested project configs in request order, plus the IDs that were not found
+func GetProjectsByIDs(c *gin.Context) {
+	var request GetProjectsByIDsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var projects []models.ProjectConfig
+	if err := dbconfig.DB.Where("id IN ?", request.IDs).Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	byID := make(map[uint]models.ProjectConfig, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+
+	// 按请求顺序返回，重复 ID 只返回一次
+	ordered := make([]models.ProjectConfig, 0, len(projects))
+	notFound := []uint{}
+	seen := make(map[uint]bool, len(request.IDs))
+	for _, id := range request.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if project, ok := byID[id]; ok {
+			ordered = append(ordered, project)
+		} else {
+			notFound = append(notFound, id)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":          buildProjectConfigResps(ordered),
+		"not_found_ids": notFound,
+	})
+}
+
 // CreateProjectConfig creates a new project config
 func CreateProjectConfig(c *gin.Context) {
 	var request ProjectConfigRequest
@@ -126,9 +322,8 @@ func CreateProjectConfig(c *gin.Context) {
 	}
 
 	// 验证池子平台类型
-	if *request.PoolPlatform != "raydium" && *request.PoolPlatform != "pumpfun_internal" && *request.PoolPlatform != "pumpfun_amm" &&
-		*request.PoolPlatform != "raydium_launchpad" && *request.PoolPlatform != "raydium_cpmm" && *request.PoolPlatform != "meteora_dbc" && *request.PoolPlatform != "meteora_cpmm" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "pool_platform 必须是 raydium, pumpfun_internal, pumpfun_amm, raydium_launchpad, raydium_cpmm, meteora_dbc 或 meteora_cpmm 之一"})
+	if !request.PoolPlatform.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pool_platform 必须是 " + strings.Join(models.PoolPlatformNames(), ", ") + " 之一"})
 		return
 	}
 
@@ -147,43 +342,43 @@ func CreateProjectConfig(c *gin.Context) {
 
 	// Verify pool exists based on platform
 	switch *request.PoolPlatform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var pool models.PoolConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium pool not found"})
 			return
 		}
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var pool models.PumpfuninternalConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Pumpfun pool not found"})
 			return
 		}
-	case "pumpfun_amm":
+	case models.PoolPlatformPumpfunAmm:
 		var pool models.PumpfunAmmPoolConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: PumpfunAmm pool not found"})
 			return
 		}
-	case "raydium_launchpad":
+	case models.PoolPlatformRaydiumLaunchpad:
 		var pool models.RaydiumLaunchpadPoolConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium Launchpad pool not found"})
 			return
 		}
-	case "raydium_cpmm":
+	case models.PoolPlatformRaydiumCpmm:
 		var pool models.RaydiumCpmmPoolConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium CPMM pool not found"})
 			return
 		}
-	case "meteora_dbc":
+	case models.PoolPlatformMeteoraDbc:
 		var pool models.MeteoradbcConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoradbc pool not found"})
 			return
 		}
-	case "meteora_cpmm":
+	case models.PoolPlatformMeteoraCpmm:
 		var pool models.MeteoracpmmConfig
 		if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoracpmm pool not found"})
@@ -268,47 +463,52 @@ func UpdateProjectConfig(c *gin.Context) {
 		return
 	}
 
+	if request.PoolPlatform != nil && !request.PoolPlatform.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pool_platform 必须是 " + strings.Join(models.PoolPlatformNames(), ", ") + " 之一"})
+		return
+	}
+
 	// 验证池子平台和ID的关联性
 	if request.PoolPlatform != nil && request.PoolID != nil {
 		// Verify pool exists based on platform
 		switch *request.PoolPlatform {
-		case "raydium":
+		case models.PoolPlatformRaydium:
 			var pool models.PoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium pool not found"})
 				return
 			}
-		case "pumpfun_internal":
+		case models.PoolPlatformPumpfunInternal:
 			var pool models.PumpfuninternalConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Pumpfun pool not found"})
 				return
 			}
-		case "pumpfun_amm":
+		case models.PoolPlatformPumpfunAmm:
 			var pool models.PumpfunAmmPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: PumpfunAmm pool not found"})
 				return
 			}
-		case "raydium_launchpad":
+		case models.PoolPlatformRaydiumLaunchpad:
 			var pool models.RaydiumLaunchpadPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium Launchpad pool not found"})
 				return
 			}
-		case "raydium_cpmm":
+		case models.PoolPlatformRaydiumCpmm:
 			var pool models.RaydiumCpmmPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium CPMM pool not found"})
 				return
 			}
-		case "meteora_dbc":
+		case models.PoolPlatformMeteoraDbc:
 			var pool models.MeteoradbcConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoradbc pool not found"})
 				return
 			}
-		case "meteora_cpmm":
+		case models.PoolPlatformMeteoraCpmm:
 			var pool models.MeteoracpmmConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoracpmm pool not found"})
@@ -321,43 +521,43 @@ func UpdateProjectConfig(c *gin.Context) {
 	} else if request.PoolPlatform != nil {
 		// 如果只提供了平台，验证现有池子ID是否匹配
 		switch *request.PoolPlatform {
-		case "raydium":
+		case models.PoolPlatformRaydium:
 			var pool models.PoolConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Raydium platform"})
 				return
 			}
-		case "pumpfun_internal":
+		case models.PoolPlatformPumpfunInternal:
 			var pool models.PumpfuninternalConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Pumpfun platform"})
 				return
 			}
-		case "pumpfun_amm":
+		case models.PoolPlatformPumpfunAmm:
 			var pool models.PumpfunAmmPoolConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match PumpfunAmm platform"})
 				return
 			}
-		case "raydium_launchpad":
+		case models.PoolPlatformRaydiumLaunchpad:
 			var pool models.RaydiumLaunchpadPoolConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Raydium Launchpad platform"})
 				return
 			}
-		case "raydium_cpmm":
+		case models.PoolPlatformRaydiumCpmm:
 			var pool models.RaydiumCpmmPoolConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Raydium CPMM platform"})
 				return
 			}
-		case "meteora_dbc":
+		case models.PoolPlatformMeteoraDbc:
 			var pool models.MeteoradbcConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Meteoradbc platform"})
 				return
 			}
-		case "meteora_cpmm":
+		case models.PoolPlatformMeteoraCpmm:
 			var pool models.MeteoracpmmConfig
 			if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Existing pool_id does not match Meteoracpmm platform"})
@@ -370,43 +570,43 @@ func UpdateProjectConfig(c *gin.Context) {
 	} else if request.PoolID != nil {
 		// 如果只提供了池子ID，验证是否匹配现有平台
 		switch project.PoolPlatform {
-		case "raydium":
+		case models.PoolPlatformRaydium:
 			var pool models.PoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium pool not found"})
 				return
 			}
-		case "pumpfun_internal":
+		case models.PoolPlatformPumpfunInternal:
 			var pool models.PumpfuninternalConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Pumpfun pool not found"})
 				return
 			}
-		case "pumpfun_amm":
+		case models.PoolPlatformPumpfunAmm:
 			var pool models.PumpfunAmmPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: PumpfunAmm pool not found"})
 				return
 			}
-		case "raydium_launchpad":
+		case models.PoolPlatformRaydiumLaunchpad:
 			var pool models.RaydiumLaunchpadPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium Launchpad pool not found"})
 				return
 			}
-		case "raydium_cpmm":
+		case models.PoolPlatformRaydiumCpmm:
 			var pool models.RaydiumCpmmPoolConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Raydium CPMM pool not found"})
 				return
 			}
-		case "meteora_dbc":
+		case models.PoolPlatformMeteoraDbc:
 			var pool models.MeteoradbcConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoradbc pool not found"})
 				return
 			}
-		case "meteora_cpmm":
+		case models.PoolPlatformMeteoraCpmm:
 			var pool models.MeteoracpmmConfig
 			if err := dbconfig.DB.First(&pool, *request.PoolID).Error; err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id: Meteoracpmm pool not found"})
@@ -495,6 +695,14 @@ func UpdateProjectConfig(c *gin.Context) {
 		return
 	}
 
+	// assets_balance 变更时同步保存 project_profit
+	if request.AssetsBalance != nil {
+		if err := syncProjectProfit(dbconfig.DB, project.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project profit: " + err.Error()})
+			return
+		}
+	}
+
 	// 重新加载项目并使用新的响应结构
 	if err := dbconfig.DB.Preload("Token").First(&project, project.ID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load project associations"})
@@ -553,81 +761,197 @@ func DeleteProjectConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 }
 
+// projectRespLoader 批量预加载项目响应所需的池子、Token、状态及池子关系，避免逐个项目查询
+type projectRespLoader struct {
+	pools                map[models.PoolPlatform]map[uint]interface{}
+	tokens               map[uint]models.TokenConfig
+	statuses             map[uint]models.ProjecStatus
+	meteoracpmmByAddress map[string]models.MeteoracpmmConfig
+	raydiumRelations     map[string]models.RaydiumPoolRelation
+	raydiumCpmmByAddress map[string]models.RaydiumCpmmPoolConfig
+}
+
+// newProjectRespLoader loads everything buildProjectConfigResp needs for the given projects
+// with one query per table instead of one per project.
+func newProjectRespLoader(db *gorm.DB, projects []models.ProjectConfig) *projectRespLoader {
+	loader := &projectRespLoader{
+		pools:                map[models.PoolPlatform]map[uint]interface{}{},
+		tokens:               map[uint]models.TokenConfig{},
+		statuses:             map[uint]models.ProjecStatus{},
+		meteoracpmmByAddress: map[string]models.MeteoracpmmConfig{},
+		raydiumRelations:     map[string]models.RaydiumPoolRelation{},
+		raydiumCpmmByAddress: map[string]models.RaydiumCpmmPoolConfig{},
+	}
+	if len(projects) == 0 {
+		return loader
+	}
+
+	poolIDs := map[models.PoolPlatform][]uint{}
+	var tokenIDs, projectIDs []uint
+	for _, project := range projects {
+		poolIDs[project.PoolPlatform] = append(poolIDs[project.PoolPlatform], project.PoolID)
+		tokenIDs = append(tokenIDs, project.TokenID)
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	var dammV2Addresses, launchpadAddresses []string
+	for platform, ids := range poolIDs {
+		byID := map[uint]interface{}{}
+		switch platform {
+		case models.PoolPlatformRaydium:
+			var rows []models.PoolConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+		case models.PoolPlatformPumpfunInternal:
+			var rows []models.PumpfuninternalConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+		case models.PoolPlatformPumpfunAmm:
+			var rows []models.PumpfunAmmPoolConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+		case models.PoolPlatformRaydiumLaunchpad:
+			var rows []models.RaydiumLaunchpadPoolConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+				launchpadAddresses = append(launchpadAddresses, row.PoolAddress)
+			}
+		case models.PoolPlatformRaydiumCpmm:
+			var rows []models.RaydiumCpmmPoolConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+		case models.PoolPlatformMeteoraDbc:
+			var rows []models.MeteoradbcConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+				if row.DammV2PoolAddress != "" {
+					dammV2Addresses = append(dammV2Addresses, row.DammV2PoolAddress)
+				}
+			}
+		case models.PoolPlatformMeteoraCpmm:
+			var rows []models.MeteoracpmmConfig
+			db.Where("id IN ?", ids).Find(&rows)
+			for _, row := range rows {
+				byID[row.ID] = row
+			}
+		}
+		loader.pools[platform] = byID
+	}
+
+	// 与 First 保持一致：同一地址取 id 最小的记录
+	if len(dammV2Addresses) > 0 {
+		var rows []models.MeteoracpmmConfig
+		db.Where("pool_address IN ?", dammV2Addresses).Order("id asc").Find(&rows)
+		for _, row := range rows {
+			if _, exists := loader.meteoracpmmByAddress[row.PoolAddress]; !exists {
+				loader.meteoracpmmByAddress[row.PoolAddress] = row
+			}
+		}
+	}
+
+	if len(launchpadAddresses) > 0 {
+		var relations []models.RaydiumPoolRelation
+		db.Where("launchpad_pool_id IN ?", launchpadAddresses).Order("id asc").Find(&relations)
+		var cpmmAddresses []string
+		for _, relation := range relations {
+			if _, exists := loader.raydiumRelations[relation.LaunchpadPoolID]; !exists {
+				loader.raydiumRelations[relation.LaunchpadPoolID] = relation
+				cpmmAddresses = append(cpmmAddresses, relation.CpmmPoolID)
+			}
+		}
+		if len(cpmmAddresses) > 0 {
+			var rows []models.RaydiumCpmmPoolConfig
+			db.Where("pool_address IN ?", cpmmAddresses).Order("id asc").Find(&rows)
+			for _, row := range rows {
+				if _, exists := loader.raydiumCpmmByAddress[row.PoolAddress]; !exists {
+					loader.raydiumCpmmByAddress[row.PoolAddress] = row
+				}
+			}
+		}
+	}
+
+	var tokens []models.TokenConfig
+	db.Where("id IN ?", tokenIDs).Find(&tokens)
+	for _, token := range tokens {
+		loader.tokens[token.ID] = token
+	}
+
+	var statuses []models.ProjecStatus
+	db.Where("project_id IN ?", projectIDs).Order("id asc").Find(&statuses)
+	for _, status := range statuses {
+		if _, exists := loader.statuses[status.ProjectID]; !exists {
+			loader.statuses[status.ProjectID] = status
+		}
+	}
+
+	return loader
+}
+
+// buildProjectConfigResps 批量构建项目配置响应，保持输入顺序
+func buildProjectConfigResps(projects []models.ProjectConfig) []ProjectConfigResp {
+	loader := newProjectRespLoader(dbconfig.DB, projects)
+	respList := make([]ProjectConfigResp, 0, len(projects))
+	for i := range projects {
+		if resp := loader.build(&projects[i]); resp != nil {
+			respList = append(respList, *resp)
+		}
+	}
+	return respList
+}
+
 // buildProjectConfigResp 构建项目配置响应
 func buildProjectConfigResp(project *models.ProjectConfig) *ProjectConfigResp {
 	if project == nil {
 		return nil
 	}
+	return newProjectRespLoader(dbconfig.DB, []models.ProjectConfig{*project}).build(project)
+}
+
+// build 使用预加载的数据构建单个项目的响应
+func (l *projectRespLoader) build(project *models.ProjectConfig) *ProjectConfigResp {
+	if project == nil {
+		return nil
+	}
 
 	// 查询池子
-	var pool interface{}
+	pool := l.pools[project.PoolPlatform][project.PoolID]
 	// 用于 meteora_dbc 衍生关系
 	var meteoradbcForRelation *models.MeteoradbcConfig
-	switch project.PoolPlatform {
-	case "raydium":
-		var raydiumPool models.PoolConfig
-		if err := dbconfig.DB.First(&raydiumPool, project.PoolID).Error; err == nil {
-			pool = raydiumPool
-		}
-	case "pumpfun_internal":
-		var pumpfunPool models.PumpfuninternalConfig
-		if err := dbconfig.DB.First(&pumpfunPool, project.PoolID).Error; err == nil {
-			pool = pumpfunPool
-		}
-	case "pumpfun_amm":
-		var pumpfunAmmPool models.PumpfunAmmPoolConfig
-		if err := dbconfig.DB.First(&pumpfunAmmPool, project.PoolID).Error; err == nil {
-			pool = pumpfunAmmPool
-		}
-	case "raydium_launchpad":
-		var raydiumLaunchpadPool models.RaydiumLaunchpadPoolConfig
-		if err := dbconfig.DB.First(&raydiumLaunchpadPool, project.PoolID).Error; err == nil {
-			pool = raydiumLaunchpadPool
-		}
-	case "raydium_cpmm":
-		var raydiumCpmmPool models.RaydiumCpmmPoolConfig
-		if err := dbconfig.DB.First(&raydiumCpmmPool, project.PoolID).Error; err == nil {
-			pool = raydiumCpmmPool
-		}
-	case "meteora_dbc":
-		var meteoradbcPool models.MeteoradbcConfig
-		if err := dbconfig.DB.First(&meteoradbcPool, project.PoolID).Error; err == nil {
-			pool = meteoradbcPool
-			meteoradbcForRelation = &meteoradbcPool
-			// 检查 IsMigrated 是否为真
-			if meteoradbcPool.IsMigrated && meteoradbcPool.DammV2PoolAddress != "" {
-				// 查询对应的 MeteoracpmmConfig
-				var meteoracpmmConfig models.MeteoracpmmConfig
-				if err := dbconfig.DB.Where("pool_address = ?", meteoradbcPool.DammV2PoolAddress).First(&meteoracpmmConfig).Error; err == nil {
-					// 直接修改 pool_platform, pool_id 和 pool 的数据
-					project.PoolPlatform = "meteora_cpmm"
-					project.PoolID = meteoracpmmConfig.ID
-					pool = meteoracpmmConfig
-				}
+	if meteoradbcPool, ok := pool.(models.MeteoradbcConfig); ok {
+		meteoradbcForRelation = &meteoradbcPool
+		// 检查 IsMigrated 是否为真
+		if meteoradbcPool.IsMigrated && meteoradbcPool.DammV2PoolAddress != "" {
+			if meteoracpmmConfig, ok := l.meteoracpmmByAddress[meteoradbcPool.DammV2PoolAddress]; ok {
+				// 直接修改 pool_platform, pool_id 和 pool 的数据
+				project.PoolPlatform = models.PoolPlatformMeteoraCpmm
+				project.PoolID = meteoracpmmConfig.ID
+				pool = meteoracpmmConfig
 			}
 		}
-	case "meteora_cpmm":
-		var meteoracpmmPool models.MeteoracpmmConfig
-		if err := dbconfig.DB.First(&meteoracpmmPool, project.PoolID).Error; err == nil {
-			pool = meteoracpmmPool
-		}
 	}
 
 	// 查询 Token
-	var token models.TokenConfig
-	dbconfig.DB.First(&token, project.TokenID)
+	token := l.tokens[project.TokenID]
 
 	// 查询 PoolRelation，默认为空字典
 	var poolRelation interface{} = map[string]interface{}{}
 
 	// 当 project.PoolPlatform 为 raydium_launchpad 时，尝试查询 RaydiumPoolRelation
-	if project.PoolPlatform == "raydium_launchpad" {
+	if project.PoolPlatform == models.PoolPlatformRaydiumLaunchpad {
 		if raydiumLaunchpadPool, ok := pool.(models.RaydiumLaunchpadPoolConfig); ok {
-			var relation models.RaydiumPoolRelation
-			if err := dbconfig.DB.Where("launchpad_pool_id = ?", raydiumLaunchpadPool.PoolAddress).First(&relation).Error; err == nil {
+			if relation, ok := l.raydiumRelations[raydiumLaunchpadPool.PoolAddress]; ok {
 				// 如果找到 RaydiumPoolRelation，尝试获取对应的 RaydiumCpmmPoolConfig
-				var cpmmPoolConfig models.RaydiumCpmmPoolConfig
-				if err := dbconfig.DB.Where("pool_address = ?", relation.CpmmPoolID).First(&cpmmPoolConfig).Error; err == nil {
+				if cpmmPoolConfig, ok := l.raydiumCpmmByAddress[relation.CpmmPoolID]; ok {
 					// 构建 PoolRelation 响应
 					poolRelation = map[string]interface{}{
 						"relation":         relation,
@@ -645,57 +969,39 @@ func buildProjectConfigResp(project *models.ProjectConfig) *ProjectConfigResp {
 
 	// 当平台为 meteora_dbc 时，返回对应 DammV2PoolAddress 的 MeteoracpmmConfig 于 PoolRelation
 	if meteoradbcForRelation != nil && meteoradbcForRelation.DammV2PoolAddress != "" {
-		var meteoracpmmConfig models.MeteoracpmmConfig
-		if err := dbconfig.DB.Where("pool_address = ?", meteoradbcForRelation.DammV2PoolAddress).First(&meteoracpmmConfig).Error; err == nil {
-			if pr, ok := poolRelation.(map[string]interface{}); ok {
-				pr["meteoracpmm_config"] = meteoracpmmConfig
-				poolRelation = pr
-			} else {
-				poolRelation = map[string]interface{}{
-					"meteoracpmm_config": meteoracpmmConfig,
-				}
-			}
+		if meteoracpmmConfig, ok := l.meteoracpmmByAddress[meteoradbcForRelation.DammV2PoolAddress]; ok {
+			poolRelation.(map[string]interface{})["meteoracpmm_config"] = meteoracpmmConfig
 		}
 	}
 
-	// 计算 project_profit: 当前 id 的 assets_balance 减去上一个 id 的 assets_balance
-	projectProfit := 0.0
-	if project.ID > 1 {
-		var previousProject models.ProjectConfig
-		if err := dbconfig.DB.First(&previousProject, project.ID-1).Error; err == nil {
-			projectProfit = project.AssetsBalance - previousProject.AssetsBalance
-		}
-		// 如果找不到上一个 id，projectProfit 保持为 0.0（默认值）
-	}
-
 	var projecStatus *models.ProjecStatus
-	var statusRow models.ProjecStatus
-	if err := dbconfig.DB.Where("project_id = ?", project.ID).First(&statusRow).Error; err == nil {
+	if statusRow, ok := l.statuses[project.ID]; ok {
 		projecStatus = &statusRow
 	}
 
 	return &ProjectConfigResp{
-		ID:              project.ID,
-		Name:            project.Name,
-		PoolPlatform:    project.PoolPlatform,
-		PoolID:          project.PoolID,
-		TokenID:         project.TokenID,
-		TokenMetadataID: project.TokenMetadataID,
-		IsActive:        project.IsActive,
-		IsMigrated:      project.IsMigrated,
-		IsLocked:        project.IsLocked,
-		AssetsBalance:   project.AssetsBalance,
-		RetailSolAmount: project.RetailSolAmount,
-		PoolConfig:      project.PoolConfig,
-		Event:           project.Event,
-		Vesting:         project.Vesting,
-		ProjectProfit:   projectProfit,
-		CreatedAt:       project.CreatedAt,
-		UpdatedAt:       project.UpdatedAt,
-		Pool:            pool,
-		Token:           &token,
-		PoolRelation:    poolRelation,
-		Status:          projecStatus,
+		ID:               project.ID,
+		Name:             project.Name,
+		PoolPlatform:     project.PoolPlatform,
+		PoolID:           project.PoolID,
+		TokenID:          project.TokenID,
+		TokenMetadataID:  project.TokenMetadataID,
+		IsActive:         project.IsActive,
+		IsMigrated:       project.IsMigrated,
+		IsLocked:         project.IsLocked,
+		AssetsBalance:    project.AssetsBalance,
+		RetailSolAmount:  project.RetailSolAmount,
+		PoolConfig:       project.PoolConfig,
+		Event:            project.Event,
+		Vesting:          project.Vesting,
+		ProjectProfit:    project.ProjectProfit,
+		ProfitBaselineID: project.ProfitBaselineID,
+		CreatedAt:        project.CreatedAt,
+		UpdatedAt:        project.UpdatedAt,
+		Pool:             pool,
+		Token:            &token,
+		PoolRelation:     poolRelation,
+		Status:           projecStatus,
 	}
 }
 
@@ -742,8 +1048,7 @@ func CreateProjectFundTransferRecord(c *gin.Context) {
 // ListProjectFundTransferRecords returns all project fund transfer records
 func ListProjectFundTransferRecords(c *gin.Context) {
 	var records []models.ProjectFundTransferRecord
-	if err := dbconfig.DB.Find(&records).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &records) {
 		return
 	}
 	c.JSON(http.StatusOK, records)
@@ -765,7 +1070,9 @@ func GetProjectFundTransferRecord(c *gin.Context) {
 	c.JSON(http.StatusOK, record)
 }
 
-// GetProjectFundTransferRecordsByProjectID returns all records for a specific project
+// GetProjectFundTransferRecordsByProjectID returns the records of a specific project.
+// Optional filters: mint, direction, target_name. Passing page (and page_size) paginates the list;
+// summary=true returns per-mint in/out/net totals instead of the records.
 func GetProjectFundTransferRecordsByProjectID(c *gin.Context) {
 	projectID, err := strconv.Atoi(c.Param("project_id"))
 	if err != nil {
@@ -773,12 +1080,122 @@ func GetProjectFundTransferRecordsByProjectID(c *gin.Context) {
 		return
 	}
 
+	// 可选过滤条件：mint / direction / target_name；mint 传 sol 或 WSOL 时两种记录都会匹配
+	query := dbconfig.DB.Model(&models.ProjectFundTransferRecord{}).Where("project_id = ?", projectID)
+	for _, key := range []string{"mint", "direction", "target_name"} {
+		v := c.Query(key)
+		switch {
+		case v == "":
+		case key == "mint" && pumpsolana.IsSolMint(v):
+			query = query.Where(pumpsolana.CanonicalMintSQL("mint")+" = ?", pumpsolana.NativeSolMint)
+		default:
+			query = query.Where(key+" = ?", v)
+		}
+	}
+
+	// summary=true 时返回按 mint 汇总的净额
+	if c.Query("summary") == "true" {
+		summaries, err := summarizeFundTransfers(query)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"project_id": projectID,
+			"data":       summaries,
+		})
+		return
+	}
+
+	// 未传 page 时保持原有的完整列表返回
+	if c.Query("page") == "" {
+		var records []models.ProjectFundTransferRecord
+		if err := query.Order("id asc").Find(&records).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, records)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 500 {
+		pageSize = 20
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	var records []models.ProjectFundTransferRecord
-	if err := dbconfig.DB.Where("project_id = ?", projectID).Find(&records).Error; err != nil {
+	if err := query.Order("id asc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&records).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, records)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       records,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// FundTransferMintSummary is the per-mint total of a project's fund transfer records
+type FundTransferMintSummary struct {
+	Mint         string  `json:"mint"`
+	InAmount     float64 `json:"in_amount"`
+	OutAmount    float64 `json:"out_amount"`
+	NetAmount    float64 `json:"net_amount"` // in - out
+	InCount      int64   `json:"in_count"`
+	OutCount     int64   `json:"out_count"`
+	RecordsCount int64   `json:"records_count"`
+}
+
+// summarizeFundTransfers groups the fund transfer records matched by query by (mint, direction) in SQL
+// and folds them into per-mint in/out/net totals, ordered by mint. "sol" and WSOL records are summed
+// under the canonical "sol" mint.
+func summarizeFundTransfers(query *gorm.DB) ([]FundTransferMintSummary, error) {
+	var rows []struct {
+		Mint      string
+		Direction string
+		Amount    float64
+		Count     int64
+	}
+	mint := pumpsolana.CanonicalMintSQL("mint")
+	if err := query.Select(mint + " AS mint, direction, COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count").
+		Group(mint + ", direction").
+		Order("mint asc").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	summaries := []FundTransferMintSummary{}
+	index := map[string]int{}
+	for _, row := range rows {
+		i, ok := index[row.Mint]
+		if !ok {
+			i = len(summaries)
+			index[row.Mint] = i
+			summaries = append(summaries, FundTransferMintSummary{Mint: row.Mint})
+		}
+		summary := &summaries[i]
+		switch row.Direction {
+		case "in":
+			summary.InAmount += row.Amount
+			summary.InCount += row.Count
+		case "out":
+			summary.OutAmount += row.Amount
+			summary.OutCount += row.Count
+		}
+		summary.NetAmount = summary.InAmount - summary.OutAmount
+		summary.RecordsCount += row.Count
+	}
+	return summaries, nil
 }
 
 // UpdateProjectFundTransferRecord updates an existing project fund transfer record
@@ -789,6 +1206,7 @@ func UpdateProjectFundTransferRecord(c *gin.Context) {
 		return
 	}
 
+	// 与创建接口共用请求结构体，direction / target_name 按同样的枚举校验
 	var request ProjectFundTransferRecordRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -812,6 +1230,7 @@ func UpdateProjectFundTransferRecord(c *gin.Context) {
 	record.Mint = request.Mint
 	record.Direction = request.Direction
 	record.Amount = request.Amount
+	record.TargetName = request.TargetName
 
 	if err := dbconfig.DB.Save(&record).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -852,27 +1271,147 @@ func GetProjectInitialSol(c *gin.Context) {
 		return
 	}
 
-	// Query all SOL records for this project
-	var records []models.ProjectFundTransferRecord
-	if err := dbconfig.DB.Where("project_id = ? AND mint = ?", projectID, "sol").Find(&records).Error; err != nil {
+	// Calculate net amount (in - out) of all SOL records for this project, native "sol" and WSOL alike
+	summaries, err := summarizeFundTransfers(dbconfig.DB.Model(&models.ProjectFundTransferRecord{}).
+		Where("project_id = ? AND "+pumpsolana.CanonicalMintSQL("mint")+" = ?", projectID, pumpsolana.NativeSolMint))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Calculate net amount (in - out)
 	var projectInitialSol float64
-	for _, record := range records {
-		if record.Direction == "in" {
-			projectInitialSol += record.Amount
-		} else if record.Direction == "out" {
-			projectInitialSol -= record.Amount
-		}
+	var recordsCount int64
+	for _, summary := range summaries {
+		projectInitialSol += summary.NetAmount
+		recordsCount += summary.RecordsCount
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"project_id":          projectID,
 		"project_initial_sol": projectInitialSol,
-		"records_count":       len(records),
+		"records_count":       recordsCount,
+	})
+}
+
+// defaultSolReconciliationTolerance absorbs transaction fees and rent that move SOL outside swaps and transfers
+const defaultSolReconciliationTolerance = 0.01
+
+// SolReconciliationPool is the net SOL of the project's addresses from the swaps of one pool
+type SolReconciliationPool struct {
+	PoolPlatform models.PoolPlatform `json:"pool_platform"`
+	PoolAddress  string              `json:"pool_address"`
+	SwapCount    int64               `json:"swap_count"`
+	NetSol       float64             `json:"net_sol"`
+}
+
+// ReconcileProjectSol cross-checks a project's SOL accounting: the net SOL transferred to the project
+// (fund transfer records with target_name "project") plus the net SOL its addresses made in swaps across
+// the project's pools should match the SOL its addresses hold in wallet_token_stat. "sol" and WSOL are
+// treated as the same asset on every side; swaps of pools not quoted in SOL are ignored.
+// Query parameters: tolerance (SOL, default: 0.01) - discrepancy is true when |difference| exceeds it
+func ReconcileProjectSol(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	tolerance := defaultSolReconciliationTolerance
+	if t := c.Query("tolerance"); t != "" {
+		if tolerance, err = strconv.ParseFloat(t, 64); err != nil || tolerance < 0 || math.IsNaN(tolerance) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tolerance"})
+			return
+		}
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	solMint := pumpsolana.CanonicalMintSQL("mint")
+
+	// 1. 资金划转：按 target_name 汇总 SOL 净额，project 部分参与对账
+	var transfers []struct {
+		TargetName string
+		NetAmount  float64
+	}
+	if err := dbconfig.DB.Model(&models.ProjectFundTransferRecord{}).
+		Select("target_name, COALESCE(SUM(CASE WHEN direction = 'in' THEN amount WHEN direction = 'out' THEN -amount ELSE 0 END), 0) AS net_amount").
+		Where("project_id = ? AND "+solMint+" = ?", project.ID, pumpsolana.NativeSolMint).
+		Group("target_name").
+		Scan(&transfers).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	transferByTarget := map[string]float64{}
+	for _, t := range transfers {
+		transferByTarget[t.TargetName] = t.NetAmount
+	}
+	transferSol := transferByTarget["project"]
+
+	// 2. 项目地址在各池子中 swap 的 SOL 净变化
+	projectAddresses := dbconfig.DB.Table("role_address").
+		Select("DISTINCT role_address.address").
+		Joins("JOIN role_config ON role_address.role_id = role_config.id").
+		Where("role_config.project_id = ?", project.ID)
+
+	platforms, tables, err := projectPoolTables(&project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	pools := []SolReconciliationPool{}
+	var swapSol float64
+	for i, t := range tables {
+		_, solColumn := traderChangeColumns(platforms[i])
+		query := dbconfig.DB.Model(t.SwapModel).
+			Select(fmt.Sprintf("COUNT(*) AS swap_count, COALESCE(SUM(%s), 0) AS net_sol", solColumn)).
+			Where(t.PoolColumn+" = ? AND address IN (?)", t.PoolValue, projectAddresses)
+		// pumpfun 内盘的 trader_sol_change 本身就是 SOL，其余池子只统计以 SOL 计价的 swap
+		if platforms[i] != models.PoolPlatformPumpfunInternal {
+			query = query.Where(pumpsolana.CanonicalMintSQL("quote_mint")+" = ?", pumpsolana.NativeSolMint)
+		}
+
+		pool := SolReconciliationPool{PoolPlatform: platforms[i], PoolAddress: t.PoolValue}
+		if err := query.Scan(&pool).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		swapSol += pool.NetSol
+		pools = append(pools, pool)
+	}
+
+	// 3. 项目地址当前持有的 SOL（原生 sol 与 WSOL 合计）
+	var walletSol float64
+	if err := dbconfig.DB.Model(&models.WalletTokenStat{}).
+		Select("COALESCE(SUM(balance_readable), 0)").
+		Where("owner_address IN (?) AND "+solMint+" = ?", projectAddresses, pumpsolana.NativeSolMint).
+		Scan(&walletSol).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	expectedSol := transferSol + swapSol
+	difference := walletSol - expectedSol
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":              project.ID,
+		"fund_transfer_sol":       transferSol,
+		"fund_transfer_by_target": transferByTarget,
+		"swap_net_sol":            swapSol,
+		"expected_sol":            expectedSol,
+		"wallet_sol":              walletSol,
+		"difference":              difference,
+		"tolerance":               tolerance,
+		"discrepancy":             math.Abs(difference) > tolerance,
+		"pools":                   pools,
 	})
 }
 
@@ -931,7 +1470,7 @@ func ListProjectConfigsBySlice(c *gin.Context) {
 		validFields := []string{
 			"id", "name", "pool_platform", "pool_id", "token_id",
 			"snapshot_enabled", "snapshot_count", "is_active", "update_stat_enabled",
-			"is_migrated", "created_at", "updated_at",
+			"is_migrated", "assets_balance", "project_profit", "created_at", "updated_at",
 		}
 		for _, field := range validFields {
 			if of == field {
@@ -966,28 +1505,12 @@ func ListProjectConfigsBySlice(c *gin.Context) {
 		return
 	}
 
-	// Convert to ProjectConfigResp with project_profit calculation
-	var respList []ProjectConfigResp
-	for _, config := range configs {
-		proj := config // Create a new variable to avoid pointer issues
-		if resp := buildProjectConfigResp(&proj); resp != nil {
-			respList = append(respList, *resp)
-		}
-	}
-
-	// Calculate pagination info
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
+	// Convert to ProjectConfigResp, loading related pools/tokens in batch
+	respList := buildProjectConfigResps(configs)
 
 	response := gin.H{
-		"data": respList,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       respList,
+		"pagination": paginationMeta(page, pageSize, total),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -1046,6 +1569,291 @@ func GetLatestActiveProjectConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// ListLatestProjects returns the N most recent projects
+// Query parameters: limit (default: 10, max: 100), platform (pool_platform), is_active (true/false)
+func ListLatestProjects(c *gin.Context) {
+	limit := 10
+	if l := c.Query("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+			return
+		}
+		limit = parsed
+	}
+
+	query := dbconfig.DB.Model(&models.ProjectConfig{})
+	if platform := c.Query("platform"); platform != "" {
+		query = query.Where("pool_platform = ?", platform)
+	}
+	if v := c.Query("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid is_active value"})
+			return
+		}
+		query = query.Where("is_active = ?", isActive)
+	}
+
+	var projects []models.ProjectConfig
+	if err := query.Order("id desc").Limit(limit).Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildProjectConfigResps(projects))
+}
+
+// PoolGraphNode 池子关系图中的节点
+type PoolGraphNode struct {
+	Platform    models.PoolPlatform `json:"platform"`
+	PoolID      uint                `json:"pool_id"` // 0 表示关系中存在但未找到对应的池子配置
+	PoolAddress string              `json:"pool_address"`
+	BaseMint    string              `json:"base_mint"`
+	QuoteMint   string              `json:"quote_mint"`
+	Status      string              `json:"status"`
+	IsProject   bool                `json:"is_project"` // 是否为项目配置中直接引用的池子
+}
+
+// PoolGraphEdge 池子之间的迁移关系（from 迁移到 to）
+type PoolGraphEdge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Relation  string `json:"relation"`
+	Completed bool   `json:"completed"`
+}
+
+// ProjectPoolGraph 项目完整的池子关系图
+type ProjectPoolGraph struct {
+	ProjectID    uint                `json:"project_id"`
+	PoolPlatform models.PoolPlatform `json:"pool_platform"`
+	PoolID       uint                `json:"pool_id"`
+	Nodes        []PoolGraphNode     `json:"nodes"`
+	Edges        []PoolGraphEdge     `json:"edges"`
+}
+
+// GetProjectPoolGraph returns the normalized pool relation graph for a project
+// (dbc pool <-> cpmm pool, launchpad pool <-> cpmm pool)
+func GetProjectPoolGraph(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	graph, err := buildProjectPoolGraph(dbconfig.DB, &project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+// buildProjectPoolGraph 根据项目引用的池子构建关系图
+func buildProjectPoolGraph(db *gorm.DB, project *models.ProjectConfig) (*ProjectPoolGraph, error) {
+	graph := &ProjectPoolGraph{
+		ProjectID:    project.ID,
+		PoolPlatform: project.PoolPlatform,
+		PoolID:       project.PoolID,
+		Nodes:        []PoolGraphNode{},
+		Edges:        []PoolGraphEdge{},
+	}
+
+	// 查询可选记录：未找到返回 false，其他错误向上返回
+	lookup := func(dest interface{}, query string, args ...interface{}) (bool, error) {
+		err := db.Where(query, args...).First(dest).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	launchpadNode := func(p models.RaydiumLaunchpadPoolConfig) PoolGraphNode {
+		return PoolGraphNode{Platform: models.PoolPlatformRaydiumLaunchpad, PoolID: p.ID, PoolAddress: p.PoolAddress, BaseMint: p.BaseMint, QuoteMint: p.QuoteMint, Status: p.Status}
+	}
+	raydiumCpmmNode := func(p models.RaydiumCpmmPoolConfig) PoolGraphNode {
+		return PoolGraphNode{Platform: models.PoolPlatformRaydiumCpmm, PoolID: p.ID, PoolAddress: p.PoolAddress, BaseMint: p.BaseMint, QuoteMint: p.QuoteMint, Status: p.Status}
+	}
+	dbcNode := func(p models.MeteoradbcConfig) PoolGraphNode {
+		return PoolGraphNode{Platform: models.PoolPlatformMeteoraDbc, PoolID: p.ID, PoolAddress: p.PoolAddress, BaseMint: p.BaseMint, QuoteMint: p.QuoteMint, Status: p.Status}
+	}
+	meteoraCpmmNode := func(p models.MeteoracpmmConfig) PoolGraphNode {
+		return PoolGraphNode{Platform: models.PoolPlatformMeteoraCpmm, PoolID: p.ID, PoolAddress: p.PoolAddress, BaseMint: p.BaseMint, QuoteMint: p.QuoteMint, Status: p.Status}
+	}
+
+	switch project.PoolPlatform {
+	case models.PoolPlatformRaydiumLaunchpad, models.PoolPlatformRaydiumCpmm:
+		var relation models.RaydiumPoolRelation
+		var hasRelation bool
+		if project.PoolPlatform == models.PoolPlatformRaydiumLaunchpad {
+			var launchpad models.RaydiumLaunchpadPoolConfig
+			found, err := lookup(&launchpad, "id = ?", project.PoolID)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return graph, nil
+			}
+			node := launchpadNode(launchpad)
+			node.IsProject = true
+			graph.Nodes = append(graph.Nodes, node)
+
+			if hasRelation, err = lookup(&relation, "launchpad_pool_id = ?", launchpad.PoolAddress); err != nil {
+				return nil, err
+			}
+			if hasRelation && relation.CpmmPoolID != "" {
+				var cpmm models.RaydiumCpmmPoolConfig
+				found, err := lookup(&cpmm, "pool_address = ?", relation.CpmmPoolID)
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					graph.Nodes = append(graph.Nodes, raydiumCpmmNode(cpmm))
+				} else {
+					graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformRaydiumCpmm, PoolAddress: relation.CpmmPoolID})
+				}
+			}
+		} else {
+			var cpmm models.RaydiumCpmmPoolConfig
+			found, err := lookup(&cpmm, "id = ?", project.PoolID)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return graph, nil
+			}
+
+			if hasRelation, err = lookup(&relation, "cpmm_pool_id = ?", cpmm.PoolAddress); err != nil {
+				return nil, err
+			}
+			if hasRelation && relation.LaunchpadPoolID != "" {
+				var launchpad models.RaydiumLaunchpadPoolConfig
+				found, err := lookup(&launchpad, "pool_address = ?", relation.LaunchpadPoolID)
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					graph.Nodes = append(graph.Nodes, launchpadNode(launchpad))
+				} else {
+					graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformRaydiumLaunchpad, PoolAddress: relation.LaunchpadPoolID})
+				}
+			}
+			node := raydiumCpmmNode(cpmm)
+			node.IsProject = true
+			graph.Nodes = append(graph.Nodes, node)
+		}
+		if hasRelation && relation.LaunchpadPoolID != "" && relation.CpmmPoolID != "" {
+			graph.Edges = append(graph.Edges, PoolGraphEdge{
+				From:      relation.LaunchpadPoolID,
+				To:        relation.CpmmPoolID,
+				Relation:  "migrated_to",
+				Completed: relation.Completed,
+			})
+		}
+
+	case models.PoolPlatformMeteoraDbc, models.PoolPlatformMeteoraCpmm:
+		var dbc models.MeteoradbcConfig
+		var cpmm models.MeteoracpmmConfig
+		var hasDbc, hasCpmm bool
+		var err error
+		if project.PoolPlatform == models.PoolPlatformMeteoraDbc {
+			if hasDbc, err = lookup(&dbc, "id = ?", project.PoolID); err != nil {
+				return nil, err
+			}
+			if hasDbc && dbc.DammV2PoolAddress != "" {
+				if hasCpmm, err = lookup(&cpmm, "pool_address = ?", dbc.DammV2PoolAddress); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			if hasCpmm, err = lookup(&cpmm, "id = ?", project.PoolID); err != nil {
+				return nil, err
+			}
+			if hasCpmm && cpmm.DbcPoolAddress != "" {
+				if hasDbc, err = lookup(&dbc, "pool_address = ?", cpmm.DbcPoolAddress); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		dbcAddress := dbc.PoolAddress
+		if !hasDbc && hasCpmm {
+			dbcAddress = cpmm.DbcPoolAddress
+		}
+		cpmmAddress := cpmm.PoolAddress
+		if !hasCpmm && hasDbc {
+			cpmmAddress = dbc.DammV2PoolAddress
+		}
+
+		if hasDbc {
+			node := dbcNode(dbc)
+			node.IsProject = project.PoolPlatform == models.PoolPlatformMeteoraDbc
+			graph.Nodes = append(graph.Nodes, node)
+		} else if dbcAddress != "" {
+			graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformMeteoraDbc, PoolAddress: dbcAddress})
+		}
+		if hasCpmm {
+			node := meteoraCpmmNode(cpmm)
+			node.IsProject = project.PoolPlatform == models.PoolPlatformMeteoraCpmm
+			graph.Nodes = append(graph.Nodes, node)
+		} else if cpmmAddress != "" {
+			graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformMeteoraCpmm, PoolAddress: cpmmAddress})
+		}
+
+		if dbcAddress != "" && cpmmAddress != "" {
+			graph.Edges = append(graph.Edges, PoolGraphEdge{
+				From:      dbcAddress,
+				To:        cpmmAddress,
+				Relation:  "migrated_to",
+				Completed: !hasDbc || dbc.IsMigrated,
+			})
+		}
+
+	case models.PoolPlatformPumpfunInternal:
+		var pool models.PumpfuninternalConfig
+		found, err := lookup(&pool, "id = ?", project.PoolID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformPumpfunInternal, PoolID: pool.ID, PoolAddress: pool.BondingCurvePda, BaseMint: pool.Mint, Status: pool.Status, IsProject: true})
+		}
+
+	case models.PoolPlatformPumpfunAmm:
+		var pool models.PumpfunAmmPoolConfig
+		found, err := lookup(&pool, "id = ?", project.PoolID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformPumpfunAmm, PoolID: pool.ID, PoolAddress: pool.PoolAddress, BaseMint: pool.BaseMint, QuoteMint: pool.QuoteMint, Status: pool.Status, IsProject: true})
+		}
+
+	case models.PoolPlatformRaydium:
+		var pool models.PoolConfig
+		found, err := lookup(&pool, "id = ?", project.PoolID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			graph.Nodes = append(graph.Nodes, PoolGraphNode{Platform: models.PoolPlatformRaydium, PoolID: pool.ID, PoolAddress: pool.PoolAddress, Status: pool.Status, IsProject: true})
+		}
+	}
+
+	return graph, nil
+}
+
 // ProjectExtraAddressRequest 项目额外地址请求结构
 type ProjectExtraAddressRequest struct {
 	ProjectID       uint   `json:"project_id" binding:"required"`
@@ -1058,8 +1866,7 @@ type ProjectExtraAddressRequest struct {
 // ListProjectExtraAddresses 获取所有项目额外地址
 func ListProjectExtraAddresses(c *gin.Context) {
 	var addresses []models.ProjectExtraAddress
-	if err := dbconfig.DB.Find(&addresses).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &addresses) {
 		return
 	}
 	c.JSON(http.StatusOK, addresses)
@@ -1209,6 +2016,54 @@ func DeleteProjectExtraAddress(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Project extra address deleted successfully"})
 }
 
+// BatchToggleProjectExtraAddressRequest 批量切换项目额外地址开关的请求结构
+// ids 与 project_id 至少提供一个；field 可选 "enabled"（默认）或 "private_key_vaild"
+type BatchToggleProjectExtraAddressRequest struct {
+	IDs       []uint `json:"ids"`
+	ProjectID uint   `json:"project_id"`
+	Field     string `json:"field" binding:"omitempty,oneof=enabled private_key_vaild"`
+	Value     *bool  `json:"value" binding:"required"`
+}
+
+// BatchToggleProjectExtraAddress 批量更新项目额外地址的 enabled / private_key_vaild 字段
+func BatchToggleProjectExtraAddress(c *gin.Context) {
+	var request BatchToggleProjectExtraAddressRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(request.IDs) == 0 && request.ProjectID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Either ids or project_id is required"})
+		return
+	}
+
+	field := request.Field
+	if field == "" {
+		field = "enabled"
+	}
+
+	query := dbconfig.DB.Model(&models.ProjectExtraAddress{})
+	if len(request.IDs) > 0 {
+		query = query.Where("id IN ?", request.IDs)
+	}
+	if request.ProjectID != 0 {
+		query = query.Where("project_id = ?", request.ProjectID)
+	}
+
+	result := query.Update(field, *request.Value)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Batch toggle completed",
+		"rows_affected": result.RowsAffected,
+		"field":         field,
+		"value":         *request.Value,
+	})
+}
+
 // ProjecStatusRequest 项目状态请求结构
 type ProjecStatusRequest struct {
 	ProjectID                      uint    `json:"project_id" binding:"required"`
@@ -1249,8 +2104,7 @@ type ProjecStatusUpdateBody struct {
 // ListProjecStatuses 获取所有项目状态记录
 func ListProjecStatuses(c *gin.Context) {
 	var rows []models.ProjecStatus
-	if err := dbconfig.DB.Find(&rows).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &rows) {
 		return
 	}
 	c.JSON(http.StatusOK, rows)
@@ -1312,17 +2166,9 @@ func ListProjecStatusesBySlice(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": rows,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       rows,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -1587,6 +2433,30 @@ func DeleteProjecStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "ProjecStatus deleted successfully"})
 }
 
+// autoCreateError is a failed auto-create flow together with the HTTP status its endpoint responds with
+type autoCreateError struct {
+	status  int
+	message string
+}
+
+func (e *autoCreateError) Error() string {
+	return e.message
+}
+
+func newAutoCreateError(status int, message string) error {
+	return &autoCreateError{status: status, message: message}
+}
+
+// respondAutoCreateError writes err the way the auto-create endpoints always have: {"error": message}
+func respondAutoCreateError(c *gin.Context, err error) {
+	var createErr *autoCreateError
+	if errors.As(err, &createErr) {
+		c.JSON(createErr.status, gin.H{"error": createErr.message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
 // AutoCreatePumpfuninternalProjectRequest represents the request body for auto-creating a pumpfun internal project
 type AutoCreatePumpfuninternalProjectRequest struct {
 	Mint            string   `json:"mint" binding:"required"`
@@ -1606,6 +2476,17 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 		return
 	}
 
+	_, response, err := autoCreatePumpfuninternalProject(middleware.RequestLogger(c), request)
+	if err != nil {
+		respondAutoCreateError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// autoCreatePumpfuninternalProject creates the TokenConfig, PumpfuninternalConfig, ProjectConfig and RoleConfigRelation of a pumpfun internal project.
+// It returns the created project and the response body of the endpoint; every failure is an *autoCreateError.
+func autoCreatePumpfuninternalProject(logger *log.Entry, request AutoCreatePumpfuninternalProjectRequest) (*models.ProjectConfig, gin.H, error) {
 	// Start a database transaction
 	tx := dbconfig.DB.Begin()
 	defer func() {
@@ -1618,8 +2499,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	var tokenMetadata models.TokenMetadata
 	if err := tx.First(&tokenMetadata, request.TokenMetadataID).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{"error": "TokenMetadata not found"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "TokenMetadata not found")
 	}
 
 	// 2. Create or get TokenConfig
@@ -1638,8 +2518,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 		}
 		if err := tx.Create(&tokenConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create TokenConfig"})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create TokenConfig")
 		}
 	} else {
 		// TokenConfig exists, update Creator if it's empty
@@ -1647,8 +2526,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 			tokenConfig.Creator = request.CoinCreator
 			if err := tx.Save(&tokenConfig).Error; err != nil {
 				tx.Rollback()
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update TokenConfig creator"})
-				return
+				return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to update TokenConfig creator")
 			}
 		}
 	}
@@ -1658,8 +2536,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	solanaRPC := os.Getenv("DEFAULT_SOLANA_RPC")
 	if solanaRPC == "" {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Solana RPC endpoint not configured"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Solana RPC endpoint not configured")
 	}
 
 	// Create client
@@ -1669,16 +2546,14 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	mintPubkey, err := solana.PublicKeyFromBase58(request.Mint)
 	if err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mint address"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "Invalid mint address")
 	}
 
 	// Validate CoinCreator address
 	_, err = solana.PublicKeyFromBase58(request.CoinCreator)
 	if err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid coin creator address"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "Invalid coin creator address")
 	}
 
 	// Use default fee recipient (you may want to make this configurable)
@@ -1690,8 +2565,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	feeRecipientPubkey, err := solana.PublicKeyFromBase58(feeRecipient)
 	if err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fee recipient address"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "Invalid fee recipient address")
 	}
 
 	// Use default fee rate if not provided
@@ -1704,13 +2578,12 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	poolStat, err := pumpsolana.GetPumpFunInternalPoolStat(client, mintPubkey, feeRate, feeRecipientPubkey)
 	if err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get on-chain data: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to get on-chain data: "+err.Error())
 	}
 
 	// Create PumpfuninternalConfig with on-chain data
 	pumpfunConfig := models.PumpfuninternalConfig{
-		Platform:               "pumpfun_internal",
+		Platform:               models.PoolPlatformPumpfunInternal.String(),
 		Mint:                   poolStat.Mint,
 		BondingCurvePda:        poolStat.BondingCurvePDA,
 		AssociatedBondingCurve: poolStat.AssociatedBondingCurve,
@@ -1721,8 +2594,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	}
 	if err := tx.Create(&pumpfunConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create PumpfuninternalConfig"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create PumpfuninternalConfig")
 	}
 
 	// 4. Generate project name if not provided
@@ -1739,7 +2611,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	// 5. Create ProjectConfig
 	projectConfig := models.ProjectConfig{
 		Name:              projectName,
-		PoolPlatform:      "pumpfun_internal",
+		PoolPlatform:      models.PoolPlatformPumpfunInternal,
 		PoolID:            pumpfunConfig.ID,
 		TokenID:           tokenConfig.ID,
 		TokenMetadataID:   request.TokenMetadataID,
@@ -1750,8 +2622,7 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	}
 	if err := tx.Create(&projectConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjectConfig"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjectConfig")
 	}
 
 	// 6. Create RoleConfigRelation
@@ -1761,14 +2632,12 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 	}
 	if err := tx.Create(&roleConfigRelation).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create RoleConfigRelation"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create RoleConfigRelation")
 	}
 
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to commit transaction")
 	}
 
 	// Build response
@@ -1816,18 +2685,29 @@ func AutoCreatePumpfuninternalProject(c *gin.Context) {
 		},
 	}
 
-	c.JSON(http.StatusCreated, response)
+	return &projectConfig, response, nil
+}
+
+// findProjectIDsByPool 返回引用指定平台池子的项目 ID，用于删除池子前的依赖检查
+func findProjectIDsByPool(db *gorm.DB, poolPlatform models.PoolPlatform, poolID uint) ([]uint, error) {
+	var projectIDs []uint
+	err := db.Model(&models.ProjectConfig{}).
+		Where("pool_platform = ? AND pool_id = ?", poolPlatform, poolID).
+		Order("id asc").
+		Pluck("id", &projectIDs).Error
+	return projectIDs, err
 }
 
-// UpdatePoolStatus 根据平台与池ID更新池状态，同时处理 meteora_cpmm 对应的 dbc 状态联动
-func UpdatePoolStatus(poolPlatform string, poolID uint, active bool) error {
+// UpdatePoolStatus 根据平台与池ID更新池状态，同时处理 meteora_cpmm 对应的 dbc 状态联动；
+// 每次实际变化都会写入审计记录（见 recordPoolStatusChange）
+func UpdatePoolStatus(poolPlatform models.PoolPlatform, poolID uint, active bool) error {
 	statusVal := "inactive"
 	if active {
 		statusVal = "active"
 	}
 
 	switch poolPlatform {
-	case "meteora_cpmm":
+	case models.PoolPlatformMeteoraCpmm:
 		var cpmm models.MeteoracpmmConfig
 		if err := dbconfig.DB.First(&cpmm, poolID).Error; err != nil {
 			return fmt.Errorf("MeteoracpmmConfig not found: %v", err)
@@ -1835,26 +2715,49 @@ func UpdatePoolStatus(poolPlatform string, poolID uint, active bool) error {
 		if err := dbconfig.DB.Model(&models.MeteoracpmmConfig{}).Where("id = ?", poolID).Update("status", statusVal).Error; err != nil {
 			return fmt.Errorf("failed to update MeteoracpmmConfig status: %v", err)
 		}
+		recordPoolStatusChange(dbconfig.DB, poolPlatform, cpmm.ID, cpmm.PoolAddress, cpmm.Status, statusVal, "UpdatePoolStatus")
 		// 级联更新对应 DBC 池（按 DbcPoolAddress 匹配 MeteoradbcConfig.PoolAddress）
 		if cpmm.DbcPoolAddress != "" {
+			var dbcPools []models.MeteoradbcConfig
+			if err := dbconfig.DB.Where("pool_address = ?", cpmm.DbcPoolAddress).Find(&dbcPools).Error; err != nil {
+				return fmt.Errorf("failed to load MeteoradbcConfig for cascade: %v", err)
+			}
 			if err := dbconfig.DB.Model(&models.MeteoradbcConfig{}).
 				Where("pool_address = ?", cpmm.DbcPoolAddress).
 				Update("status", statusVal).Error; err != nil {
 				return fmt.Errorf("failed to cascade update MeteoradbcConfig status: %v", err)
 			}
+			for _, dbc := range dbcPools {
+				recordPoolStatusChange(dbconfig.DB, models.PoolPlatformMeteoraDbc, dbc.ID, dbc.PoolAddress, dbc.Status, statusVal, "UpdatePoolStatus")
+			}
+		}
+	case models.PoolPlatformMeteoraDbc:
+		var dbc models.MeteoradbcConfig
+		if err := dbconfig.DB.First(&dbc, poolID).Error; err != nil {
+			return fmt.Errorf("MeteoradbcConfig not found: %v", err)
 		}
-	case "meteora_dbc":
 		if err := dbconfig.DB.Model(&models.MeteoradbcConfig{}).Where("id = ?", poolID).Update("status", statusVal).Error; err != nil {
 			return fmt.Errorf("failed to update MeteoradbcConfig status: %v", err)
 		}
-	case "pumpfun_amm":
+		recordPoolStatusChange(dbconfig.DB, poolPlatform, dbc.ID, dbc.PoolAddress, dbc.Status, statusVal, "UpdatePoolStatus")
+	case models.PoolPlatformPumpfunAmm:
+		var amm models.PumpfunAmmPoolConfig
+		if err := dbconfig.DB.First(&amm, poolID).Error; err != nil {
+			return fmt.Errorf("PumpfunAmmPoolConfig not found: %v", err)
+		}
 		if err := dbconfig.DB.Model(&models.PumpfunAmmPoolConfig{}).Where("id = ?", poolID).Update("status", statusVal).Error; err != nil {
 			return fmt.Errorf("failed to update PumpfunAmmPoolConfig status: %v", err)
 		}
-	case "pumpfun_internal":
+		recordPoolStatusChange(dbconfig.DB, poolPlatform, amm.ID, amm.PoolAddress, amm.Status, statusVal, "UpdatePoolStatus")
+	case models.PoolPlatformPumpfunInternal:
+		var pumpfun models.PumpfuninternalConfig
+		if err := dbconfig.DB.First(&pumpfun, poolID).Error; err != nil {
+			return fmt.Errorf("PumpfuninternalConfig not found: %v", err)
+		}
 		if err := dbconfig.DB.Model(&models.PumpfuninternalConfig{}).Where("id = ?", poolID).Update("status", statusVal).Error; err != nil {
 			return fmt.Errorf("failed to update PumpfuninternalConfig status: %v", err)
 		}
+		recordPoolStatusChange(dbconfig.DB, poolPlatform, pumpfun.ID, pumpfun.BondingCurvePda, pumpfun.Status, statusVal, "UpdatePoolStatus")
 	default:
 		return fmt.Errorf("unsupported pool_platform: %s", poolPlatform)
 	}
@@ -1873,10 +2776,10 @@ func CloseAllStrategyStatus(projectConfigId uint) error {
 
 // AutoCreatePumpfunAmmProjectRequest represents the request body for auto-creating a pumpfun amm project
 type AutoCreatePumpfunAmmProjectRequest struct {
-	PoolPlatform        string  `json:"pool_platform" binding:"required"`
-	Mint                string  `json:"mint" binding:"required"`
-	ProjectInitialToken float64 `json:"project_initial_token" binding:"required"`
-	RoleID              uint    `json:"role_id" binding:"required"`
+	PoolPlatform        models.PoolPlatform `json:"pool_platform" binding:"required"`
+	Mint                string              `json:"mint" binding:"required"`
+	ProjectInitialToken float64             `json:"project_initial_token" binding:"required"`
+	RoleID              uint                `json:"role_id" binding:"required"`
 	PoolConfig          struct {
 		PoolAddress           string `json:"pool_address" binding:"required"`
 		PoolBump              uint8  `json:"pool_bump" binding:"required"`
@@ -1901,16 +2804,25 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 		return
 	}
 
-	// Validate pool_platform
-	if request.PoolPlatform != "pumpfun_amm" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "pool_platform must be 'pumpfun_amm'"})
+	_, response, err := autoCreatePumpfunAmmProject(middleware.RequestLogger(c), request)
+	if err != nil {
+		respondAutoCreateError(c, err)
 		return
 	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// autoCreatePumpfunAmmProject creates the TokenConfig, PumpfunAmmPoolConfig, ProjectConfig and RoleConfigRelation of a pumpfun amm project.
+// It returns the created project and the response body of the endpoint; every failure is an *autoCreateError.
+func autoCreatePumpfunAmmProject(logger *log.Entry, request AutoCreatePumpfunAmmProjectRequest) (*models.ProjectConfig, gin.H, error) {
+	// Validate pool_platform
+	if request.PoolPlatform != models.PoolPlatformPumpfunAmm {
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "pool_platform must be 'pumpfun_amm'")
+	}
 
 	// Validate project_initial_token
 	if request.ProjectInitialToken < 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "project_initial_token must be non-negative"})
-		return
+		return nil, nil, newAutoCreateError(http.StatusBadRequest, "project_initial_token must be non-negative")
 	}
 
 	// Start a database transaction
@@ -1936,8 +2848,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 		}
 		if err := tx.Create(&tokenConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create TokenConfig: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create TokenConfig: "+err.Error())
 		}
 	}
 
@@ -1958,8 +2869,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 	}
 	if err := tx.Create(&pumpfunAmmConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create PumpfunAmmPoolConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create PumpfunAmmPoolConfig: "+err.Error())
 	}
 
 	// 3. Generate project name if not provided
@@ -1976,7 +2886,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 	// 4. Create ProjectConfig
 	projectConfig := models.ProjectConfig{
 		Name:              projectName,
-		PoolPlatform:      "pumpfun_amm",
+		PoolPlatform:      models.PoolPlatformPumpfunAmm,
 		PoolID:            pumpfunAmmConfig.ID,
 		TokenID:           tokenConfig.ID,
 		TokenMetadataID:   0,
@@ -1987,8 +2897,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 	}
 	if err := tx.Create(&projectConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjectConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjectConfig: "+err.Error())
 	}
 
 	// 5. Create ProjectFundTransferRecord for initial token amount
@@ -2003,8 +2912,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 		}
 		if err := tx.Create(fundTransferRecord).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjectFundTransferRecord: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjectFundTransferRecord: "+err.Error())
 		}
 	}
 
@@ -2015,14 +2923,12 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 	}
 	if err := tx.Create(&roleConfigRelation).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create RoleConfigRelation: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create RoleConfigRelation: "+err.Error())
 	}
 
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
 	}
 
 	// Build response
@@ -2085,7 +2991,7 @@ func AutoCreatePumpfunAmmProject(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusCreated, response)
+	return &projectConfig, response, nil
 }
 
 // CpmmPoolConfig represents the configuration for a Meteoracpmm pool
@@ -2169,6 +3075,17 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 		return
 	}
 
+	_, response, err := autoCreateMeteoradbcProject(middleware.RequestLogger(c), request)
+	if err != nil {
+		respondAutoCreateError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// autoCreateMeteoradbcProject creates the TokenConfig, Meteora pool configs, ProjectConfig and RoleConfigRelation of a meteora dbc project.
+// It returns the created project and the response body of the endpoint; every failure is an *autoCreateError.
+func autoCreateMeteoradbcProject(logger *log.Entry, request AutoCreateMeteoradbcProjectRequest) (*models.ProjectConfig, gin.H, error) {
 	// Start a database transaction
 	tx := dbconfig.DB.Begin()
 	defer func() {
@@ -2192,8 +3109,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 		}
 		if err := tx.Create(&tokenConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create TokenConfig: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create TokenConfig: "+err.Error())
 		}
 	}
 
@@ -2219,8 +3135,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 	}
 	if err := tx.Create(&meteoradbcConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create MeteoradbcConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create MeteoradbcConfig: "+err.Error())
 	}
 
 	// 2.1. Create MeteoracpmmConfig if CpmmPoolConfig is provided
@@ -2245,8 +3160,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 		}
 		if err := tx.Create(meteoracpmmConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create MeteoracpmmConfig: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create MeteoracpmmConfig: "+err.Error())
 		}
 	}
 
@@ -2264,7 +3178,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 	// 4. Create ProjectConfig
 	projectConfig := models.ProjectConfig{
 		Name:              projectName,
-		PoolPlatform:      "meteora_dbc",
+		PoolPlatform:      models.PoolPlatformMeteoraDbc,
 		PoolID:            meteoradbcConfig.ID,
 		TokenID:           tokenConfig.ID,
 		TokenMetadataID:   request.TokenMetadataID,
@@ -2276,8 +3190,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 	}
 	if err := tx.Create(&projectConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjectConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjectConfig: "+err.Error())
 	}
 
 	// 5. Create RoleConfigRelation
@@ -2287,64 +3200,16 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 	}
 	if err := tx.Create(&roleConfigRelation).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create RoleConfigRelation: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create RoleConfigRelation: "+err.Error())
 	}
 
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
 	}
 
 	// Publish monitoring task to RabbitMQ (async, non-blocking)
-	go func() {
-		if config.RabbitMQ != nil {
-			publisher, err := config.NewPublisher()
-			if err != nil {
-				log.Errorf("Failed to create RabbitMQ publisher: %v", err)
-				return
-			}
-			defer publisher.Close()
-
-			// Prepare monitoring message
-			monitorMsg := meteora.PoolMonitorMessage{
-				Action:               "start_monitoring",
-				MeteoradbcAddress:    meteoradbcConfig.PoolAddress,
-				ProjectID:            projectConfig.ID,
-				BaseTokenMint:        meteoradbcConfig.BaseMint,
-				QuoteTokenMint:       meteoradbcConfig.QuoteMint,
-				MeteoraDbcAuthority:  "FhVo3mqL8PW5pH5U2CN4XE33DokiyZnUwuGpH2hmHLuM",
-				MeteoraCpmmAuthority: "HLnpSz9h2S4hiLQ43rnSD9XkcUThA7B8hQMKmDaiTLcC",
-			}
-
-			// Add Meteoracpmm address if it exists
-			if meteoracpmmConfig != nil {
-				monitorMsg.MeteoracpmmAddress = meteoracpmmConfig.PoolAddress
-				// Use Meteoracpmm token info if available
-				if meteoracpmmConfig.BaseMint != "" {
-					monitorMsg.BaseTokenMint = meteoracpmmConfig.BaseMint
-				}
-				if meteoracpmmConfig.QuoteMint != "" {
-					monitorMsg.QuoteTokenMint = meteoracpmmConfig.QuoteMint
-				}
-			}
-
-			// Publish message
-			if err := publisher.Publish("meteora_pool_monitor", monitorMsg); err != nil {
-				log.Errorf("Failed to publish monitoring message: %v", err)
-			} else {
-				meteoracpmmAddr := ""
-				if meteoracpmmConfig != nil {
-					meteoracpmmAddr = meteoracpmmConfig.PoolAddress
-				}
-				log.Infof("Published monitoring task for project %d: Meteoradbc=%s, Meteoracpmm=%s",
-					projectConfig.ID, meteoradbcConfig.PoolAddress, meteoracpmmAddr)
-			}
-		} else {
-			log.Warn("RabbitMQ not initialized, skipping monitoring task publication")
-		}
-	}()
+	go publishMeteoraMonitoring(logger, projectConfig.ID, &meteoradbcConfig, meteoracpmmConfig)
 
 	// Build response
 	response := gin.H{
@@ -2410,7 +3275,7 @@ func AutoCreateMeteoradbcProject(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusCreated, response)
+	return &projectConfig, response, nil
 }
 
 // AutoCreateMeteoradbcProjectV2 automatically creates a complete project setup for Meteora DBC with strategy configs
@@ -2421,6 +3286,17 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 		return
 	}
 
+	_, response, err := autoCreateMeteoradbcProjectV2(middleware.RequestLogger(c), request)
+	if err != nil {
+		respondAutoCreateError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// autoCreateMeteoradbcProjectV2 is autoCreateMeteoradbcProject plus the ProjecStatus and the requested StrategyConfigs.
+// It returns the created project and the response body of the endpoint; every failure is an *autoCreateError.
+func autoCreateMeteoradbcProjectV2(logger *log.Entry, request AutoCreateMeteoradbcProjectRequestV2) (*models.ProjectConfig, gin.H, error) {
 	// Start a database transaction
 	tx := dbconfig.DB.Begin()
 	defer func() {
@@ -2444,8 +3320,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 		}
 		if err := tx.Create(&tokenConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create TokenConfig: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create TokenConfig: "+err.Error())
 		}
 	}
 
@@ -2471,8 +3346,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 	}
 	if err := tx.Create(&meteoradbcConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create MeteoradbcConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create MeteoradbcConfig: "+err.Error())
 	}
 
 	// 2.1. Create MeteoracpmmConfig if CpmmPoolConfig is provided
@@ -2497,8 +3371,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 		}
 		if err := tx.Create(meteoracpmmConfig).Error; err != nil {
 			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create MeteoracpmmConfig: " + err.Error()})
-			return
+			return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create MeteoracpmmConfig: "+err.Error())
 		}
 	}
 
@@ -2516,7 +3389,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 	// 4. Create ProjectConfig
 	projectConfig := models.ProjectConfig{
 		Name:              projectName,
-		PoolPlatform:      "meteora_dbc",
+		PoolPlatform:      models.PoolPlatformMeteoraDbc,
 		PoolID:            meteoradbcConfig.ID,
 		TokenID:           tokenConfig.ID,
 		TokenMetadataID:   request.TokenMetadataID,
@@ -2528,8 +3401,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 	}
 	if err := tx.Create(&projectConfig).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjectConfig: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjectConfig: "+err.Error())
 	}
 
 	// 4.1 Create ProjecStatus with default values
@@ -2553,8 +3425,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 	}
 	if err := tx.Create(&projecStatus).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ProjecStatus: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create ProjecStatus: "+err.Error())
 	}
 
 	// 5. Create RoleConfigRelation
@@ -2564,8 +3435,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 	}
 	if err := tx.Create(&roleConfigRelation).Error; err != nil {
 		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create RoleConfigRelation: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create RoleConfigRelation: "+err.Error())
 	}
 
 	// 6. Create StrategyConfigs if provided
@@ -2589,8 +3459,7 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 
 			if err := tx.Create(&strategy).Error; err != nil {
 				tx.Rollback()
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create StrategyConfig: " + err.Error()})
-				return
+				return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to create StrategyConfig: "+err.Error())
 			}
 			createdStrategies = append(createdStrategies, strategy)
 		}
@@ -2598,58 +3467,11 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
-		return
+		return nil, nil, newAutoCreateError(http.StatusInternalServerError, "Failed to commit transaction: "+err.Error())
 	}
 
 	// Publish monitoring task to RabbitMQ (async, non-blocking)
-	go func() {
-		if config.RabbitMQ != nil {
-			publisher, err := config.NewPublisher()
-			if err != nil {
-				log.Errorf("Failed to create RabbitMQ publisher: %v", err)
-				return
-			}
-			defer publisher.Close()
-
-			// Prepare monitoring message
-			monitorMsg := meteora.PoolMonitorMessage{
-				Action:               "start_monitoring",
-				MeteoradbcAddress:    meteoradbcConfig.PoolAddress,
-				ProjectID:            projectConfig.ID,
-				BaseTokenMint:        meteoradbcConfig.BaseMint,
-				QuoteTokenMint:       meteoradbcConfig.QuoteMint,
-				MeteoraDbcAuthority:  "FhVo3mqL8PW5pH5U2CN4XE33DokiyZnUwuGpH2hmHLuM",
-				MeteoraCpmmAuthority: "HLnpSz9h2S4hiLQ43rnSD9XkcUThA7B8hQMKmDaiTLcC",
-			}
-
-			// Add Meteoracpmm address if it exists
-			if meteoracpmmConfig != nil {
-				monitorMsg.MeteoracpmmAddress = meteoracpmmConfig.PoolAddress
-				// Use Meteoracpmm token info if available
-				if meteoracpmmConfig.BaseMint != "" {
-					monitorMsg.BaseTokenMint = meteoracpmmConfig.BaseMint
-				}
-				if meteoracpmmConfig.QuoteMint != "" {
-					monitorMsg.QuoteTokenMint = meteoracpmmConfig.QuoteMint
-				}
-			}
-
-			// Publish message
-			if err := publisher.Publish("meteora_pool_monitor", monitorMsg); err != nil {
-				log.Errorf("Failed to publish monitoring message: %v", err)
-			} else {
-				meteoracpmmAddr := ""
-				if meteoracpmmConfig != nil {
-					meteoracpmmAddr = meteoracpmmConfig.PoolAddress
-				}
-				log.Infof("Published monitoring task for project %d: Meteoradbc=%s, Meteoracpmm=%s",
-					projectConfig.ID, meteoradbcConfig.PoolAddress, meteoracpmmAddr)
-			}
-		} else {
-			log.Warn("RabbitMQ not initialized, skipping monitoring task publication")
-		}
-	}()
+	go publishMeteoraMonitoring(logger, projectConfig.ID, &meteoradbcConfig, meteoracpmmConfig)
 
 	// Build response
 	response := gin.H{
@@ -2736,80 +3558,197 @@ func AutoCreateMeteoradbcProjectV2(c *gin.Context) {
 		response["data"].(gin.H)["strategy_configs"] = strategyConfigsList
 	}
 
-	c.JSON(http.StatusCreated, response)
+	return &projectConfig, response, nil
 }
 
-// RefillTokenMetadataID fills TokenMetadataID for all ProjectConfigs where TokenMetadataID is 0
-func RefillTokenMetadataID(c *gin.Context) {
-	// 1. Find all ProjectConfigs where TokenMetadataID is 0
+// findTokenMetadataForToken returns the TokenMetadata matching a token's name and symbol,
+// or gorm.ErrRecordNotFound when there is none
+func findTokenMetadataForToken(db *gorm.DB, tokenConfig models.TokenConfig) (models.TokenMetadata, error) {
+	var tokenMetadata models.TokenMetadata
+	err := db.Where("name = ? AND symbol = ?", tokenConfig.Name, tokenConfig.Symbol).First(&tokenMetadata).Error
+	return tokenMetadata, err
+}
+
+// MissingTokenMetadataProject describes a project with TokenMetadataID = 0 and what the refill would find for it
+type MissingTokenMetadataProject struct {
+	ProjectID        uint   `json:"project_id"`
+	ProjectName      string `json:"project_name"`
+	TokenID          uint   `json:"token_id"`
+	TokenConfigFound bool   `json:"token_config_found"`
+	TokenName        string `json:"token_name"`
+	TokenSymbol      string `json:"token_symbol"`
+	TokenMint        string `json:"token_mint"`
+	MetadataFound    bool   `json:"metadata_found"`
+	TokenMetadataID  uint   `json:"token_metadata_id,omitempty"` // ID the refill would assign
+	Reason           string `json:"reason,omitempty"`            // why the refill would skip the project
+}
+
+// ListProjectsMissingTokenMetadata lists ProjectConfigs with TokenMetadataID = 0 and whether
+// RefillTokenMetadataID would find a TokenMetadata for them (same name+symbol matching). Read-only.
+func ListProjectsMissingTokenMetadata(c *gin.Context) {
 	var projects []models.ProjectConfig
-	if err := dbconfig.DB.Where("token_metadata_id = ?", 0).Find(&projects).Error; err != nil {
+	if err := dbconfig.DB.Where("token_metadata_id = ?", 0).Order("id asc").Find(&projects).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to query ProjectConfigs: %v", err)})
 		return
 	}
 
-	if len(projects) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "No ProjectConfigs with TokenMetadataID = 0 found",
-			"updated": 0,
-		})
-		return
+	tokenIDs := make([]uint, 0, len(projects))
+	for _, project := range projects {
+		tokenIDs = append(tokenIDs, project.TokenID)
+	}
+	tokens := make(map[uint]models.TokenConfig)
+	if len(tokenIDs) > 0 {
+		var tokenConfigs []models.TokenConfig
+		if err := dbconfig.DB.Where("id IN ?", tokenIDs).Find(&tokenConfigs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to query TokenConfigs: %v", err)})
+			return
+		}
+		for _, tokenConfig := range tokenConfigs {
+			tokens[tokenConfig.ID] = tokenConfig
+		}
 	}
 
-	// Start a transaction
-	tx := dbconfig.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	matchable := 0
+	items := make([]MissingTokenMetadataProject, 0, len(projects))
+	for _, project := range projects {
+		item := MissingTokenMetadataProject{
+			ProjectID:   project.ID,
+			ProjectName: project.Name,
+			TokenID:     project.TokenID,
 		}
-	}()
+		tokenConfig, ok := tokens[project.TokenID]
+		if !ok {
+			item.Reason = fmt.Sprintf("TokenConfig not found for TokenID %d", project.TokenID)
+			items = append(items, item)
+			continue
+		}
+		item.TokenConfigFound = true
+		item.TokenName = tokenConfig.Name
+		item.TokenSymbol = tokenConfig.Symbol
+		item.TokenMint = tokenConfig.Mint
+
+		tokenMetadata, err := findTokenMetadataForToken(dbconfig.DB, tokenConfig)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get TokenMetadata for ProjectConfig ID %d: %v", project.ID, err)})
+				return
+			}
+			item.Reason = fmt.Sprintf("TokenMetadata not found for Name='%s', Symbol='%s'", tokenConfig.Name, tokenConfig.Symbol)
+		} else {
+			item.MetadataFound = true
+			item.TokenMetadataID = tokenMetadata.ID
+			matchable++
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":     len(items),
+		"matchable": matchable,
+		"unmatched": len(items) - matchable,
+		"data":      items,
+	})
+}
+
+// refillTokenMetadataAttempts is how often a project is tried before RefillTokenMetadataID reports it failed
+const refillTokenMetadataAttempts = 3
+
+// TokenMetadataRefillResult is the outcome of RefillTokenMetadataID for one project
+type TokenMetadataRefillResult struct {
+	ProjectID       uint   `json:"project_id"`
+	TokenID         uint   `json:"token_id"`
+	TokenMetadataID uint   `json:"token_metadata_id,omitempty"`
+	Status          string `json:"status"` // updated, not_found or failed
+	Attempts        int    `json:"attempts"`
+	Error           string `json:"error,omitempty"`
+}
+
+// refillProjectTokenMetadataID matches the project's token to a TokenMetadata and stores its ID. The lookups
+// return gorm.ErrRecordNotFound (wrapped) when there is nothing to match.
+func refillProjectTokenMetadataID(db *gorm.DB, project models.ProjectConfig) (uint, error) {
+	var tokenConfig models.TokenConfig
+	if err := db.First(&tokenConfig, project.TokenID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("TokenConfig not found for TokenID %d: %w", project.TokenID, err)
+		}
+		return 0, fmt.Errorf("failed to get TokenConfig: %w", err)
+	}
 
+	tokenMetadata, err := findTokenMetadataForToken(db, tokenConfig)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("TokenMetadata not found for Name='%s', Symbol='%s': %w", tokenConfig.Name, tokenConfig.Symbol, err)
+		}
+		return 0, fmt.Errorf("failed to get TokenMetadata: %w", err)
+	}
+
+	// 仅在仍为 0 时写入，避免覆盖并发请求已填好的值
+	if err := db.Model(&models.ProjectConfig{}).Where("id = ? AND token_metadata_id = ?", project.ID, 0).
+		Update("token_metadata_id", tokenMetadata.ID).Error; err != nil {
+		return 0, fmt.Errorf("failed to update ProjectConfig: %w", err)
+	}
+	return tokenMetadata.ID, nil
+}
+
+// RefillTokenMetadataID fills TokenMetadataID for all ProjectConfigs where TokenMetadataID is 0.
+// Every project is committed on its own, so a failing project does not roll back the ones already matched;
+// unexpected errors are retried up to refillTokenMetadataAttempts times. The outcome of every project is
+// returned in results.
+func RefillTokenMetadataID(c *gin.Context) {
+	// 1. Find all ProjectConfigs where TokenMetadataID is 0
+	var projects []models.ProjectConfig
+	if err := dbconfig.DB.Where("token_metadata_id = ?", 0).Order("id asc").Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to query ProjectConfigs: %v", err)})
+		return
+	}
+
+	if len(projects) == 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "No ProjectConfigs with TokenMetadataID = 0 found",
+			"updated": 0,
+		})
+		return
+	}
+
+	logger := middleware.RequestLogger(c)
 	updatedCount := 0
 	notFoundCount := 0
+	failedCount := 0
 	var errorMessages []string
+	results := make([]TokenMetadataRefillResult, 0, len(projects))
 
 	// 2. Process each project
 	for _, project := range projects {
-		// 2.1. Get TokenConfig by TokenID
-		var tokenConfig models.TokenConfig
-		if err := tx.First(&tokenConfig, project.TokenID).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				notFoundCount++
-				errorMessages = append(errorMessages, fmt.Sprintf("ProjectConfig ID %d: TokenConfig not found for TokenID %d", project.ID, project.TokenID))
-				continue
+		result := TokenMetadataRefillResult{ProjectID: project.ID, TokenID: project.TokenID}
+		var err error
+		for result.Attempts < refillTokenMetadataAttempts {
+			result.Attempts++
+			result.TokenMetadataID, err = refillProjectTokenMetadataID(dbconfig.DB, project)
+			if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+				break
 			}
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get TokenConfig for ProjectConfig ID %d: %v", project.ID, err)})
-			return
-		}
-
-		// 2.2. Find TokenMetadata by Name and Symbol
-		var tokenMetadata models.TokenMetadata
-		if err := tx.Where("name = ? AND symbol = ?", tokenConfig.Name, tokenConfig.Symbol).First(&tokenMetadata).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				notFoundCount++
-				errorMessages = append(errorMessages, fmt.Sprintf("ProjectConfig ID %d: TokenMetadata not found for Name='%s', Symbol='%s'", project.ID, tokenConfig.Name, tokenConfig.Symbol))
-				continue
+			logger.Warnf("RefillTokenMetadataID: attempt %d for ProjectConfig ID %d failed: %v", result.Attempts, project.ID, err)
+			if result.Attempts < refillTokenMetadataAttempts {
+				time.Sleep(time.Duration(result.Attempts) * 200 * time.Millisecond)
 			}
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to get TokenMetadata for ProjectConfig ID %d: %v", project.ID, err)})
-			return
 		}
 
-		// 2.3. Update ProjectConfig with TokenMetadata.ID
-		if err := tx.Model(&models.ProjectConfig{}).Where("id = ?", project.ID).Update("token_metadata_id", tokenMetadata.ID).Error; err != nil {
-			tx.Rollback()
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to update ProjectConfig ID %d: %v", project.ID, err)})
-			return
+		switch {
+		case err == nil:
+			result.Status = "updated"
+			updatedCount++
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			result.Status = "not_found"
+			notFoundCount++
+		default:
+			result.Status = "failed"
+			failedCount++
 		}
-
-		updatedCount++
-	}
-
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to commit transaction: %v", err)})
-		return
+		if err != nil {
+			result.Error = err.Error()
+			errorMessages = append(errorMessages, fmt.Sprintf("ProjectConfig ID %d: %v", project.ID, err))
+		}
+		results = append(results, result)
 	}
 
 	// Build response
@@ -2818,6 +3757,8 @@ func RefillTokenMetadataID(c *gin.Context) {
 		"total_found": len(projects),
 		"updated":     updatedCount,
 		"not_found":   notFoundCount,
+		"failed":      failedCount,
+		"results":     results,
 	}
 
 	// Include errors if any
@@ -2834,7 +3775,7 @@ type UpdateAssetsBalanceRequest struct {
 	AssetsBalance float64 `json:"assets_balance" binding:"required"`
 }
 
-// UpdateAssetsBalance updates the assets balance for a project
+// UpdateAssetsBalance updates the assets balance for a project and recomputes its profit and IsLocked
 func UpdateAssetsBalance(c *gin.Context) {
 	var request UpdateAssetsBalanceRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -2842,17 +3783,13 @@ func UpdateAssetsBalance(c *gin.Context) {
 		return
 	}
 
-	// Verify project exists
-	var project models.ProjectConfig
-	if err := dbconfig.DB.First(&project, request.ProjectID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
-		return
-	}
-
-	// Update assets balance
-	project.AssetsBalance = request.AssetsBalance
-	if err := dbconfig.DB.Save(&project).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	project, err := updateAssetsBalance(dbconfig.DB, request.ProjectID, request.AssetsBalance)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -2862,27 +3799,35 @@ func UpdateAssetsBalance(c *gin.Context) {
 		return
 	}
 
-	// Build response
-	resp := buildProjectConfigResp(&project)
+	c.JSON(http.StatusOK, buildProjectConfigResp(&project))
+}
 
-	// Sync IsLocked with ProjectProfit: lock if < -0.5, unlock if >= -0.5
-	if resp != nil {
-		if resp.ProjectProfit < -0.5 {
-			project.IsLocked = true
-		} else {
-			project.IsLocked = false
+// updateAssetsBalance stores assets_balance of project projectID. The update, the profit recomputation and the
+// IsLocked sync run in one transaction with the project row locked, so concurrent updates can't lose writes.
+func updateAssetsBalance(db *gorm.DB, projectID uint, assetsBalance float64) (models.ProjectConfig, error) {
+	var project models.ProjectConfig
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, projectID).Error; err != nil {
+			return err
 		}
-		if err := dbconfig.DB.Save(&project).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update IsLocked: " + err.Error()})
-			return
+
+		// Update assets balance
+		if err := tx.Model(&project).UpdateColumn("assets_balance", assetsBalance).Error; err != nil {
+			return err
 		}
-		// Reload project and rebuild response
-		if err := dbconfig.DB.Preload("Token").First(&project, project.ID).Error; err == nil {
-			resp = buildProjectConfigResp(&project)
+
+		// 同步保存 project_profit
+		if err := syncProjectProfit(tx, project.ID); err != nil {
+			return fmt.Errorf("failed to update project profit: %w", err)
+		}
+		if err := tx.First(&project, project.ID).Error; err != nil {
+			return err
 		}
-	}
 
-	c.JSON(http.StatusOK, resp)
+		// Sync IsLocked with ProjectProfit: lock if < -0.5, unlock if >= -0.5
+		return tx.Model(&project).UpdateColumn("is_locked", project.ProjectProfit < -0.5).Error
+	})
+	return project, err
 }
 
 // UpdateVestingRequest represents the request body for updating vesting
@@ -2959,3 +3904,796 @@ func ToggleProjectConfigLocker(c *gin.Context) {
 	resp := buildProjectConfigResp(&project)
 	c.JSON(http.StatusOK, resp)
 }
+
+// ToggleProjectUpdateStat toggles the UpdateStatEnabled field for a project config. Projects with it
+// disabled are skipped by the stat recompute paths (profit recompute, snapshots, retail SOL, settle records).
+func ToggleProjectUpdateStat(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// Toggle UpdateStatEnabled
+	project.UpdateStatEnabled = !project.UpdateStatEnabled
+	if err := dbconfig.DB.Save(&project).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reload project with associations
+	if err := dbconfig.DB.Preload("Token").First(&project, project.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load project associations"})
+		return
+	}
+
+	// Build response
+	resp := buildProjectConfigResp(&project)
+	c.JSON(http.StatusOK, resp)
+}
+
+// TeardownProjectRequest represents the request body for tearing down a completed project
+type TeardownProjectRequest struct {
+	PurgeSwaps    bool `json:"purge_swaps"`
+	RetentionDays int  `json:"retention_days"` // swaps older than this are purged, at least minPurgeRetentionDays
+	BatchSize     int  `json:"batch_size"`
+}
+
+// TeardownProject detaches monitoring and archives a completed project in one call:
+// the project is deactivated and its strategies closed in a single transaction, then the
+// pool monitor is told to stop and the per-address queues are deleted. Swap rows older
+// than the retention window can optionally be purged. Each step is reported separately
+// so a partial failure can be retried.
+func TeardownProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var request TeardownProjectRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if request.PurgeSwaps && request.RetentionDays < minPurgeRetentionDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "retention_days is too small",
+			"min_age_days": minPurgeRetentionDays,
+		})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 1. 停用项目并关闭策略，放在同一个事务中
+	var strategiesClosed int64
+	err = dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ProjectConfig{}).Where("id = ?", project.ID).Updates(map[string]interface{}{
+			"is_active":           false,
+			"update_stat_enabled": false,
+		}).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.StrategyConfig{}).Where("project_id = ? AND enabled = ?", project.ID, true).Update("enabled", false)
+		if result.Error != nil {
+			return result.Error
+		}
+		strategiesClosed = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary := gin.H{
+		"project_id": project.ID,
+		"archive": gin.H{
+			"status":            "ok",
+			"strategies_closed": strategiesClosed,
+		},
+	}
+	failed := false
+
+	// 2. 停止监控并删除每个地址对应的队列（仅 Meteora 池子有实时监控）
+	var poolTables []poolDataTables
+	var dbcCfg *models.MeteoradbcConfig
+	var cpmmCfg *models.MeteoracpmmConfig
+	isMeteora := project.PoolPlatform == models.PoolPlatformMeteoraDbc || project.PoolPlatform == models.PoolPlatformMeteoraCpmm
+	if isMeteora {
+		dbcCfg, cpmmCfg, err = loadMeteoraMonitorPools(project)
+	}
+	switch {
+	case !isMeteora:
+		summary["monitoring"] = gin.H{"status": "skipped", "reason": "platform has no pool monitor"}
+	case err != nil:
+		failed = true
+		summary["monitoring"] = gin.H{"status": "failed", "error": err.Error()}
+	default:
+		cpmmAddress := ""
+		if cpmmCfg != nil {
+			cpmmAddress = cpmmCfg.PoolAddress
+		}
+		monitoring := gin.H{
+			"meteoradbc_address":  dbcCfg.PoolAddress,
+			"meteoracpmm_address": cpmmAddress,
+		}
+		if err := publishMeteoraStopMonitoring(dbcCfg.PoolAddress, cpmmAddress); err != nil {
+			failed = true
+			monitoring["status"] = "failed"
+			monitoring["error"] = err.Error()
+		} else {
+			monitoring["status"] = "ok"
+		}
+
+		queues := gin.H{}
+		for _, address := range []string{dbcCfg.PoolAddress, cpmmAddress} {
+			if address == "" {
+				continue
+			}
+			if err := dbconfig.DeleteQueue(meteora.AddressQueueName(address)); err != nil {
+				// 队列可能从未创建过，只记录不视为失败
+				queues[address] = err.Error()
+			} else {
+				queues[address] = "deleted"
+			}
+		}
+		monitoring["queues"] = queues
+		summary["monitoring"] = monitoring
+
+		if dbcCfg.PoolAddress != "" {
+			poolTables = append(poolTables, poolDataTables{SwapModel: &models.MeteoradbcSwap{}, PoolColumn: "pool_address", PoolValue: dbcCfg.PoolAddress})
+		}
+		if cpmmAddress != "" {
+			poolTables = append(poolTables, poolDataTables{SwapModel: &models.MeteoracpmmSwap{}, PoolColumn: "pool_address", PoolValue: cpmmAddress})
+		}
+	}
+
+	// 3. 可选：清理超过保留期的 swap 数据
+	if !request.PurgeSwaps {
+		summary["purge"] = gin.H{"status": "skipped"}
+	} else {
+		if !isMeteora {
+			if resp := buildProjectConfigResp(&project); resp != nil {
+				if tables, ok := resolvePoolDataTables(resp.Pool); ok && tables.PoolValue != "" {
+					poolTables = append(poolTables, tables)
+				}
+			}
+		}
+
+		batchSize := request.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultPurgeBatchSize
+		}
+		if batchSize > maxPurgeBatchSize {
+			batchSize = maxPurgeBatchSize
+		}
+		cutoff := uint(handlerClock.Now().AddDate(0, 0, -request.RetentionDays).Unix())
+
+		purge := gin.H{"before_timestamp": cutoff, "status": "ok"}
+		deleted := gin.H{}
+		for _, tables := range poolTables {
+			count, err := purgePoolSwaps(tables, cutoff, batchSize)
+			deleted[tables.PoolValue] = count
+			if err != nil {
+				failed = true
+				purge["status"] = "failed"
+				purge["error"] = err.Error()
+				break
+			}
+		}
+		purge["swaps_deleted"] = deleted
+		summary["purge"] = purge
+	}
+
+	log.Infof("Tore down project %d (platform=%s, failed=%v)", project.ID, project.PoolPlatform, failed)
+
+	status := http.StatusOK
+	if failed {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, summary)
+}
+
+// TraderPoolPosition is a trader's holder record in one pool of a project
+type TraderPoolPosition struct {
+	PoolPlatform   models.PoolPlatform `json:"pool_platform,omitempty"`
+	PoolAddress    string              `json:"pool_address,omitempty"`
+	HolderType     string              `json:"holder_type"`
+	BaseChange     float64             `json:"base_change"`
+	QuoteChange    float64             `json:"quote_change"`
+	SolChange      float64             `json:"sol_change"`
+	TxCount        uint                `json:"tx_count"`
+	StartSlot      uint                `json:"start_slot"`
+	LastSlot       uint                `json:"last_slot"`
+	StartTimestamp uint                `json:"start_timestamp"`
+	LastTimestamp  uint                `json:"last_timestamp"`
+}
+
+// projectPoolTables returns the platform and swap/holder tables of every pool a project trades in:
+// the project's own pool, plus the DBC/CPMM counterpart for Meteora projects that migrated.
+func projectPoolTables(project *models.ProjectConfig) ([]models.PoolPlatform, []poolDataTables, error) {
+	var platforms []models.PoolPlatform
+	var tables []poolDataTables
+	add := func(platform models.PoolPlatform, pool interface{}) {
+		if t, ok := resolvePoolDataTables(pool); ok && t.PoolValue != "" {
+			platforms = append(platforms, platform)
+			tables = append(tables, t)
+		}
+	}
+
+	switch project.PoolPlatform {
+	case models.PoolPlatformMeteoraDbc, models.PoolPlatformMeteoraCpmm:
+		dbcCfg, cpmmCfg, err := loadMeteoraMonitorPools(*project)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(models.PoolPlatformMeteoraDbc, *dbcCfg)
+		if cpmmCfg != nil {
+			add(models.PoolPlatformMeteoraCpmm, *cpmmCfg)
+		}
+	default:
+		if resp := buildProjectConfigResp(project); resp != nil {
+			add(project.PoolPlatform, resp.Pool)
+		}
+	}
+	return platforms, tables, nil
+}
+
+// GetTraderPositionInProject returns an address's holder records across all pools of a project
+// (e.g. both Meteora DBC and CPMM after migration) together with the merged totals.
+// For pumpfun_internal pools base_change is the token change and quote_change the SOL change.
+func GetTraderPositionInProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	address := c.Param("address")
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	platforms, tables, err := projectPoolTables(&project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	positions := []TraderPoolPosition{}
+	merged := TraderPoolPosition{}
+	for i, t := range tables {
+		quoteColumn := "quote_change"
+		if platforms[i] == models.PoolPlatformPumpfunInternal {
+			quoteColumn = "sol_change"
+		}
+
+		var rows []TraderPoolPosition
+		if err := dbconfig.DB.Model(t.HolderModel).
+			Select(fmt.Sprintf("holder_type, %s AS base_change, %s AS quote_change, sol_change, tx_count, "+
+				"start_slot, last_slot, start_timestamp, last_timestamp", t.BalanceColumn, quoteColumn)).
+			Where(t.PoolColumn+" = ? AND address = ?", t.PoolValue, address).
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		for _, row := range rows {
+			row.PoolPlatform = platforms[i]
+			row.PoolAddress = t.PoolValue
+			positions = append(positions, row)
+
+			merged.BaseChange += row.BaseChange
+			merged.QuoteChange += row.QuoteChange
+			merged.SolChange += row.SolChange
+			merged.TxCount += row.TxCount
+			if merged.StartSlot == 0 || (row.StartSlot > 0 && row.StartSlot < merged.StartSlot) {
+				merged.StartSlot = row.StartSlot
+				merged.StartTimestamp = row.StartTimestamp
+			}
+			if row.LastSlot > merged.LastSlot {
+				merged.LastSlot = row.LastSlot
+				merged.LastTimestamp = row.LastTimestamp
+			}
+			if merged.HolderType == "" {
+				merged.HolderType = row.HolderType
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.ID,
+		"address":    address,
+		"found":      len(positions) > 0,
+		"merged":     merged,
+		"positions":  positions,
+	})
+}
+
+// TraderSwap is one swap of a trader in any pool of a project. For pumpfun_internal pools base_change is
+// the token change and quote_change the SOL change.
+type TraderSwap struct {
+	PoolPlatform models.PoolPlatform `json:"pool_platform"`
+	PoolAddress  string              `json:"pool_address"`
+	ID           uint                `json:"id"`
+	Slot         uint                `json:"slot"`
+	Timestamp    uint                `json:"timestamp"`
+	Signature    string              `json:"signature"`
+	BaseChange   float64             `json:"base_change"`
+	QuoteChange  float64             `json:"quote_change"`
+}
+
+// GetTraderSwapsInProject returns an address's swaps across all pools of a project (including the
+// migrated CPMM pool), merged newest first by slot in one UNION ALL query.
+// Query parameters: page (default: 1), page_size (default: 50, max: 500)
+func GetTraderSwapsInProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	address := c.Param("address")
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 50
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 500 {
+			pageSize = parsed
+		}
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	platforms, tables, err := projectPoolTables(&project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	swaps := []TraderSwap{}
+	var total int64
+	if len(tables) > 0 {
+		parts := make([]string, len(tables))
+		args := make([]interface{}, len(tables))
+		for i, t := range tables {
+			base, quote := traderChangeColumns(platforms[i])
+			parts[i] = "(?)"
+			args[i] = dbconfig.DB.Model(t.SwapModel).
+				Select(fmt.Sprintf("? AS pool_platform, %s AS pool_address, id, slot, timestamp, signature, "+
+					"%s AS base_change, %s AS quote_change", t.PoolColumn, base, quote), platforms[i]).
+				Where(t.PoolColumn+" = ? AND address = ?", t.PoolValue, address)
+		}
+		union := dbconfig.DB.Table("("+strings.Join(parts, " UNION ALL ")+") AS s", args...)
+
+		if err := union.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err := union.Order("slot DESC, id DESC").
+			Offset((page - 1) * pageSize).
+			Limit(pageSize).
+			Scan(&swaps).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": project.ID,
+		"address":    address,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       swaps,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// holderCSVColumns is the header of ExportProjectHoldersCSV
+var holderCSVColumns = []string{"address", "holder_type", "base_change", "quote_change", "sol_change", "tx_count", "mint_proportion"}
+
+// projectHolderCSVRow is one merged holder of ExportProjectHoldersCSV
+type projectHolderCSVRow struct {
+	Address     string
+	HolderType  string
+	BaseChange  float64
+	QuoteChange float64
+	SolChange   float64
+	TxCount     uint
+}
+
+// ExportProjectHoldersCSV streams the holders of every pool of a project (including the migrated CPMM pool)
+// as CSV, merged per address and ordered by base_change desc. mint_proportion is base_change over the
+// token's TotalSupply and is left empty when the supply is unknown.
+// For pumpfun_internal pools base_change is the token change and quote_change the SOL change.
+// Query parameters: holder_type (optional), min_proportion (optional, requires a known TotalSupply)
+func ExportProjectHoldersCSV(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	var totalSupply float64
+	var token models.TokenConfig
+	if err := dbconfig.DB.First(&token, project.TokenID).Error; err == nil {
+		totalSupply = token.TotalSupply
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var minProportion *float64
+	if v := c.Query("min_proportion"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_proportion"})
+			return
+		}
+		if totalSupply <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_proportion requires the token's total_supply to be set"})
+			return
+		}
+		minProportion = &parsed
+	}
+	holderType := c.Query("holder_type")
+
+	platforms, tables, err := projectPoolTables(&project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(tables) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Project has no pool configured"})
+		return
+	}
+
+	parts := make([]string, len(tables))
+	args := make([]interface{}, len(tables))
+	for i, t := range tables {
+		quoteColumn := "quote_change"
+		if platforms[i] == models.PoolPlatformPumpfunInternal {
+			quoteColumn = "sol_change"
+		}
+		sub := dbconfig.DB.Model(t.HolderModel).
+			Select(fmt.Sprintf("address, holder_type, %s AS base_change, %s AS quote_change, sol_change, tx_count",
+				t.BalanceColumn, quoteColumn)).
+			Where(t.PoolColumn+" = ?", t.PoolValue)
+		if holderType != "" {
+			sub = sub.Where("holder_type = ?", holderType)
+		}
+		parts[i] = "(?)"
+		args[i] = sub
+	}
+	query := dbconfig.DB.Table("("+strings.Join(parts, " UNION ALL ")+") AS h", args...).
+		Select("address, MAX(holder_type) AS holder_type, SUM(base_change) AS base_change, " +
+			"SUM(quote_change) AS quote_change, SUM(sol_change) AS sol_change, SUM(tx_count) AS tx_count").
+		Group("address")
+	if minProportion != nil {
+		query = query.Having("SUM(base_change) >= ?", *minProportion*totalSupply)
+	}
+
+	rows, err := query.Order("base_change DESC, address ASC").Rows()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	extendWriteDeadline(c)
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=project_%d_holders_%s.csv", project.ID, time.Now().Format("20060102_150405")))
+	c.Status(http.StatusOK)
+
+	logger := middleware.RequestLogger(c)
+	w := csv.NewWriter(c.Writer)
+	if err := w.Write(holderCSVColumns); err != nil {
+		logger.Errorf("ExportProjectHoldersCSV: failed to write header: %v", err)
+		return
+	}
+
+	var count int
+	for rows.Next() {
+		var row projectHolderCSVRow
+		if err := dbconfig.DB.ScanRows(rows, &row); err != nil {
+			logger.Errorf("ExportProjectHoldersCSV: failed to scan row after %d holders: %v", count, err)
+			break
+		}
+		proportion := ""
+		if totalSupply > 0 {
+			proportion = strconv.FormatFloat(row.BaseChange/totalSupply, 'f', -1, 64)
+		}
+		if err := w.Write([]string{
+			row.Address,
+			row.HolderType,
+			strconv.FormatFloat(row.BaseChange, 'f', -1, 64),
+			strconv.FormatFloat(row.QuoteChange, 'f', -1, 64),
+			strconv.FormatFloat(row.SolChange, 'f', -1, 64),
+			strconv.FormatUint(uint64(row.TxCount), 10),
+			proportion,
+		}); err != nil {
+			logger.Errorf("ExportProjectHoldersCSV: failed to write row after %d holders: %v", count, err)
+			break
+		}
+		count++
+		// 定期刷新，避免整个文件堆积在缓冲区
+		if count%1000 == 0 {
+			w.Flush()
+		}
+	}
+	w.Flush()
+
+	if err := rows.Err(); err != nil {
+		logger.Errorf("ExportProjectHoldersCSV: row iteration stopped after %d holders: %v", count, err)
+	}
+}
+
+// purgePoolSwaps deletes swaps of one pool older than cutoff in batches and returns the number of rows removed
+func purgePoolSwaps(tables poolDataTables, cutoff uint, batchSize int) (int64, error) {
+	var deleted int64
+	for {
+		subQuery := dbconfig.DB.Model(tables.SwapModel).
+			Select("id").
+			Where(tables.PoolColumn+" = ? AND timestamp < ?", tables.PoolValue, cutoff).
+			Limit(batchSize)
+		result := dbconfig.DB.Where("id IN (?)", subQuery).Delete(tables.SwapModel)
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+		deleted += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// applyProjectProfit 计算并保存单个项目的 project_profit
+// 基线为同一项目最近的一次快照（ProjectSnapshot），没有快照时 profit 为 0；
+// 不再依赖其他项目，删除或归档项目不会影响别的项目的 profit
+func applyProjectProfit(db *gorm.DB, project *models.ProjectConfig) error {
+	profit := 0.0
+	baselineID := uint(0)
+
+	var baseline models.ProjectSnapshot
+	err := db.Where("project_id = ?", project.ID).Order("id desc").First(&baseline).Error
+	if err == nil {
+		profit = project.AssetsBalance - baseline.AssetsBalance
+		baselineID = baseline.ID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	project.ProjectProfit = profit
+	project.ProfitBaselineID = baselineID
+	return db.Model(&models.ProjectConfig{}).Where("id = ?", project.ID).UpdateColumns(map[string]interface{}{
+		"project_profit":     profit,
+		"profit_baseline_id": baselineID,
+	}).Error
+}
+
+// syncProjectProfit 重新加载项目并重新计算其 project_profit
+func syncProjectProfit(db *gorm.DB, projectID uint) error {
+	var project models.ProjectConfig
+	if err := db.First(&project, projectID).Error; err != nil {
+		return err
+	}
+	return applyProjectProfit(db, &project)
+}
+
+// RecomputeProjectProfitRequest represents the request body for recomputing project profit
+type RecomputeProjectProfitRequest struct {
+	ProjectIDs []uint `json:"project_ids"` // 为空时重新计算全部项目
+}
+
+// RecomputeProjectProfit recomputes and stores project_profit for the given projects (or all projects).
+// Projects with UpdateStatEnabled=false are skipped and listed in skipped_ids.
+func RecomputeProjectProfit(c *gin.Context) {
+	var request RecomputeProjectProfitRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	query := dbconfig.DB.Model(&models.ProjectConfig{})
+	if len(request.ProjectIDs) > 0 {
+		query = query.Where("id IN ?", request.ProjectIDs)
+	}
+
+	var projects []models.ProjectConfig
+	if err := query.Order("id asc").Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedCount := 0
+	skipped := []uint{}
+	var errorMessages []string
+	for i := range projects {
+		if !projects[i].UpdateStatEnabled {
+			skipped = append(skipped, projects[i].ID)
+			continue
+		}
+		if err := applyProjectProfit(dbconfig.DB, &projects[i]); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("project %d: %v", projects[i].ID, err))
+			continue
+		}
+		updatedCount++
+	}
+
+	response := gin.H{
+		"message":       "Project profit recomputed",
+		"total_count":   len(projects),
+		"updated_count": updatedCount,
+		"skipped_count": len(skipped),
+		"skipped_ids":   skipped,
+		"failed_count":  len(errorMessages),
+	}
+	if len(errorMessages) > 0 {
+		response["error_details"] = errorMessages
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// projectRetailSolAmount sums the SOL that successful swaps of mint moved into the pool (SUM(-quote_change)),
+// counting only swaps quoted in SOL (WSOL or the "sol" sentinel)
+func projectRetailSolAmount(db *gorm.DB, mint string) (float64, error) {
+	var amount float64
+	err := db.Model(&models.SwapTransaction{}).
+		Select("COALESCE(SUM(-quote_change), 0)").
+		Where("base_mint = ? AND is_success = ?", mint, true).
+		Where(pumpsolana.CanonicalMintSQL("quote_mint")+" = ?", pumpsolana.NativeSolMint).
+		Scan(&amount).Error
+	return amount, err
+}
+
+// RetailSolAmountResult is the outcome of RecalculateAllRetailSolAmounts for one project
+type RetailSolAmountResult struct {
+	ProjectID       uint    `json:"project_id"`
+	Name            string  `json:"name"`
+	Previous        float64 `json:"previous"`
+	RetailSolAmount float64 `json:"retail_sol_amount"`
+	Updated         bool    `json:"updated"`
+	Skipped         string  `json:"skipped,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// RecalculateAllRetailSolAmountsRequest represents the request body for RecalculateAllRetailSolAmounts
+type RecalculateAllRetailSolAmountsRequest struct {
+	ProjectIDs []uint `json:"project_ids"` // 为空时处理全部活跃项目
+}
+
+// RecalculateAllRetailSolAmounts recomputes and stores retail_sol_amount for every active project (or the given
+// ones) from swap_transaction, the same figure GetSwapTransactionsByProject stores as a side effect of a read.
+// Projects with UpdateStatEnabled=false or without a token are skipped.
+func RecalculateAllRetailSolAmounts(c *gin.Context) {
+	var request RecalculateAllRetailSolAmountsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	query := dbconfig.DB.Model(&models.ProjectConfig{}).Where("is_active = ?", true)
+	if len(request.ProjectIDs) > 0 {
+		query = query.Where("id IN ?", request.ProjectIDs)
+	}
+	var projects []models.ProjectConfig
+	if err := query.Order("id asc").Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenIDs := make([]uint, 0, len(projects))
+	for _, project := range projects {
+		tokenIDs = append(tokenIDs, project.TokenID)
+	}
+	var tokens []models.TokenConfig
+	if len(tokenIDs) > 0 {
+		if err := dbconfig.DB.Where("id IN ?", tokenIDs).Find(&tokens).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	mintByToken := make(map[uint]string, len(tokens))
+	for _, token := range tokens {
+		mintByToken[token.ID] = token.Mint
+	}
+
+	results := make([]RetailSolAmountResult, 0, len(projects))
+	var updatedCount, skippedCount, failedCount int
+	for _, project := range projects {
+		result := RetailSolAmountResult{ProjectID: project.ID, Name: project.Name, Previous: project.RetailSolAmount}
+		mint, ok := mintByToken[project.TokenID]
+		switch {
+		case !project.UpdateStatEnabled:
+			result.Skipped = "update_stat_disabled"
+		case !ok || mint == "":
+			result.Skipped = "token_not_found"
+		}
+		if result.Skipped != "" {
+			result.RetailSolAmount = project.RetailSolAmount
+			skippedCount++
+			results = append(results, result)
+			continue
+		}
+
+		amount, err := projectRetailSolAmount(dbconfig.DB, mint)
+		if err == nil {
+			err = dbconfig.DB.Model(&models.ProjectConfig{}).Where("id = ?", project.ID).
+				UpdateColumn("retail_sol_amount", amount).Error
+		}
+		if err != nil {
+			result.Error = err.Error()
+			failedCount++
+		} else {
+			result.RetailSolAmount = amount
+			result.Updated = true
+			updatedCount++
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Retail SOL amounts recalculated",
+		"total_count":   len(projects),
+		"updated_count": updatedCount,
+		"skipped_count": skippedCount,
+		"failed_count":  failedCount,
+		"results":       results,
+	})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB returns a gorm DB backed by sqlmock with a single connection, so transactions run one at a time
+// like they would on a locked row
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db, mock
+}
+
+// recordArg matches any argument and records it
+type recordArg struct {
+	mu     *sync.Mutex
+	values *[]float64
+}
+
+func (r recordArg) Match(v driver.Value) bool {
+	if f, ok := v.(float64); ok {
+		r.mu.Lock()
+		*r.values = append(*r.values, f)
+		r.mu.Unlock()
+	}
+	return true
+}
+
+func TestUpdateAssetsBalanceConcurrent(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	const (
+		workers  = 5
+		baseline = 100.0
+	)
+	var mu sync.Mutex
+	written := []float64{}
+	projectColumns := []string{"id", "assets_balance", "project_profit", "profit_baseline_id", "is_locked"}
+
+	// 每个请求都必须先锁行，再在同一事务内更新余额、重算利润和 is_locked
+	for i := 0; i < workers; i++ {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "project_config" WHERE "project_config"."id" = \$1 .*FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(1, 0, 0, 0, false))
+		mock.ExpectExec(`UPDATE "project_config" SET "assets_balance"=\$1`).
+			WithArgs(recordArg{&mu, &written}, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "project_config" WHERE "project_config"."id" = \$1`).
+			WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(1, 99, 0, 0, false))
+		mock.ExpectQuery(`SELECT \* FROM "project_snapshots" WHERE project_id = \$1 ORDER BY id desc`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "assets_balance"}).AddRow(7, 1, baseline))
+		mock.ExpectExec(`UPDATE "project_config" SET "profit_baseline_id"=\$1,"project_profit"=\$2`).
+			WithArgs(7, -1.0, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "project_config" WHERE "project_config"."id" = \$1`).
+			WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(1, 99, -1, 7, false))
+		mock.ExpectExec(`UPDATE "project_config" SET "is_locked"=\$1`).
+			WithArgs(true, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = updateAssetsBalance(db, 1, baseline+float64(i))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet(), "every update must run as one locked transaction")
+	assert.ElementsMatch(t, []float64{100, 101, 102, 103, 104}, written)
+}
+
+func TestUpdateAssetsBalanceNotFound(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "project_config" .*FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err := updateAssetsBalance(db, 42, 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -23,7 +23,7 @@ import (
 type ProjectSettleResp struct {
 	ID                uint                `json:"id"`
 	Name              string              `json:"name"`
-	PoolPlatform      string              `json:"pool_platform"`
+	PoolPlatform      models.PoolPlatform `json:"pool_platform"`
 	PoolID            uint                `json:"pool_id"`
 	TokenID           uint                `json:"token_id"`
 	TokenMetadataID   uint                `json:"token_metadata_id"`
@@ -87,14 +87,8 @@ func GetProjectProfitRanking(c *gin.Context) {
 	for i := range projects {
 		project := &projects[i]
 
-		// Calculate project_profit: current id's assets_balance minus previous id's assets_balance
-		projectProfit := 0.0
-		if project.ID > 1 {
-			var previousProject models.ProjectConfig
-			if err := dbconfig.DB.First(&previousProject, project.ID-1).Error; err == nil {
-				projectProfit = project.AssetsBalance - previousProject.AssetsBalance
-			}
-		}
+		// project_profit is stored on ProjectConfig (see applyProjectProfit)
+		projectProfit := project.ProjectProfit
 
 		// Filter by IGNORE_EXTREMUM_RANGE: only include projects within [Min, Max]
 		if projectProfit < IGNORE_EXTREMUM_RANGE_MIN || projectProfit > IGNORE_EXTREMUM_RANGE_MAX {
@@ -143,10 +137,6 @@ func GetProjectProfitRanking(c *gin.Context) {
 
 	// 4. Apply pagination
 	total := len(results)
-	totalPages := (total + pageSize - 1) / pageSize
-	if totalPages == 0 {
-		totalPages = 1
-	}
 
 	// Calculate offset and limit
 	offset := (page - 1) * pageSize
@@ -163,15 +153,8 @@ func GetProjectProfitRanking(c *gin.Context) {
 
 	// 5. Return paginated response
 	response := gin.H{
-		"data": paginatedResults,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < totalPages,
-			"has_prev":     page > 1,
-		},
+		"data":       paginatedResults,
+		"pagination": paginationMeta(page, pageSize, int64(total)),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -334,16 +317,9 @@ func GetProjectProfitRecord(c *gin.Context) {
 
 	if len(bucketMap) == 0 {
 		c.JSON(http.StatusOK, gin.H{
-			"data": []ProjectProfitRecordItem{},
-			"pagination": gin.H{
-				"current_page": page,
-				"page_size":    pageSize,
-				"total_pages":  1,
-				"total_count":  0,
-				"has_next":     false,
-				"has_prev":     false,
-			},
-			"interval": interval,
+			"data":       []ProjectProfitRecordItem{},
+			"pagination": paginationMeta(page, pageSize, 0),
+			"interval":   interval,
 		})
 		return
 	}
@@ -396,10 +372,6 @@ func GetProjectProfitRecord(c *gin.Context) {
 	})
 
 	total := len(results)
-	totalPages := (total + pageSize - 1) / pageSize
-	if totalPages == 0 {
-		totalPages = 1
-	}
 	offset := (page - 1) * pageSize
 	end := offset + pageSize
 	if end > total {
@@ -411,16 +383,9 @@ func GetProjectProfitRecord(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": paginated,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < totalPages,
-			"has_prev":     page > 1,
-		},
-		"interval": interval,
+		"data":       paginated,
+		"pagination": paginationMeta(page, pageSize, int64(total)),
+		"interval":   interval,
 	})
 }
 
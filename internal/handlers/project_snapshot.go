@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"marketcontrol/internal/models"
 	dbconfig "marketcontrol/pkg/config"
+	mcsolana "marketcontrol/pkg/solana"
 	"marketcontrol/pkg/utils"
 )
 
@@ -19,8 +24,7 @@ type AggregateTokenSnapshot struct {
 // ListWalletTokenSnapshots 获取所有钱包快照
 func ListWalletTokenSnapshots(c *gin.Context) {
 	var snapshots []models.WalletTokenSnapshot
-	if err := dbconfig.DB.Find(&snapshots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &snapshots) {
 		return
 	}
 	c.JSON(http.StatusOK, snapshots)
@@ -45,8 +49,7 @@ func GetWalletTokenSnapshot(c *gin.Context) {
 func ListWalletTokenSnapshotsByProject(c *gin.Context) {
 	projectID := c.Param("project_id")
 	var snapshots []models.WalletTokenSnapshot
-	if err := dbconfig.DB.Where("project_id = ?", projectID).Find(&snapshots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Where("project_id = ?", projectID), &snapshots) {
 		return
 	}
 	c.JSON(http.StatusOK, snapshots)
@@ -56,8 +59,7 @@ func ListWalletTokenSnapshotsByProject(c *gin.Context) {
 func ListWalletTokenSnapshotsBySnapshotID(c *gin.Context) {
 	snapshotID := c.Param("snapshot_id")
 	var snapshots []models.WalletTokenSnapshot
-	if err := dbconfig.DB.Where("snapshot_id = ?", snapshotID).Find(&snapshots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Where("snapshot_id = ?", snapshotID), &snapshots) {
 		return
 	}
 	c.JSON(http.StatusOK, snapshots)
@@ -66,32 +68,32 @@ func ListWalletTokenSnapshotsBySnapshotID(c *gin.Context) {
 // ListPoolSnapshots 获取所有池子快照
 func ListPoolSnapshots(c *gin.Context) {
 	// 获取查询参数
-	platform := c.Query("platform")
+	platform := models.PoolPlatform(c.Query("platform"))
 	if platform == "" {
-		platform = "raydium" // 默认为 raydium
+		platform = models.PoolPlatformRaydium // 默认为 raydium
 	}
 
 	switch platform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var snapshots []models.PoolSnapshot
-		if err := dbconfig.DB.Find(&snapshots).Error; err != nil {
+		if err := dbconfig.DB.Order("id desc").Find(&snapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		var result []*PoolSnapshotResp
+		result := []*PoolSnapshotResp{}
 		for _, snapshot := range snapshots {
 			snap := snapshot // Create a new variable to avoid pointer issues
 			result = append(result, BuildPoolSnapshotResp(&snap))
 		}
 		c.JSON(http.StatusOK, result)
 
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var snapshots []models.PumpfuninternalSnapshot
-		if err := dbconfig.DB.Find(&snapshots).Error; err != nil {
+		if err := dbconfig.DB.Order("id desc").Find(&snapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		var result []*PumpfuninternalSnapshotResp
+		result := []*PumpfuninternalSnapshotResp{}
 		for _, snapshot := range snapshots {
 			snap := snapshot // Create a new variable to avoid pointer issues
 			result = append(result, BuildPumpfuninternalSnapshotResp(&snap))
@@ -112,13 +114,13 @@ func GetPoolSnapshot(c *gin.Context) {
 	}
 
 	// 获取查询参数
-	platform := c.Query("platform")
+	platform := models.PoolPlatform(c.Query("platform"))
 	if platform == "" {
-		platform = "raydium" // 默认为 raydium
+		platform = models.PoolPlatformRaydium // 默认为 raydium
 	}
 
 	switch platform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var snapshot models.PoolSnapshot
 		if err := dbconfig.DB.First(&snapshot, id).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
@@ -126,7 +128,7 @@ func GetPoolSnapshot(c *gin.Context) {
 		}
 		c.JSON(http.StatusOK, BuildPoolSnapshotResp(&snapshot))
 
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var snapshot models.PumpfuninternalSnapshot
 		if err := dbconfig.DB.First(&snapshot, id).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
@@ -160,7 +162,7 @@ func GetPoolSnapshotByProject(c *gin.Context) {
 
 	// 2. 根据不同的池子平台返回对应的快照数据
 	switch project.PoolPlatform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var snapshot models.PoolSnapshot
 		query := dbconfig.DB.Where("project_id = ?", projectID)
 		if req.SnapshotID > 0 {
@@ -172,7 +174,7 @@ func GetPoolSnapshotByProject(c *gin.Context) {
 		}
 		c.JSON(http.StatusOK, BuildPoolSnapshotResp(&snapshot))
 
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var snapshot models.PumpfuninternalSnapshot
 		query := dbconfig.DB.Where("project_id = ?", projectID)
 		if req.SnapshotID > 0 {
@@ -194,32 +196,32 @@ func ListPoolSnapshotsBySnapshotID(c *gin.Context) {
 	snapshotID := c.Param("snapshot_id")
 
 	// 获取查询参数
-	platform := c.Query("platform")
+	platform := models.PoolPlatform(c.Query("platform"))
 	if platform == "" {
-		platform = "raydium" // 默认为 raydium
+		platform = models.PoolPlatformRaydium // 默认为 raydium
 	}
 
 	switch platform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var snapshots []models.PoolSnapshot
 		if err := dbconfig.DB.Where("snapshot_id = ?", snapshotID).Find(&snapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		var result []*PoolSnapshotResp
+		result := []*PoolSnapshotResp{}
 		for _, snapshot := range snapshots {
 			snap := snapshot // Create a new variable to avoid pointer issues
 			result = append(result, BuildPoolSnapshotResp(&snap))
 		}
 		c.JSON(http.StatusOK, result)
 
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var snapshots []models.PumpfuninternalSnapshot
 		if err := dbconfig.DB.Where("snapshot_id = ?", snapshotID).Find(&snapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		var result []*PumpfuninternalSnapshotResp
+		result := []*PumpfuninternalSnapshotResp{}
 		for _, snapshot := range snapshots {
 			snap := snapshot // Create a new variable to avoid pointer issues
 			result = append(result, BuildPumpfuninternalSnapshotResp(&snap))
@@ -281,9 +283,9 @@ func ListWalletTokenSnapshotsByRoleID(c *gin.Context) {
 		Tokens       []WalletTokenSnapshotResp `json:"tokens"`
 	}
 
-	var result []TokenGroup
+	result := []TokenGroup{}
 	for owner, tokens := range resultMap {
-		var tokenList []WalletTokenSnapshotResp
+		tokenList := []WalletTokenSnapshotResp{}
 		for _, t := range tokens {
 			tokenList = append(tokenList, WalletTokenSnapshotResp{
 				Mint:            t.Mint,
@@ -345,7 +347,7 @@ func ListAggregateWalletTokenSnapshotsByRoleID(c *gin.Context) {
 		}
 	}
 
-	var result []AggregateTokenSnapshot
+	result := []AggregateTokenSnapshot{}
 	for _, v := range tokenMap {
 		result = append(result, *v)
 	}
@@ -387,9 +389,9 @@ func GetSettleSnapshotByProject(c *gin.Context) {
 
 	// 根据不同的池子平台调用不同的结算逻辑
 	switch project.PoolPlatform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		getSettleSnapshotsByRaydiumPool(c, project, req.SnapshotID)
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		getSettleSnapshotsByPumpfunPool(c, project, req.SnapshotID)
 	default:
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform"})
@@ -460,11 +462,10 @@ func getSettleSnapshotsByRaydiumPool(c *gin.Context, project models.ProjectConfi
 	tvlByProjectLastSoldOut := tvlByExpectPool - tvlByRetailInvestors
 
 	// 7. 查询项目代币和SOL代币的快照统计数据
-	solMint := "So11111111111111111111111111111111111111112"
-	nativeSolMint := "sol"
+	mints := append([]string{tokenMint}, mcsolana.SolMints()...)
 	var allSnapshots []models.WalletTokenSnapshot
 	if len(addresses) > 0 {
-		if err := dbconfig.DB.Where("project_id = ? AND snapshot_id = ? AND mint IN ? AND owner_address IN ?", project.ID, snapshotID, []string{tokenMint, solMint, nativeSolMint}, addresses).Find(&allSnapshots).Error; err != nil {
+		if err := dbconfig.DB.Where("project_id = ? AND snapshot_id = ? AND mint IN ? AND owner_address IN ?", project.ID, snapshotID, mints, addresses).Find(&allSnapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -575,11 +576,10 @@ func getSettleSnapshotsByPumpfunPool(c *gin.Context, project models.ProjectConfi
 	tvlByProjectLastSoldOut := tvlByExpectPool - tvlByRetailInvestors
 
 	// 7. 查询项目代币和SOL代币的快照统计数据
-	solMint := "So11111111111111111111111111111111111111112"
-	nativeSolMint := "sol"
+	mints := append([]string{tokenMint}, mcsolana.SolMints()...)
 	var allSnapshots []models.WalletTokenSnapshot
 	if len(addresses) > 0 {
-		if err := dbconfig.DB.Where("project_id = ? AND snapshot_id = ? AND mint IN ? AND owner_address IN ?", project.ID, snapshotID, []string{tokenMint, solMint, nativeSolMint}, addresses).Find(&allSnapshots).Error; err != nil {
+		if err := dbconfig.DB.Where("project_id = ? AND snapshot_id = ? AND mint IN ? AND owner_address IN ?", project.ID, snapshotID, mints, addresses).Find(&allSnapshots).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -717,12 +717,11 @@ func BuildPumpfuninternalSnapshotResp(snapshot *models.PumpfuninternalSnapshot)
 // ListPumpfuninternalSnapshots 获取所有 Pumpfuninternal 快照
 func ListPumpfuninternalSnapshots(c *gin.Context) {
 	var snapshots []models.PumpfuninternalSnapshot
-	if err := dbconfig.DB.Find(&snapshots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &snapshots) {
 		return
 	}
 
-	var result []*PumpfuninternalSnapshotResp
+	result := []*PumpfuninternalSnapshotResp{}
 	for _, snapshot := range snapshots {
 		snap := snapshot // Create a new variable to avoid pointer issues
 		result = append(result, BuildPumpfuninternalSnapshotResp(&snap))
@@ -773,15 +772,232 @@ func GetPumpfuninternalSnapshotByProject(c *gin.Context) {
 func ListPumpfuninternalSnapshotsBySnapshotID(c *gin.Context) {
 	snapshotID := c.Param("snapshot_id")
 	var snapshots []models.PumpfuninternalSnapshot
-	if err := dbconfig.DB.Where("snapshot_id = ?", snapshotID).Find(&snapshots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Where("snapshot_id = ?", snapshotID), &snapshots) {
 		return
 	}
 
-	var result []*PumpfuninternalSnapshotResp
+	result := []*PumpfuninternalSnapshotResp{}
 	for _, snapshot := range snapshots {
 		snap := snapshot // Create a new variable to avoid pointer issues
 		result = append(result, BuildPumpfuninternalSnapshotResp(&snap))
 	}
 	c.JSON(http.StatusOK, result)
 }
+
+// CreateProjectSnapshot computes and stores a snapshot of a project's current assets balance,
+// retail SOL, holder count and profit since the previous snapshot, and increments ProjectConfig.SnapshotCount.
+// The new snapshot becomes the baseline of the project's project_profit.
+func CreateProjectSnapshot(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project_id format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if !project.SnapshotEnabled {
+		c.JSON(http.StatusConflict, gin.H{"error": "Snapshots are disabled for this project"})
+		return
+	}
+	if !project.UpdateStatEnabled {
+		c.JSON(http.StatusConflict, gin.H{"error": "Stat updates are disabled for this project"})
+		return
+	}
+
+	// 持有者数：池子持有者表中仓位大于 0 的地址
+	var holderCount int64
+	if resp := buildProjectConfigResp(&project); resp != nil {
+		if tables, ok := resolvePoolDataTables(resp.Pool); ok && tables.PoolValue != "" {
+			if err := dbconfig.DB.Model(tables.HolderModel).
+				Where(tables.PoolColumn+" = ? AND "+tables.BalanceColumn+" > 0", tables.PoolValue).
+				Count(&holderCount).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
+	snapshot := models.ProjectSnapshot{
+		ProjectID:       project.ID,
+		AssetsBalance:   project.AssetsBalance,
+		RetailSolAmount: project.RetailSolAmount,
+		HolderCount:     holderCount,
+	}
+	err = dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, project.ID).Error; err != nil {
+			return err
+		}
+		// 快照记录相对上一次快照的 profit，之后项目的 profit 以本次快照为基线重新计算
+		if err := applyProjectProfit(tx, &project); err != nil {
+			return err
+		}
+		snapshot.AssetsBalance = project.AssetsBalance
+		snapshot.ProjectProfit = project.ProjectProfit
+
+		if err := tx.Model(&models.ProjectConfig{}).Where("id = ?", project.ID).
+			UpdateColumn("snapshot_count", gorm.Expr("snapshot_count + 1")).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ProjectConfig{}).Select("snapshot_count").
+			Where("id = ?", project.ID).Scan(&snapshot.SnapshotNo).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&snapshot).Error; err != nil {
+			return err
+		}
+		return applyProjectProfit(tx, &project)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, snapshot)
+}
+
+// ListProjectSnapshots returns a project's snapshot time series in chronological order
+// Query parameters: start_time / end_time (RFC3339), page (default: 1), page_size (default: 100, max: 1000)
+func ListProjectSnapshots(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project_id format"})
+		return
+	}
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 100
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 1000 {
+			pageSize = parsed
+		}
+	}
+
+	query := dbconfig.DB.Model(&models.ProjectSnapshot{}).Where("project_id = ?", projectID)
+	if v := c.Query("start_time"); v != "" {
+		startTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_time, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at >= ?", startTime)
+	}
+	if v := c.Query("end_time"); v != "" {
+		endTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_time, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at <= ?", endTime)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshots := []models.ProjectSnapshot{}
+	if err := query.Order("created_at asc, id asc").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&snapshots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       snapshots,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// ProjectProfitPoint is one snapshot of a project's profit time series
+type ProjectProfitPoint struct {
+	SnapshotID       uint      `json:"snapshot_id"`
+	SnapshotNo       int       `json:"snapshot_no"`
+	AssetsBalance    float64   `json:"assets_balance"`
+	Profit           float64   `json:"profit"` // assets_balance minus the previous snapshot's; 0 for the first snapshot
+	CumulativeProfit float64   `json:"cumulative_profit"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// GetProjectProfitHistory returns a project's profit time series derived from its own snapshots:
+// each point is the change of assets_balance since the previous snapshot of the same project, and
+// current is the unsnapshotted change since the latest one (the stored project_profit).
+// Query parameters: start_time / end_time (RFC3339); deltas at the window start still use the snapshot before it
+func GetProjectProfitHistory(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project_id format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 先在项目全部快照上计算差值，再按时间窗口过滤，窗口内第一个点的差值仍以窗口前的快照为基线
+	series := dbconfig.DB.Model(&models.ProjectSnapshot{}).
+		Select("id AS snapshot_id, snapshot_no, assets_balance, created_at, "+
+			"COALESCE(assets_balance - LAG(assets_balance) OVER (ORDER BY id), 0) AS profit").
+		Where("project_id = ?", project.ID)
+	query := dbconfig.DB.Table("(?) AS s", series)
+	if v := c.Query("start_time"); v != "" {
+		startTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_time, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at >= ?", startTime)
+	}
+	if v := c.Query("end_time"); v != "" {
+		endTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_time, expected RFC3339"})
+			return
+		}
+		query = query.Where("created_at <= ?", endTime)
+	}
+
+	points := []ProjectProfitPoint{}
+	if err := query.Order("snapshot_id asc").Scan(&points).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var cumulative float64
+	for i := range points {
+		cumulative += points[i].Profit
+		points[i].CumulativeProfit = cumulative
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":     project.ID,
+		"assets_balance": project.AssetsBalance,
+		"current": gin.H{
+			"profit":               project.ProjectProfit,
+			"baseline_snapshot_id": project.ProfitBaselineID,
+		},
+		"total_profit": cumulative + project.ProjectProfit,
+		"data":         points,
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -19,8 +18,7 @@ import (
 // ListWalletTokenStats 获取所有钱包代币统计信息
 func ListWalletTokenStats(c *gin.Context) {
 	var stats []models.WalletTokenStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -56,7 +54,7 @@ func CleanWalletTokenStat(c *gin.Context) {
 		return
 	}
 
-	cutoff := time.Now().AddDate(0, 0, -req.Before)
+	cutoff := handlerClock.Now().AddDate(0, 0, -req.Before)
 	result := dbconfig.DB.Where("created_at < ?", cutoff).Delete(&models.WalletTokenStat{})
 	if result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
@@ -96,8 +94,7 @@ func GetWalletTokenStatsByMint(c *gin.Context) {
 // ListPoolStats 获取所有池子统计信息
 func ListPoolStats(c *gin.Context) {
 	var stats []models.PoolStat
-	if err := dbconfig.DB.Preload("Pool").Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Preload("Pool"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -150,7 +147,7 @@ func GetPoolStatByProjectID(c *gin.Context) {
 	}
 
 	switch project.PoolPlatform {
-	case "raydium":
+	case models.PoolPlatformRaydium:
 		var poolStat models.PoolStat
 		if err := dbconfig.DB.Preload("Pool").Where("pool_id = ?", project.PoolID).First(&poolStat).Error; err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "PoolStat not found"})
@@ -164,7 +161,7 @@ func GetPoolStatByProjectID(c *gin.Context) {
 		}
 		c.JSON(http.StatusOK, resp)
 
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		var pumpfunStat models.PumpfuninternalStat
 		if err := dbconfig.DB.Preload("PumpfunPool").
 			Where("pumpfuninternal_id = ?", project.PoolID).
@@ -174,7 +171,7 @@ func GetPoolStatByProjectID(c *gin.Context) {
 			return
 		}
 		resp := BuildPumpfuninternalStatRespSimple(&pumpfunStat)
-		resp.Platform = "pumpfun_internal"
+		resp.Platform = models.PoolPlatformPumpfunInternal.String()
 		c.JSON(http.StatusOK, resp)
 
 	default:
@@ -225,10 +222,11 @@ func GetWalletTokenStatsByRole(c *gin.Context) {
 
 	if len(roleAddresses) == 0 {
 		c.JSON(http.StatusOK, gin.H{
-			"total":     0,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      []interface{}{},
+			"total":      0,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       []interface{}{},
+			"pagination": paginationMeta(page, pageSize, 0),
 		})
 		return
 	}
@@ -259,9 +257,9 @@ func GetWalletTokenStatsByRole(c *gin.Context) {
 		statMap[stat.OwnerAddress][stat.Mint] = stat
 	}
 
-	var result []TokenGroup
+	result := []TokenGroup{}
 	for _, address := range addresses {
-		var tokenList []WalletTokenStatResp
+		tokenList := []WalletTokenStatResp{}
 
 		// 确保返回所有请求的代币，即使余额为0
 		for _, mint := range req.Tokens {
@@ -321,10 +319,11 @@ func GetWalletTokenStatsByRole(c *gin.Context) {
 	}
 	if start >= len(result) {
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      []interface{}{},
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       []interface{}{},
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 		return
 	}
@@ -334,10 +333,11 @@ func GetWalletTokenStatsByRole(c *gin.Context) {
 
 	// 返回分页结果
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      pagedResult,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       pagedResult,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -405,9 +405,9 @@ func GetTotalWalletTokenStatsByRole(c *gin.Context) {
 		resultMap[stat.OwnerAddress] = append(resultMap[stat.OwnerAddress], stat)
 	}
 
-	var result []TokenGroup
+	result := []TokenGroup{}
 	for owner, tokens := range resultMap {
-		var tokenList []WalletTokenStatResp
+		tokenList := []WalletTokenStatResp{}
 		for _, t := range tokens {
 			tokenList = append(tokenList, WalletTokenStatResp{
 				Mint:            t.Mint,
@@ -477,7 +477,7 @@ func GetAggregateWalletTokenStatsByRole(c *gin.Context) {
 
 	tokenMap := AggregateTokenStats(stats)
 
-	var result []AggregateTokenStat
+	result := []AggregateTokenStat{}
 	for _, v := range tokenMap {
 		result = append(result, *v)
 	}
@@ -534,7 +534,7 @@ func GetAggregateWalletTokenStatsByProject(c *gin.Context) {
 
 	tokenMap := AggregateTokenStats(stats)
 
-	var result []AggregateTokenStat
+	result := []AggregateTokenStat{}
 	for _, mint := range req.Tokens {
 		if agg, ok := tokenMap[mint]; ok {
 			result = append(result, *agg)
@@ -574,13 +574,13 @@ func GetSettleStatsByProject(c *gin.Context) {
 	switch project.PoolPlatform {
 	// case "raydium":
 	// 	getSettleStatsByRaydiumPool(c, project)
-	case "pumpfun_internal":
+	case models.PoolPlatformPumpfunInternal:
 		getSettleStatsByPumpfunPool(c, project)
-	case "pumpfun_amm":
+	case models.PoolPlatformPumpfunAmm:
 		getSettleStatsByPumpfunAmmPool(c, project)
-	case "meteora_dbc":
+	case models.PoolPlatformMeteoraDbc:
 		getSettleStatsByMeteoradbcPool(c, project)
-	case "meteora_cpmm":
+	case models.PoolPlatformMeteoraCpmm:
 		getSettleStatsByMeteoracpmmPool(c, project)
 	default:
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform"})
@@ -604,7 +604,7 @@ func GetRetailSolAmountByProject(c *gin.Context) {
 	retailSol := 0.0
 
 	switch project.PoolPlatform {
-	case "meteora_cpmm":
+	case models.PoolPlatformMeteoraCpmm:
 		var pool models.MeteoracpmmConfig
 		if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -648,7 +648,7 @@ func GetRetailSolAmountByProject(c *gin.Context) {
 			}
 		}
 
-	case "meteora_dbc":
+	case models.PoolPlatformMeteoraDbc:
 		var pool models.MeteoradbcConfig
 		if err := dbconfig.DB.First(&pool, project.PoolID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -950,8 +950,7 @@ func getSettleStatsByMeteoracpmmPool(c *gin.Context, project models.ProjectConfi
 // ListPumpfuninternalStats returns a list of all pumpfun internal stats
 func ListPumpfuninternalStats(c *gin.Context) {
 	var stats []models.PumpfuninternalStat
-	if err := dbconfig.DB.Preload("PumpfunPool").Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Preload("PumpfunPool"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -998,7 +997,7 @@ func GetPumpfuninternalStatByProjectID(c *gin.Context) {
 	}
 
 	var project models.ProjectConfig
-	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, "pumpfun_internal").First(&project).Error; err != nil {
+	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, models.PoolPlatformPumpfunInternal).First(&project).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found or not a pumpfun internal pool"})
 		return
 	}
@@ -1013,7 +1012,7 @@ func GetPumpfuninternalStatByProjectID(c *gin.Context) {
 	}
 
 	resp := BuildPumpfuninternalStatRespSimple(&stat)
-	resp.Platform = "pumpfun_internal"
+	resp.Platform = models.PoolPlatformPumpfunInternal.String()
 	c.JSON(http.StatusOK, gin.H{
 		"id":                     resp.ID,
 		"pumpfuninternal_id":     resp.PumpfuninternalID,
@@ -1064,8 +1063,7 @@ func GetPumpfuninternalStatsByMint(c *gin.Context) {
 // ListPumpfunAmmPoolStats returns a list of all pool stats
 func ListPumpfunAmmPoolStats(c *gin.Context) {
 	var stats []models.PumpfunAmmPoolStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -1120,7 +1118,7 @@ func GetPumpfunAmmPoolStatByProjectID(c *gin.Context) {
 	}
 
 	var project models.ProjectConfig
-	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, "pumpfun_amm").First(&project).Error; err != nil {
+	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, models.PoolPlatformPumpfunAmm).First(&project).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found or not a pumpfun AMM pool"})
 		return
 	}
@@ -1175,8 +1173,7 @@ func DeletePumpfunAmmPoolStat(c *gin.Context) {
 // ListRaydiumLaunchpadPoolStats returns all Raydium Launchpad pool stats
 func ListRaydiumLaunchpadPoolStats(c *gin.Context) {
 	var stats []models.RaydiumLaunchpadPoolStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -1329,8 +1326,7 @@ func DeleteRaydiumLaunchpadPoolStat(c *gin.Context) {
 // ListRaydiumCpmmPoolStats returns all Raydium CPMM pool stats
 func ListRaydiumCpmmPoolStats(c *gin.Context) {
 	var stats []models.RaydiumCpmmPoolStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -1497,7 +1493,7 @@ func LocateDuplicateWalletTokenStat(c *gin.Context) {
 	}
 
 	// 存储有重复记录的地址
-	var duplicateAddresses []string
+	duplicateAddresses := []string{}
 
 	// 对每个地址进行处理
 	for _, address := range addresses {
@@ -1554,7 +1550,7 @@ func RemoveDuplicateWalletTokenStat(c *gin.Context) {
 
 	// 统计删除的记录数和地址
 	var totalDeleted int64 = 0
-	var affectedAddresses []string
+	affectedAddresses := []string{}
 
 	// 对每个地址进行处理
 	for _, address := range addresses {
@@ -1593,8 +1589,7 @@ func RemoveDuplicateWalletTokenStat(c *gin.Context) {
 // ListMeteoradbcPoolStats returns all Meteoradbc pool stats
 func ListMeteoradbcPoolStats(c *gin.Context) {
 	var stats []models.MeteoradbcPoolStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -1703,7 +1698,7 @@ func GetMeteoradbcPoolStatByProjectID(c *gin.Context) {
 
 	// Find the project config
 	var projectConfig models.ProjectConfig
-	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, "meteora_dbc").First(&projectConfig).Error; err != nil {
+	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, models.PoolPlatformMeteoraDbc).First(&projectConfig).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found or not a Meteoradbc pool"})
 			return
@@ -1759,8 +1754,7 @@ func GetMeteoradbcPoolStatByProjectID(c *gin.Context) {
 // ListMeteoracpmmPoolStats returns all Meteoracpmm pool stats
 func ListMeteoracpmmPoolStats(c *gin.Context) {
 	var stats []models.MeteoracpmmPoolStat
-	if err := dbconfig.DB.Find(&stats).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &stats) {
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -1874,7 +1868,7 @@ func GetMeteoracpmmPoolStatByProjectID(c *gin.Context) {
 
 	// Find the project config
 	var projectConfig models.ProjectConfig
-	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, "meteora_cpmm").First(&projectConfig).Error; err != nil {
+	if err := dbconfig.DB.Where("id = ? AND pool_platform = ?", projectID, models.PoolPlatformMeteoraCpmm).First(&projectConfig).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found or not a Meteoracpmm pool"})
 			return
@@ -91,7 +91,7 @@ func GetPumpfunAmmPoolConfig(c *gin.Context) {
 func ListPumpfunAmmPoolConfigs(c *gin.Context) {
 	var configs []models.PumpfunAmmPoolConfig
 
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -153,19 +153,9 @@ func ListPumpfunAmmPoolConfigsBySlice(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
-
 	response := gin.H{
-		"data": configs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       configs,
+		"pagination": paginationMeta(page, pageSize, total),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -200,6 +190,7 @@ func UpdatePumpfunAmmPoolConfig(c *gin.Context) {
 	config.PoolQuoteTokenAccount = req.PoolQuoteTokenAccount
 	config.LpSupply = req.LpSupply
 	config.CoinCreator = req.CoinCreator
+	previousStatus := config.Status
 	if req.Status != "" {
 		config.Status = req.Status
 	}
@@ -208,6 +199,7 @@ func UpdatePumpfunAmmPoolConfig(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformPumpfunAmm, config.ID, config.PoolAddress, previousStatus, config.Status, "UpdatePumpfunAmmPoolConfig")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -229,20 +221,19 @@ func DeletePumpfunAmmPoolConfig(c *gin.Context) {
 	}
 
 	// Check if any project is using this pool
-	var projectCount int64
-	if err := tx.Model(&models.ProjectConfig{}).
-		Where("pool_platform = ? AND pool_id = ?", "pumpfun_amm", idInt).
-		Count(&projectCount).Error; err != nil {
+	projectIDs, err := findProjectIDsByPool(tx, models.PoolPlatformPumpfunAmm, uint(idInt))
+	if err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
 		return
 	}
 
-	if projectCount > 0 {
+	if len(projectIDs) > 0 {
 		tx.Rollback()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot delete pool: there are projects using this pool",
-			"project_count": projectCount,
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
 		})
 		return
 	}
@@ -307,10 +298,12 @@ func UpdatePumpfunAmmPoolConfigStatus(c *gin.Context) {
 	}
 
 	// Update status
+	previousStatus := config.Status
 	if err := dbconfig.DB.Model(&config).Update("status", request.Status).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformPumpfunAmm, config.ID, config.PoolAddress, previousStatus, request.Status, "UpdatePumpfunAmmPoolConfigStatus")
 
 	c.JSON(http.StatusOK, config)
 }
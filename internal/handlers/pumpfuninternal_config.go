@@ -32,7 +32,7 @@ type UpdateStatusRequest struct {
 // ListPumpfuninternalConfigs returns a list of all pumpfuninternal configs
 func ListPumpfuninternalConfigs(c *gin.Context) {
 	var configs []models.PumpfuninternalConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -93,19 +93,9 @@ func ListPumpfuninternalConfigsBySlice(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
-
 	response := gin.H{
-		"data": configs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       configs,
+		"pagination": paginationMeta(page, pageSize, total),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -271,10 +261,12 @@ func UpdatePumpfuninternalConfigStatus(c *gin.Context) {
 	}
 
 	// Update status
+	previousStatus := config.Status
 	if err := dbconfig.DB.Model(&config).Update("status", request.Status).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	recordPoolStatusChange(dbconfig.DB, models.PoolPlatformPumpfunInternal, config.ID, config.BondingCurvePda, previousStatus, request.Status, "UpdatePumpfuninternalConfigStatus")
 
 	c.JSON(http.StatusOK, config)
 }
@@ -295,20 +287,19 @@ func DeletePumpfuninternalConfig(c *gin.Context) {
 	}
 
 	// Check if any project is using this pool
-	var projectCount int64
-	if err := tx.Model(&models.ProjectConfig{}).
-		Where("pool_platform = ? AND pool_id = ?", "pumpfun_internal", id).
-		Count(&projectCount).Error; err != nil {
+	projectIDs, err := findProjectIDsByPool(tx, models.PoolPlatformPumpfunInternal, uint(id))
+	if err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
 		return
 	}
 
-	if projectCount > 0 {
+	if len(projectIDs) > 0 {
 		tx.Rollback()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Cannot delete pool: there are projects using this pool",
-			"project_count": projectCount,
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
 		})
 		return
 	}
@@ -340,4 +331,132 @@ func DeletePumpfuninternalConfig(c *gin.Context) {
 		"message": "Pool config and associated stats deleted successfully",
 		"deleted_stats_count": deletedStatsCount,
 	})
-} 
\ No newline at end of file
+} 
+const (
+	defaultPDABackfillBatchSize = 50
+	maxPDABackfillBatchSize     = 500
+)
+
+// BackfillPumpfunPDAsRequest represents the optional request body for BackfillPumpfunPDAs
+type BackfillPumpfunPDAsRequest struct {
+	BatchSize int `json:"batch_size"`
+}
+
+// PDABackfillFailure records a config whose PDAs could not be re-derived or stored
+type PDABackfillFailure struct {
+	ID    uint   `json:"id"`
+	Mint  string `json:"mint"`
+	Error string `json:"error"`
+}
+
+// BackfillPumpfunPDAs re-derives bonding_curve_pda, associated_bonding_curve and
+// creator_vault_pda for configs missing any of them. Only blank columns are written.
+func BackfillPumpfunPDAs(c *gin.Context) {
+	var request BackfillPumpfunPDAsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	batchSize := request.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPDABackfillBatchSize
+	}
+	if batchSize > maxPDABackfillBatchSize {
+		batchSize = maxPDABackfillBatchSize
+	}
+
+	endpoints := solanaRPCEndpoints()
+	if len(endpoints) == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Solana RPC endpoint not configured"})
+		return
+	}
+
+	missing := func(column string) string {
+		return "(" + column + " IS NULL OR " + column + " = '')"
+	}
+	missingPDAs := missing("bonding_curve_pda") + " OR " +
+		missing("associated_bonding_curve") + " OR " +
+		missing("creator_vault_pda")
+
+	scanned, updated := 0, 0
+	failures := make([]PDABackfillFailure, 0)
+	var lastID uint
+	for {
+		var configs []models.PumpfuninternalConfig
+		if err := dbconfig.DB.Where("id > ?", lastID).Where(missingPDAs).
+			Order("id").Limit(batchSize).Find(&configs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(configs) == 0 {
+			break
+		}
+		lastID = configs[len(configs)-1].ID
+		scanned += len(configs)
+
+		// 先在链上推导整批，再用一个事务写回
+		changes := make(map[uint]map[string]interface{}, len(configs))
+		for _, config := range configs {
+			mintPubkey, err := solana.PublicKeyFromBase58(config.Mint)
+			if err != nil {
+				failures = append(failures, PDABackfillFailure{ID: config.ID, Mint: config.Mint, Error: "Invalid mint address"})
+				continue
+			}
+
+			var pdas *pumpsolana.PumpFunInternalPDAs
+			err = withSolanaRPCFailover(endpoints, func(client *rpc.Client) error {
+				var err error
+				pdas, err = pumpsolana.GetPumpFunInternalPDAs(client, mintPubkey)
+				return err
+			})
+			if err != nil {
+				failures = append(failures, PDABackfillFailure{ID: config.ID, Mint: config.Mint, Error: err.Error()})
+				continue
+			}
+
+			fields := make(map[string]interface{})
+			if config.BondingCurvePda == "" {
+				fields["bonding_curve_pda"] = pdas.BondingCurvePDA
+			}
+			if config.AssociatedBondingCurve == "" {
+				fields["associated_bonding_curve"] = pdas.AssociatedBondingCurve
+			}
+			if config.CreatorVaultPda == "" {
+				fields["creator_vault_pda"] = pdas.CreatorVaultPDA
+			}
+			if len(fields) > 0 {
+				changes[config.ID] = fields
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+			for id, fields := range changes {
+				if err := tx.Model(&models.PumpfuninternalConfig{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for _, config := range configs {
+				if _, ok := changes[config.ID]; ok {
+					failures = append(failures, PDABackfillFailure{ID: config.ID, Mint: config.Mint, Error: err.Error()})
+				}
+			}
+			continue
+		}
+		updated += len(changes)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scanned":  scanned,
+		"updated":  updated,
+		"failed":   len(failures),
+		"failures": failures,
+	})
+}
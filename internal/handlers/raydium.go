@@ -54,7 +54,7 @@ type RaydiumLaunchpadPoolConfigRequest struct {
 // ListRaydiumLaunchpadPoolConfigs returns all Raydium launchpad pool configs
 func ListRaydiumLaunchpadPoolConfigs(c *gin.Context) {
 	var configs []models.RaydiumLaunchpadPoolConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -210,6 +210,22 @@ func DeleteRaydiumLaunchpadPoolConfig(c *gin.Context) {
 		return
 	}
 
+	// Check if any project is using this pool
+	projectIDs, err := findProjectIDsByPool(dbconfig.DB, models.PoolPlatformRaydiumLaunchpad, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
+		return
+	}
+
+	if len(projectIDs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
+		})
+		return
+	}
+
 	if err := dbconfig.DB.Delete(&models.RaydiumLaunchpadPoolConfig{}, id).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -289,7 +305,7 @@ func CreateRaydiumLaunchpadPoolConfigByMint(c *gin.Context) {
 	// Step 4: Create RaydiumLaunchpadPoolConfig based on poolData and configData
 	launchpadConfig := models.RaydiumLaunchpadPoolConfig{
 		PoolAddress:         poolIds.LaunchpadPoolId.String(),
-		Platform:            "raydium_launchpad",
+		Platform:            models.PoolPlatformRaydiumLaunchpad.String(),
 		PoolConfigEpoch:     uint64(configData.Epoch),
 		CurveType:           uint64(configData.CurveType),
 		Index:               uint64(configData.Index),
@@ -414,7 +430,7 @@ type RaydiumPoolRelationRequest struct {
 // ListRaydiumCpmmPoolConfigs returns all Raydium CPMM pool configs
 func ListRaydiumCpmmPoolConfigs(c *gin.Context) {
 	var configs []models.RaydiumCpmmPoolConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -552,6 +568,22 @@ func DeleteRaydiumCpmmPoolConfig(c *gin.Context) {
 		return
 	}
 
+	// Check if any project is using this pool
+	projectIDs, err := findProjectIDsByPool(dbconfig.DB, models.PoolPlatformRaydiumCpmm, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
+		return
+	}
+
+	if len(projectIDs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":         "Cannot delete pool: there are projects using this pool",
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
+		})
+		return
+	}
+
 	if err := dbconfig.DB.Delete(&models.RaydiumCpmmPoolConfig{}, id).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -564,7 +596,7 @@ func DeleteRaydiumCpmmPoolConfig(c *gin.Context) {
 // ListRaydiumPoolRelations returns all Raydium pool relations
 func ListRaydiumPoolRelations(c *gin.Context) {
 	var relations []models.RaydiumPoolRelation
-	if err := dbconfig.DB.Find(&relations).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&relations).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
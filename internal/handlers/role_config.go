@@ -9,7 +9,6 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
-	"sort"
 	"strconv"
 	"time"
 
@@ -62,7 +61,7 @@ type RoleConfigRelationRequest struct {
 // ListRoleConfigs returns a list of all role configs
 func ListRoleConfigs(c *gin.Context) {
 	var roles []models.RoleConfig
-	if err := dbconfig.DB.Find(&roles).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&roles).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -219,80 +218,67 @@ func ListRoleAddresses(c *gin.Context) {
 		order = "desc"
 	}
 
-	var addresses []models.RoleAddress
-	if err := dbconfig.DB.Preload("Role").Find(&addresses).Error; err != nil {
+	// 在数据库中按地址聚合并分页，只加载当前页地址的角色信息
+	var total int64
+	if err := dbconfig.DB.Model(&models.RoleAddress{}).Distinct("address").Count(&total).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	totalPages := (int(total) + pageSize - 1) / pageSize
 
-	// 使用 map 来统计和去重
-	addressMap := make(map[string]*AddressWithRoleInfo)
+	// 确保页码不超过总页数
+	if page > totalPages {
+		page = totalPages
+	}
 
-	// 遍历所有地址，进行统计和角色信息收集
-	for _, addr := range addresses {
-		if info, exists := addressMap[addr.Address]; exists {
-			// 地址已存在，增加计数并添加角色信息
-			info.RoleCount++
-			// 检查角色是否已添加
+	pageData := []AddressWithRoleInfo{}
+	if total > 0 {
+		var counts []struct {
+			Address   string
+			RoleCount int
+		}
+		query := dbconfig.DB.Model(&models.RoleAddress{}).
+			Select("address, COUNT(*) AS role_count").
+			Group("address").
+			Order("role_count " + order).
+			Order("MAX(id) desc").
+			Offset((page - 1) * pageSize).
+			Limit(pageSize)
+		if !findBounded(c, query, &counts) {
+			return
+		}
+
+		pageAddresses := make([]string, len(counts))
+		for i, count := range counts {
+			pageAddresses[i] = count.Address
+		}
+		var addresses []models.RoleAddress
+		if err := dbconfig.DB.Preload("Role").Where("address IN ?", pageAddresses).Order("id asc").Find(&addresses).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 收集每个地址的角色信息（去重）
+		roles := make(map[string][]*models.RoleConfig, len(counts))
+		for _, addr := range addresses {
 			roleExists := false
-			for _, role := range info.RoleLists {
+			for _, role := range roles[addr.Address] {
 				if role.ID == addr.Role.ID {
 					roleExists = true
 					break
 				}
 			}
 			if !roleExists {
-				info.RoleLists = append(info.RoleLists, addr.Role)
-			}
-		} else {
-			// 新地址，创建记录
-			addressMap[addr.Address] = &AddressWithRoleInfo{
-				Address:   addr.Address,
-				RoleCount: 1,
-				RoleLists: []*models.RoleConfig{addr.Role},
+				roles[addr.Address] = append(roles[addr.Address], addr.Role)
 			}
 		}
-	}
-
-	// 转换 map 为 slice 并排序
-	result := make([]AddressWithRoleInfo, 0, len(addressMap))
-	for _, info := range addressMap {
-		result = append(result, *info)
-	}
-
-	// 根据 role_count 排序
-	if order == "asc" {
-		sort.Slice(result, func(i, j int) bool {
-			return result[i].RoleCount < result[j].RoleCount
-		})
-	} else {
-		sort.Slice(result, func(i, j int) bool {
-			return result[i].RoleCount > result[j].RoleCount
-		})
-	}
-
-	// 计算总记录数和总页数
-	total := len(result)
-	totalPages := (total + pageSize - 1) / pageSize
-
-	// 确保页码不超过总页数
-	if page > totalPages {
-		page = totalPages
-	}
-
-	// 计算分页的起始和结束索引
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if end > total {
-		end = total
-	}
-
-	// 获取当前页的数据
-	var pageData []AddressWithRoleInfo
-	if start < total {
-		pageData = result[start:end]
-	} else {
-		pageData = []AddressWithRoleInfo{}
+		for _, count := range counts {
+			pageData = append(pageData, AddressWithRoleInfo{
+				Address:   count.Address,
+				RoleCount: count.RoleCount,
+				RoleLists: roles[count.Address],
+			})
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -302,6 +288,7 @@ func ListRoleAddresses(c *gin.Context) {
 		"page_size":    pageSize,
 		"order":        order,
 		"data":         pageData,
+		"pagination":   paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -424,10 +411,11 @@ func GetRoleAddressByRoleID(c *gin.Context) {
 
 	// 返回结果
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      addresses,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       addresses,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -979,7 +967,7 @@ func GetRoleConfigRelationByProjectID(c *gin.Context) {
 // ListRoleConfigRelations returns a list of all role config relations
 func ListRoleConfigRelations(c *gin.Context) {
 	var relations []models.RoleConfigRelation
-	if err := dbconfig.DB.Find(&relations).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&relations).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -12,8 +12,7 @@ import (
 // ListRpcConfigs returns a list of all RPC configurations
 func ListRpcConfigs(c *gin.Context) {
 	var configs []models.RpcConfig
-	if err := dbconfig.DB.Preload("BlockchainConfig").Find(&configs).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Preload("BlockchainConfig").Order("id desc"), &configs) {
 		return
 	}
 	c.JSON(http.StatusOK, configs)
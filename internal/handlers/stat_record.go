@@ -26,8 +26,7 @@ type ProjectSettleRecordFilterResponse map[string]interface{}
 // ListProjectSettleRecords 获取项目结算记录列表
 func ListProjectSettleRecords(c *gin.Context) {
 	var records []models.ProjectSettleRecord
-	if err := dbconfig.DB.Find(&records).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &records) {
 		return
 	}
 	c.JSON(http.StatusOK, records)
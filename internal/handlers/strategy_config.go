@@ -36,7 +36,7 @@ type UpdateStrategyStatRequest struct {
 // ListStrategyConfigs returns a list of all strategy configs
 func ListStrategyConfigs(c *gin.Context) {
 	var strategies []models.StrategyConfig
-	if err := dbconfig.DB.Find(&strategies).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&strategies).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -52,13 +52,96 @@ func ListStrategyConfigsByProjectId(c *gin.Context) {
 	}
 
 	var strategies []models.StrategyConfig
-	if err := dbconfig.DB.Where("project_id = ?", projectID).Find(&strategies).Error; err != nil {
+	if err := dbconfig.DB.Where("project_id = ?", projectID).Order("id desc").Find(&strategies).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, strategies)
 }
 
+// StrategyOverview is a strategy config joined with its project, role and token context
+type StrategyOverview struct {
+	models.StrategyConfig
+	ProjectName  string `json:"project_name"`
+	PoolPlatform string `json:"pool_platform"`
+	RoleName     string `json:"role_name"`
+	TokenMint    string `json:"token_mint"`
+	TokenSymbol  string `json:"token_symbol"`
+}
+
+// ListStrategies returns a paginated list of strategies with project name and token symbol
+// Query parameters: project_id, role_id, enabled (true/false), strategy_type, page (default: 1), page_size (default: 20, max: 200)
+func ListStrategies(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 20
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 200 {
+			pageSize = parsed
+		}
+	}
+
+	query := dbconfig.DB.Table("strategy_config AS s").
+		Joins("LEFT JOIN project_config AS p ON p.id = s.project_id").
+		Joins("LEFT JOIN role_config AS r ON r.id = s.role_id").
+		Joins("LEFT JOIN token_info AS t ON t.id = p.token_id")
+
+	if v := c.Query("project_id"); v != "" {
+		projectID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project_id format"})
+			return
+		}
+		query = query.Where("s.project_id = ?", projectID)
+	}
+	if v := c.Query("role_id"); v != "" {
+		roleID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role_id format"})
+			return
+		}
+		query = query.Where("s.role_id = ?", roleID)
+	}
+	if v := c.Query("enabled"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid enabled value"})
+			return
+		}
+		query = query.Where("s.enabled = ?", enabled)
+	}
+	if v := c.Query("strategy_type"); v != "" {
+		query = query.Where("s.strategy_type = ?", v)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	strategies := []StrategyOverview{}
+	if err := query.
+		Select(`s.*, COALESCE(p.name, '') AS project_name, COALESCE(p.pool_platform, '') AS pool_platform,
+			COALESCE(r.role_name, '') AS role_name, COALESCE(t.mint, '') AS token_mint, COALESCE(t.symbol, '') AS token_symbol`).
+		Order("s.id desc").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&strategies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       strategies,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
 // GetStrategyConfig returns a specific strategy config by ID
 func GetStrategyConfig(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
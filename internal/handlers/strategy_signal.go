@@ -41,8 +41,7 @@ type StrategySignalUpdateRequest struct {
 // ListStrategySignals returns a list of all strategy signals
 func ListStrategySignals(c *gin.Context) {
 	var signals []models.StrategySignal
-	if err := dbconfig.DB.Find(&signals).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, dbconfig.DB.Order("id desc"), &signals) {
 		return
 	}
 	c.JSON(http.StatusOK, signals)
@@ -104,10 +103,11 @@ func GetStrategySignalsByProjectID(c *gin.Context) {
 
 	// 返回结果
 	c.JSON(http.StatusOK, gin.H{
-		"total": total,
-		"page": page,
-		"page_size": pageSize,
-		"data": signals,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       signals,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
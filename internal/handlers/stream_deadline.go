@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"marketcontrol/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultStreamWriteTimeout replaces SERVER_WRITE_TIMEOUT for streaming exports, configurable via
+// SERVER_STREAM_WRITE_TIMEOUT ("0" removes the deadline)
+const defaultStreamWriteTimeout = time.Hour
+
+// extendWriteDeadline lifts the server-wide write timeout for the current request so a long export is not
+// cut off mid-stream. It must be called before the first byte of the body is written.
+func extendWriteDeadline(c *gin.Context) {
+	timeout := defaultStreamWriteTimeout
+	if v := os.Getenv("SERVER_STREAM_WRITE_TIMEOUT"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed >= 0 {
+			timeout = parsed
+		}
+	}
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(deadline); err != nil {
+		middleware.RequestLogger(c).Warnf("Failed to extend the write deadline, the export stays bounded by SERVER_WRITE_TIMEOUT: %v", err)
+	}
+}
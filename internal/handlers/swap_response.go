@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"time"
+
+	"marketcontrol/internal/models"
+)
+
+// datetimeLayout is the format of the datetime fields derived from on-chain unix timestamps
+const datetimeLayout = "2006-01-02 15:04:05"
+
+// formatTimestamp renders a unix timestamp (seconds) with datetimeLayout, "" when it is unset
+func formatTimestamp(ts uint) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(int64(ts), 0).Format(datetimeLayout)
+}
+
+// toResponses maps the []T returned by findWithFields / filterRecords to response DTOs with convert.
+// Column maps selected through ?fields= are projections already and are returned unchanged.
+func toResponses[T, R any](rows interface{}, convert func(T) R) interface{} {
+	typed, ok := rows.([]T)
+	if !ok || typed == nil {
+		return rows
+	}
+	out := make([]R, len(typed))
+	for i, row := range typed {
+		out[i] = convert(row)
+	}
+	return out
+}
+
+// PumpfuninternalSwapResponse is the API representation of a pumpfuninternal_swap row
+type PumpfuninternalSwapResponse struct {
+	ID                    uint      `json:"id"`
+	Slot                  uint      `json:"slot"`
+	Timestamp             uint      `json:"timestamp"`
+	Datetime              string    `json:"datetime"`
+	Signature             string    `json:"signature"`
+	Address               string    `json:"address"`
+	Mint                  string    `json:"mint"`
+	BondingCurvePda       string    `json:"bonding_curve_pda"`
+	TraderMintChange      float64   `json:"trader_mint_change"`
+	TraderSolChange       float64   `json:"trader_sol_change"`
+	PoolMintChange        float64   `json:"pool_mint_change"`
+	PoolSolChange         float64   `json:"pool_sol_change"`
+	FeeRecipientSolChange float64   `json:"fee_recipient_sol_change"`
+	CreatorSolChange      float64   `json:"creator_sol_change"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+func newPumpfuninternalSwapResponse(s models.PumpfuninternalSwap) PumpfuninternalSwapResponse {
+	return PumpfuninternalSwapResponse{
+		ID:                    s.ID,
+		Slot:                  s.Slot,
+		Timestamp:             s.Timestamp,
+		Datetime:              formatTimestamp(s.Timestamp),
+		Signature:             s.Signature,
+		Address:               s.Address,
+		Mint:                  s.Mint,
+		BondingCurvePda:       s.BondingCurvePda,
+		TraderMintChange:      s.TraderMintChange,
+		TraderSolChange:       s.TraderSolChange,
+		PoolMintChange:        s.PoolMintChange,
+		PoolSolChange:         s.PoolSolChange,
+		FeeRecipientSolChange: s.FeeRecipientSolChange,
+		CreatorSolChange:      s.CreatorSolChange,
+		CreatedAt:             s.CreatedAt,
+	}
+}
+
+// PoolSwapResponse is the API representation of a swap of a pool with base/quote mints
+// (raydiumpool_swap, meteoradbc_swap and meteoracpmm_swap share this layout)
+type PoolSwapResponse struct {
+	ID                uint      `json:"id"`
+	Slot              uint      `json:"slot"`
+	Timestamp         uint      `json:"timestamp"`
+	Datetime          string    `json:"datetime"`
+	PoolAddress       string    `json:"pool_address"`
+	Signature         string    `json:"signature"`
+	Fee               float64   `json:"fee"`
+	Address           string    `json:"address"`
+	BaseMint          string    `json:"base_mint"`
+	QuoteMint         string    `json:"quote_mint"`
+	TraderBaseChange  float64   `json:"trader_base_change"`
+	TraderQuoteChange float64   `json:"trader_quote_change"`
+	TraderSolChange   float64   `json:"trader_sol_change"`
+	PoolBaseChange    float64   `json:"pool_base_change"`
+	PoolQuoteChange   float64   `json:"pool_quote_change"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func newMeteoradbcSwapResponse(s models.MeteoradbcSwap) PoolSwapResponse {
+	return PoolSwapResponse{
+		ID:                s.ID,
+		Slot:              s.Slot,
+		Timestamp:         s.Timestamp,
+		Datetime:          formatTimestamp(s.Timestamp),
+		PoolAddress:       s.PoolAddress,
+		Signature:         s.Signature,
+		Fee:               s.Fee,
+		Address:           s.Address,
+		BaseMint:          s.BaseMint,
+		QuoteMint:         s.QuoteMint,
+		TraderBaseChange:  s.TraderBaseChange,
+		TraderQuoteChange: s.TraderQuoteChange,
+		TraderSolChange:   s.TraderSolChange,
+		PoolBaseChange:    s.PoolBaseChange,
+		PoolQuoteChange:   s.PoolQuoteChange,
+		CreatedAt:         s.CreatedAt,
+	}
+}
+
+func newRaydiumPoolSwapResponse(s models.RaydiumPoolSwap) PoolSwapResponse {
+	return PoolSwapResponse{
+		ID:                s.ID,
+		Slot:              s.Slot,
+		Timestamp:         s.Timestamp,
+		Datetime:          formatTimestamp(s.Timestamp),
+		PoolAddress:       s.PoolAddress,
+		Signature:         s.Signature,
+		Fee:               s.Fee,
+		Address:           s.Address,
+		BaseMint:          s.BaseMint,
+		QuoteMint:         s.QuoteMint,
+		TraderBaseChange:  s.TraderBaseChange,
+		TraderQuoteChange: s.TraderQuoteChange,
+		TraderSolChange:   s.TraderSolChange,
+		PoolBaseChange:    s.PoolBaseChange,
+		PoolQuoteChange:   s.PoolQuoteChange,
+		CreatedAt:         s.CreatedAt,
+	}
+}
+
+func newMeteoracpmmSwapResponse(s models.MeteoracpmmSwap) PoolSwapResponse {
+	return PoolSwapResponse{
+		ID:                s.ID,
+		Slot:              s.Slot,
+		Timestamp:         s.Timestamp,
+		Datetime:          formatTimestamp(s.Timestamp),
+		PoolAddress:       s.PoolAddress,
+		Signature:         s.Signature,
+		Fee:               s.Fee,
+		Address:           s.Address,
+		BaseMint:          s.BaseMint,
+		QuoteMint:         s.QuoteMint,
+		TraderBaseChange:  s.TraderBaseChange,
+		TraderQuoteChange: s.TraderQuoteChange,
+		TraderSolChange:   s.TraderSolChange,
+		PoolBaseChange:    s.PoolBaseChange,
+		PoolQuoteChange:   s.PoolQuoteChange,
+		CreatedAt:         s.CreatedAt,
+	}
+}
+
+// PumpfunAmmPoolSwapResponse is the API representation of a pumpfunammpool_swap row
+type PumpfunAmmPoolSwapResponse struct {
+	PoolSwapResponse
+	PoolBaseAccountSolChange  float64 `json:"pool_base_account_sol_change"`
+	PoolQuoteAccountSolChange float64 `json:"pool_quote_account_sol_change"`
+}
+
+func newPumpfunAmmPoolSwapResponse(s models.PumpfunAmmPoolSwap) PumpfunAmmPoolSwapResponse {
+	return PumpfunAmmPoolSwapResponse{
+		PoolSwapResponse: PoolSwapResponse{
+			ID:                s.ID,
+			Slot:              s.Slot,
+			Timestamp:         s.Timestamp,
+			Datetime:          formatTimestamp(s.Timestamp),
+			PoolAddress:       s.PoolAddress,
+			Signature:         s.Signature,
+			Fee:               s.Fee,
+			Address:           s.Address,
+			BaseMint:          s.BaseMint,
+			QuoteMint:         s.QuoteMint,
+			TraderBaseChange:  s.TraderBaseChange,
+			TraderQuoteChange: s.TraderQuoteChange,
+			TraderSolChange:   s.TraderSolChange,
+			PoolBaseChange:    s.PoolBaseChange,
+			PoolQuoteChange:   s.PoolQuoteChange,
+			CreatedAt:         s.CreatedAt,
+		},
+		PoolBaseAccountSolChange:  s.PoolBaseAccountSolChange,
+		PoolQuoteAccountSolChange: s.PoolQuoteAccountSolChange,
+	}
+}
+
+// PumpfuninternalHolderResponse is the API representation of a pumpfuninternal_holder row
+type PumpfuninternalHolderResponse struct {
+	ID              uint      `json:"id"`
+	Address         string    `json:"address"`
+	HolderType      string    `json:"holder_type"`
+	BondingCurvePda string    `json:"bonding_curve_pda"`
+	Mint            string    `json:"mint"`
+	LastSlot        uint      `json:"last_slot"`
+	StartSlot       uint      `json:"start_slot"`
+	LastTimestamp   uint      `json:"last_timestamp"`
+	LastDatetime    string    `json:"last_datetime"`
+	StartTimestamp  uint      `json:"start_timestamp"`
+	StartDatetime   string    `json:"start_datetime"`
+	EndSignature    string    `json:"end_signature"`
+	StartSignature  string    `json:"start_signature"`
+	MintChange      float64   `json:"mint_change"`
+	SolChange       float64   `json:"sol_change"`
+	MintVolume      float64   `json:"mint_volume"`
+	SolVolume       float64   `json:"sol_volume"`
+	TxCount         uint      `json:"tx_count"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func newPumpfuninternalHolderResponse(h models.PumpfuninternalHolder) PumpfuninternalHolderResponse {
+	return PumpfuninternalHolderResponse{
+		ID:              h.ID,
+		Address:         h.Address,
+		HolderType:      h.HolderType,
+		BondingCurvePda: h.BondingCurvePda,
+		Mint:            h.Mint,
+		LastSlot:        h.LastSlot,
+		StartSlot:       h.StartSlot,
+		LastTimestamp:   h.LastTimestamp,
+		LastDatetime:    formatTimestamp(h.LastTimestamp),
+		StartTimestamp:  h.StartTimestamp,
+		StartDatetime:   formatTimestamp(h.StartTimestamp),
+		EndSignature:    h.EndSignature,
+		StartSignature:  h.StartSignature,
+		MintChange:      h.MintChange,
+		SolChange:       h.SolChange,
+		MintVolume:      h.MintVolume,
+		SolVolume:       h.SolVolume,
+		TxCount:         h.TxCount,
+		CreatedAt:       h.CreatedAt,
+	}
+}
+
+// PoolHolderResponse is the API representation of a holder of a pool with base/quote mints
+// (raydiumpool_holder, meteoradbc_holder and meteoracpmm_holder share this layout)
+type PoolHolderResponse struct {
+	ID             uint      `json:"id"`
+	Address        string    `json:"address"`
+	HolderType     string    `json:"holder_type"`
+	PoolAddress    string    `json:"pool_address"`
+	BaseMint       string    `json:"base_mint"`
+	QuoteMint      string    `json:"quote_mint"`
+	LastSlot       uint      `json:"last_slot"`
+	StartSlot      uint      `json:"start_slot"`
+	LastTimestamp  uint      `json:"last_timestamp"`
+	LastDatetime   string    `json:"last_datetime"`
+	StartTimestamp uint      `json:"start_timestamp"`
+	StartDatetime  string    `json:"start_datetime"`
+	EndSignature   string    `json:"end_signature"`
+	StartSignature string    `json:"start_signature"`
+	BaseChange     float64   `json:"base_change"`
+	QuoteChange    float64   `json:"quote_change"`
+	SolChange      float64   `json:"sol_change"`
+	TxCount        uint      `json:"tx_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func newMeteoradbcHolderResponse(h models.MeteoradbcHolder) PoolHolderResponse {
+	return PoolHolderResponse{
+		ID:             h.ID,
+		Address:        h.Address,
+		HolderType:     h.HolderType,
+		PoolAddress:    h.PoolAddress,
+		BaseMint:       h.BaseMint,
+		QuoteMint:      h.QuoteMint,
+		LastSlot:       h.LastSlot,
+		StartSlot:      h.StartSlot,
+		LastTimestamp:  h.LastTimestamp,
+		LastDatetime:   formatTimestamp(h.LastTimestamp),
+		StartTimestamp: h.StartTimestamp,
+		StartDatetime:  formatTimestamp(h.StartTimestamp),
+		EndSignature:   h.EndSignature,
+		StartSignature: h.StartSignature,
+		BaseChange:     h.BaseChange,
+		QuoteChange:    h.QuoteChange,
+		SolChange:      h.SolChange,
+		TxCount:        h.TxCount,
+		CreatedAt:      h.CreatedAt,
+	}
+}
+
+func newRaydiumPoolHolderResponse(h models.RaydiumPoolHolder) PoolHolderResponse {
+	return PoolHolderResponse{
+		ID:             h.ID,
+		Address:        h.Address,
+		HolderType:     h.HolderType,
+		PoolAddress:    h.PoolAddress,
+		BaseMint:       h.BaseMint,
+		QuoteMint:      h.QuoteMint,
+		LastSlot:       h.LastSlot,
+		StartSlot:      h.StartSlot,
+		LastTimestamp:  h.LastTimestamp,
+		LastDatetime:   formatTimestamp(h.LastTimestamp),
+		StartTimestamp: h.StartTimestamp,
+		StartDatetime:  formatTimestamp(h.StartTimestamp),
+		EndSignature:   h.EndSignature,
+		StartSignature: h.StartSignature,
+		BaseChange:     h.BaseChange,
+		QuoteChange:    h.QuoteChange,
+		SolChange:      h.SolChange,
+		TxCount:        h.TxCount,
+		CreatedAt:      h.CreatedAt,
+	}
+}
+
+func newMeteoracpmmHolderResponse(h models.MeteoracpmmHolder) PoolHolderResponse {
+	return PoolHolderResponse{
+		ID:             h.ID,
+		Address:        h.Address,
+		HolderType:     h.HolderType,
+		PoolAddress:    h.PoolAddress,
+		BaseMint:       h.BaseMint,
+		QuoteMint:      h.QuoteMint,
+		LastSlot:       h.LastSlot,
+		StartSlot:      h.StartSlot,
+		LastTimestamp:  h.LastTimestamp,
+		LastDatetime:   formatTimestamp(h.LastTimestamp),
+		StartTimestamp: h.StartTimestamp,
+		StartDatetime:  formatTimestamp(h.StartTimestamp),
+		EndSignature:   h.EndSignature,
+		StartSignature: h.StartSignature,
+		BaseChange:     h.BaseChange,
+		QuoteChange:    h.QuoteChange,
+		SolChange:      h.SolChange,
+		TxCount:        h.TxCount,
+		CreatedAt:      h.CreatedAt,
+	}
+}
+
+// PumpfunAmmpoolHolderResponse is the API representation of a pumpfunammpool_holder row
+type PumpfunAmmpoolHolderResponse struct {
+	PoolHolderResponse
+	TraderBaseVolume  float64 `json:"trader_base_volume"`
+	TraderQuoteVolume float64 `json:"trader_quote_volume"`
+	TraderSolVolume   float64 `json:"trader_sol_volume"`
+}
+
+func newPumpfunAmmpoolHolderResponse(h models.PumpfunAmmpoolHolder) PumpfunAmmpoolHolderResponse {
+	return PumpfunAmmpoolHolderResponse{
+		PoolHolderResponse: PoolHolderResponse{
+			ID:             h.ID,
+			Address:        h.Address,
+			HolderType:     h.HolderType,
+			PoolAddress:    h.PoolAddress,
+			BaseMint:       h.BaseMint,
+			QuoteMint:      h.QuoteMint,
+			LastSlot:       h.LastSlot,
+			StartSlot:      h.StartSlot,
+			LastTimestamp:  h.LastTimestamp,
+			LastDatetime:   formatTimestamp(h.LastTimestamp),
+			StartTimestamp: h.StartTimestamp,
+			StartDatetime:  formatTimestamp(h.StartTimestamp),
+			EndSignature:   h.EndSignature,
+			StartSignature: h.StartSignature,
+			BaseChange:     h.BaseChange,
+			QuoteChange:    h.QuoteChange,
+			SolChange:      h.SolChange,
+			TxCount:        h.TxCount,
+			CreatedAt:      h.CreatedAt,
+		},
+		TraderBaseVolume:  h.TraderBaseVolume,
+		TraderQuoteVolume: h.TraderQuoteVolume,
+		TraderSolVolume:   h.TraderSolVolume,
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -81,17 +80,9 @@ func ListSystemLogs(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": logs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       logs,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -173,7 +164,7 @@ func CleanSystemLog(c *gin.Context) {
 		return
 	}
 
-	cutoff := time.Now().AddDate(0, 0, -req.Before)
+	cutoff := handlerClock.Now().AddDate(0, 0, -req.Before)
 	result := dbconfig.DB.Where("created_at < ?", cutoff).Delete(&models.SystemLog{})
 	if result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
@@ -246,17 +237,9 @@ func ListSystemLogsByProject(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": logs,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       logs,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -339,17 +322,9 @@ func ListSystemParams(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": params,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       params,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -622,17 +597,9 @@ func ListSystemCommands(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": commands,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       commands,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -711,17 +678,9 @@ func ListSystemCommandsByProject(c *gin.Context) {
 		return
 	}
 
-	totalPages := (total + int64(pageSize) - 1) / int64(pageSize)
 	c.JSON(http.StatusOK, gin.H{
-		"data": commands,
-		"pagination": gin.H{
-			"current_page": page,
-			"page_size":    pageSize,
-			"total_pages":  totalPages,
-			"total_count":  total,
-			"has_next":     page < int(totalPages),
-			"has_prev":     page > 1,
-		},
+		"data":       commands,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tableCountConcurrency = 4
+	tableCountTimeout     = 30 * time.Second
+)
+
+// countedTable is one table reported by GetTableCounts. Platforms is empty for tables shared by every platform.
+type countedTable struct {
+	Kind      string // swap/holder/config/transaction/balance_change
+	Platforms []models.PoolPlatform
+	Model     interface{}
+}
+
+var raydiumPlatforms = []models.PoolPlatform{
+	models.PoolPlatformRaydium, models.PoolPlatformRaydiumLaunchpad, models.PoolPlatformRaydiumCpmm,
+}
+
+// countedTables lists the tables GetTableCounts reports, grouped by platform
+var countedTables = []countedTable{
+	{"config", []models.PoolPlatform{models.PoolPlatformPumpfunInternal}, &models.PumpfuninternalConfig{}},
+	{"swap", []models.PoolPlatform{models.PoolPlatformPumpfunInternal}, &models.PumpfuninternalSwap{}},
+	{"holder", []models.PoolPlatform{models.PoolPlatformPumpfunInternal}, &models.PumpfuninternalHolder{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformPumpfunAmm}, &models.PumpfunAmmPoolConfig{}},
+	{"swap", []models.PoolPlatform{models.PoolPlatformPumpfunAmm}, &models.PumpfunAmmPoolSwap{}},
+	{"holder", []models.PoolPlatform{models.PoolPlatformPumpfunAmm}, &models.PumpfunAmmpoolHolder{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformRaydium}, &models.PoolConfig{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformRaydiumLaunchpad}, &models.RaydiumLaunchpadPoolConfig{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformRaydiumCpmm}, &models.RaydiumCpmmPoolConfig{}},
+	// raydium 各平台共用 swap/holder 表
+	{"swap", raydiumPlatforms, &models.RaydiumPoolSwap{}},
+	{"holder", raydiumPlatforms, &models.RaydiumPoolHolder{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformMeteoraDbc}, &models.MeteoradbcConfig{}},
+	{"swap", []models.PoolPlatform{models.PoolPlatformMeteoraDbc}, &models.MeteoradbcSwap{}},
+	{"holder", []models.PoolPlatform{models.PoolPlatformMeteoraDbc}, &models.MeteoradbcHolder{}},
+	{"config", []models.PoolPlatform{models.PoolPlatformMeteoraCpmm}, &models.MeteoracpmmConfig{}},
+	{"swap", []models.PoolPlatform{models.PoolPlatformMeteoraCpmm}, &models.MeteoracpmmSwap{}},
+	{"holder", []models.PoolPlatform{models.PoolPlatformMeteoraCpmm}, &models.MeteoracpmmHolder{}},
+	{"swap", nil, &models.SwapTransaction{}},
+	{"config", nil, &models.TransactionsMonitorConfig{}},
+	{"transaction", nil, &models.AddressTransaction{}},
+	{"balance_change", nil, &models.AddressBalanceChange{}},
+}
+
+// TableCount is the row count of one table; Error is set (and Count is 0) when the count failed or timed out
+type TableCount struct {
+	Table      string                `json:"table"`
+	Kind       string                `json:"kind"`
+	Platforms  []models.PoolPlatform `json:"platforms"`
+	Count      int64                 `json:"count"`
+	Error      string                `json:"error,omitempty"`
+	DurationMs int64                 `json:"duration_ms"`
+}
+
+// GetTableCounts returns COUNT(*) of every swap, holder, config, transaction and balance-change table,
+// counted concurrently, plus the counts grouped by platform and kind. Tables shared by several platforms
+// (the raydium swap/holder tables) are listed under each of them. A failing table does not fail the request.
+func GetTableCounts(c *gin.Context) {
+	start := time.Now()
+	counts := make([]TableCount, len(countedTables))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, tableCountConcurrency)
+	for i, table := range countedTables {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, table countedTable) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ctx, cancel := context.WithTimeout(c.Request.Context(), tableCountTimeout)
+			defer cancel()
+
+			tableStart := time.Now()
+			result := TableCount{Kind: table.Kind, Platforms: table.Platforms}
+			if result.Platforms == nil {
+				result.Platforms = []models.PoolPlatform{}
+			}
+			stmt := dbconfig.DB.WithContext(ctx).Model(table.Model)
+			if err := stmt.Statement.Parse(table.Model); err == nil {
+				result.Table = stmt.Statement.Table
+			}
+			if err := stmt.Count(&result.Count).Error; err != nil {
+				result.Count = 0
+				result.Error = err.Error()
+			}
+			result.DurationMs = time.Since(tableStart).Milliseconds()
+			counts[i] = result
+		}(i, table)
+	}
+	wg.Wait()
+
+	byPlatform := make(map[models.PoolPlatform]map[string]int64)
+	var totalRows int64
+	failed := 0
+	for _, count := range counts {
+		if count.Error != "" {
+			failed++
+			continue
+		}
+		totalRows += count.Count
+		for _, platform := range count.Platforms {
+			if byPlatform[platform] == nil {
+				byPlatform[platform] = make(map[string]int64)
+			}
+			byPlatform[platform][count.Kind] += count.Count
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tables":      counts,
+		"by_platform": byPlatform,
+		"total_rows":  totalRows,
+		"failed":      failed,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+}
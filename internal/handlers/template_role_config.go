@@ -43,7 +43,7 @@ func GetTemplateRoleConfig(c *gin.Context) {
 // ListTemplateRoleConfigs 列出所有模板角色配置
 func ListTemplateRoleConfigs(c *gin.Context) {
 	var configs []models.TemplateRoleConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
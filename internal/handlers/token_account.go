@@ -19,7 +19,7 @@ type TokenAccountRequest struct {
 // ListTokenAccounts returns a list of all token accounts
 func ListTokenAccounts(c *gin.Context) {
 	var accounts []models.TokenAccount
-	if err := dbconfig.DB.Find(&accounts).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&accounts).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -80,7 +79,7 @@ func validateAndSanitizeURL(uri string) string {
 // ListTokenConfigs returns a list of all token configs
 func ListTokenConfigs(c *gin.Context) {
 	var tokens []models.TokenConfig
-	if err := dbconfig.DB.Find(&tokens).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&tokens).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -149,21 +148,9 @@ func ListTokenConfigsSlice(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
-	hasNext := page < totalPages
-	hasPrev := page > 1
-
 	c.JSON(http.StatusOK, gin.H{
-		"data": tokens,
-		"pagination": gin.H{
-			"page":        page,
-			"page_size":   pageSize,
-			"total_count": totalCount,
-			"total_pages": totalPages,
-			"has_next":    hasNext,
-			"has_prev":    hasPrev,
-		},
+		"data":       tokens,
+		"pagination": paginationMeta(page, pageSize, totalCount),
 		"sorting": gin.H{
 			"order_field": orderField,
 			"order_type":  orderType,
@@ -401,18 +388,20 @@ func DeleteTokenConfig(c *gin.Context) {
 	}
 
 	// Check if any project is using this token
-	var projectCount int64
+	var projectIDs []uint
 	if err := dbconfig.DB.Model(&models.ProjectConfig{}).
 		Where("token_id = ?", id).
-		Count(&projectCount).Error; err != nil {
+		Order("id asc").
+		Pluck("id", &projectIDs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check project dependencies"})
 		return
 	}
 
-	if projectCount > 0 {
+	if len(projectIDs) > 0 {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":         "Cannot delete token: there are projects using this token",
-			"project_count": projectCount,
+			"project_count": len(projectIDs),
+			"project_ids":   projectIDs,
 		})
 		return
 	}
@@ -471,21 +460,9 @@ func ListTokenMetadata(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
-	hasNext := page < totalPages
-	hasPrev := page > 1
-
 	c.JSON(http.StatusOK, gin.H{
-		"data": metadata,
-		"pagination": gin.H{
-			"page":        page,
-			"page_size":   pageSize,
-			"total_count": totalCount,
-			"total_pages": totalPages,
-			"has_next":    hasNext,
-			"has_prev":    hasPrev,
-		},
+		"data":       metadata,
+		"pagination": paginationMeta(page, pageSize, totalCount),
 	})
 }
 
@@ -528,21 +505,9 @@ func GetFavorites(c *gin.Context) {
 		return
 	}
 
-	// Calculate pagination info
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
-	hasNext := page < totalPages
-	hasPrev := page > 1
-
 	c.JSON(http.StatusOK, gin.H{
-		"data": metadata,
-		"pagination": gin.H{
-			"page":        page,
-			"page_size":   pageSize,
-			"total_count": totalCount,
-			"total_pages": totalPages,
-			"has_next":    hasNext,
-			"has_prev":    hasPrev,
-		},
+		"data":       metadata,
+		"pagination": paginationMeta(page, pageSize, totalCount),
 	})
 }
 
@@ -1,18 +1,36 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"os"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"marketcontrol/internal/middleware"
 	"marketcontrol/internal/models"
 	dbconfig "marketcontrol/pkg/config"
+	mcsolana "marketcontrol/pkg/solana"
+	"marketcontrol/pkg/solana/meteora"
+	"marketcontrol/pkg/utils"
 
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TransactionsMonitorConfigRequest represents the request body for creating/updating a transactions monitor config
@@ -97,8 +115,9 @@ type PumpfuninternalHolderRequest struct {
 
 // DeleteTransactionsMonitorConfigWithDataRequest represents the request body for deleting a config with related data
 type DeleteTransactionsMonitorConfigWithDataRequest struct {
-	PoolPlatform string `json:"pool_platform" binding:"required"`
-	Address      string `json:"address" binding:"required"`
+	PoolPlatform models.PoolPlatform `json:"pool_platform" binding:"required"`
+	Address      string              `json:"address" binding:"required"`
+	ChunkSize    int                 `json:"chunk_size"` // signatures per DELETE (default: 500, max: 900)
 }
 
 // PumpfunAmmPoolSwapRequest represents the request body for creating/updating a swap record
@@ -277,7 +296,7 @@ type SwapTransactionRequest struct {
 // ListTransactionsMonitorConfigs returns a list of all transactions monitor configs
 func ListTransactionsMonitorConfigs(c *gin.Context) {
 	var configs []models.TransactionsMonitorConfig
-	if err := dbconfig.DB.Find(&configs).Error; err != nil {
+	if err := dbconfig.DB.Order("id desc").Find(&configs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -329,7 +348,9 @@ func CreateTransactionsMonitorConfig(c *gin.Context) {
 	c.JSON(http.StatusCreated, config)
 }
 
-// UpdateTransactionsMonitorConfig updates an existing transactions monitor config
+// UpdateTransactionsMonitorConfig updates an existing transactions monitor config. The cursor fields
+// (last_slot, last_signature, last_timestamp, tx_count) are only written when last_slot is ahead of the stored
+// one, so an update racing the worker can't move the cursor back.
 func UpdateTransactionsMonitorConfig(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -349,6 +370,7 @@ func UpdateTransactionsMonitorConfig(c *gin.Context) {
 		return
 	}
 
+	wasEnabled := config.Enabled
 	config.Address = request.Address
 	config.Enabled = request.Enabled
 	config.LastSlot = request.LastSlot
@@ -361,38 +383,1184 @@ func UpdateTransactionsMonitorConfig(c *gin.Context) {
 	config.LastExecution = request.LastExecution
 	config.Retry = request.Retry
 
-	if err := dbconfig.DB.Save(&config).Error; err != nil {
+	err = dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&config).Select("*").Omit(monitorCursorColumns...).Updates(&config).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.TransactionsMonitorConfig{}).
+			Where("id = ? AND last_slot < ?", config.ID, request.LastSlot).
+			UpdateColumns(map[string]interface{}{
+				"last_slot":      request.LastSlot,
+				"last_signature": request.LastSignature,
+				"last_timestamp": request.LastTimestamp,
+				"tx_count":       request.TxCount,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.First(&config, config.ID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Enabled 切换时通知 worker 开始/停止监控
+	if wasEnabled != config.Enabled {
+		notifyMonitorToggle(middleware.RequestLogger(c), config.Address, config.Enabled)
+	}
+	c.JSON(http.StatusOK, config)
+}
+
+// notifyMonitorToggle publishes the start/stop monitoring message for an Enabled flip in the background,
+// like ControlPoolMonitor; the config change itself is already saved
+func notifyMonitorToggle(logger *logrus.Entry, address string, enabled bool) {
+	go func() {
+		if err := publishMonitorToggle(address, enabled); err != nil {
+			logger.Warnf("Failed to publish monitoring toggle (enabled=%v) for %s: %v", enabled, address, err)
+			return
+		}
+		logger.Infof("Published monitoring toggle (enabled=%v) for %s", enabled, address)
+	}()
+}
+
+// monitorPoolTables maps a platform to the pool config model whose pool_address is the monitored address
+var monitorPoolTables = map[models.PoolPlatform]interface{}{
+	models.PoolPlatformRaydium:          &models.PoolConfig{},
+	models.PoolPlatformPumpfunAmm:       &models.PumpfunAmmPoolConfig{},
+	models.PoolPlatformRaydiumLaunchpad: &models.RaydiumLaunchpadPoolConfig{},
+	models.PoolPlatformRaydiumCpmm:      &models.RaydiumCpmmPoolConfig{},
+	models.PoolPlatformMeteoraDbc:       &models.MeteoradbcConfig{},
+	models.PoolPlatformMeteoraCpmm:      &models.MeteoracpmmConfig{},
+}
+
+// monitorPlatformScope limits a transactions_monitor_config query to the addresses of one platform's pools
+func monitorPlatformScope(platform models.PoolPlatform) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if platform == models.PoolPlatformPumpfunInternal {
+			// pumpfun 内盘按 associated_bonding_curve 或 bonding_curve_pda 监控
+			return db.Where("(address IN (?) OR address IN (?))",
+				dbconfig.DB.Model(&models.PumpfuninternalConfig{}).Select("associated_bonding_curve"),
+				dbconfig.DB.Model(&models.PumpfuninternalConfig{}).Select("bonding_curve_pda"))
+		}
+		return db.Where("address IN (?)", dbconfig.DB.Model(monitorPoolTables[platform]).Select("pool_address"))
+	}
+}
+
+// UnconfiguredPoolCandidate is an address seen in address_transaction that matches no pool config
+type UnconfiguredPoolCandidate struct {
+	Address   string `json:"address"`
+	TxCount   int64  `json:"tx_count"`
+	FirstSlot uint   `json:"first_slot"`
+	LastSlot  uint   `json:"last_slot"`
+	LastSeen  uint   `json:"last_seen"` // unix seconds of the latest transaction
+	Monitored bool   `json:"monitored"` // a transactions_monitor_config exists for the address
+}
+
+// UnconfiguredMintCandidate is a mint seen in address_balance_change that is neither the base nor the quote
+// mint of any configured pool
+type UnconfiguredMintCandidate struct {
+	Mint         string `json:"mint"`
+	ChangeCount  int64  `json:"change_count"`
+	AddressCount int64  `json:"address_count"`
+	LastSeen     uint   `json:"last_seen"`
+}
+
+// nonNullColumn selects column of model without NULLs, for use in "x NOT IN (?)": a single NULL in the
+// subquery makes NOT IN unknown for every row
+func nonNullColumn(model interface{}, column string) *gorm.DB {
+	return dbconfig.DB.Model(model).Select(column).Where(column + " IS NOT NULL")
+}
+
+// ListUnconfiguredPools turns ingested transactions into an onboarding queue: the distinct addresses of
+// address_transaction without a pool config of any platform (role and project extra addresses excluded), and the
+// distinct mints of address_balance_change that no pool config trades (SOL excluded), busiest first.
+// Query parameters: kind (pools or mints, default both), min_count (default 1), limit (default 100, max 1000)
+func ListUnconfiguredPools(c *gin.Context) {
+	kind := c.Query("kind")
+	if kind != "" && kind != "pools" && kind != "mints" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be pools or mints"})
+		return
+	}
+	minCount := 1
+	if v := c.Query("min_count"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_count"})
+			return
+		}
+		minCount = parsed
+	}
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 1000 {
+			limit = parsed
+		}
+	}
+
+	response := gin.H{}
+	if kind != "mints" {
+		query := dbconfig.DB.Model(&models.AddressTransaction{}).
+			Select("address, COUNT(*) AS tx_count, MIN(slot) AS first_slot, MAX(slot) AS last_slot, MAX(timestamp) AS last_seen, "+
+				"EXISTS (SELECT 1 FROM transactions_monitor_config tmc WHERE tmc.address = address_transaction.address) AS monitored").
+			Where("address NOT IN (?)", nonNullColumn(&models.PumpfuninternalConfig{}, "associated_bonding_curve")).
+			Where("address NOT IN (?)", nonNullColumn(&models.PumpfuninternalConfig{}, "bonding_curve_pda")).
+			Where("address NOT IN (?)", nonNullColumn(&models.RoleAddress{}, "address")).
+			Where("address NOT IN (?)", nonNullColumn(&models.ProjectExtraAddress{}, "address"))
+		for _, platform := range models.AllPoolPlatforms() {
+			if model, ok := monitorPoolTables[platform]; ok {
+				query = query.Where("address NOT IN (?)", nonNullColumn(model, "pool_address"))
+			}
+		}
+		pools := []UnconfiguredPoolCandidate{}
+		if err := query.Group("address").Having("COUNT(*) >= ?", minCount).
+			Order("tx_count desc, address asc").Limit(limit).Scan(&pools).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response["pools"] = pools
+	}
+
+	if kind != "pools" {
+		query := dbconfig.DB.Model(&models.AddressBalanceChange{}).
+			Select("mint, COUNT(*) AS change_count, COUNT(DISTINCT address) AS address_count, MAX(timestamp) AS last_seen").
+			Where(mcsolana.CanonicalMintSQL("mint")+" <> ?", mcsolana.NativeSolMint).
+			Where("mint NOT IN (?)", nonNullColumn(&models.PumpfuninternalConfig{}, "mint"))
+		for _, model := range []interface{}{&models.PumpfunAmmPoolConfig{}, &models.RaydiumLaunchpadPoolConfig{},
+			&models.RaydiumCpmmPoolConfig{}, &models.MeteoradbcConfig{}, &models.MeteoracpmmConfig{}} {
+			query = query.Where("mint NOT IN (?)", nonNullColumn(model, "base_mint")).
+				Where("mint NOT IN (?)", nonNullColumn(model, "quote_mint"))
+		}
+		mints := []UnconfiguredMintCandidate{}
+		if err := query.Group("mint").Having("COUNT(*) >= ?", minCount).
+			Order("change_count desc, mint asc").Limit(limit).Scan(&mints).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response["mints"] = mints
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetMonitorConfigsEnabledRequest represents the request body for SetMonitorConfigsEnabled
+type SetMonitorConfigsEnabledRequest struct {
+	Enabled  *bool               `json:"enabled" binding:"required"`
+	Platform models.PoolPlatform `json:"platform"` // 为空时作用于全部监控配置
+}
+
+// MonitorToggleFailure records an address whose start/stop monitoring message could not be published
+type MonitorToggleFailure struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
+
+// SetMonitorConfigsEnabled enables or disables every transactions monitor config (optionally only those of
+// one platform's pools) in one UPDATE, then publishes start/stop monitoring for each config that flipped.
+// Addresses of platforms the worker does not monitor are counted as skipped; publish failures are listed
+// but do not roll the update back.
+func SetMonitorConfigsEnabled(c *gin.Context) {
+	var request SetMonitorConfigsEnabledRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Platform != "" && !request.Platform.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("platform must be one of %v", models.PoolPlatformNames())})
+		return
+	}
+	enabled := *request.Enabled
+
+	scope := func(db *gorm.DB) *gorm.DB { return db }
+	if request.Platform != "" {
+		scope = monitorPlatformScope(request.Platform)
+	}
+
+	// 一条 UPDATE 完成切换，RETURNING 拿到实际发生变化的地址用于发送消息
+	var flipped []models.TransactionsMonitorConfig
+	if err := dbconfig.DB.Model(&flipped).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "address"}}}).
+		Scopes(scope).
+		Where("enabled <> ?", enabled).
+		Update("enabled", enabled).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var matched int64
+	if err := dbconfig.DB.Model(&models.TransactionsMonitorConfig{}).Scopes(scope).Count(&matched).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		published int
+		skipped   int
+	)
+	failures := []MonitorToggleFailure{}
+	sem := make(chan struct{}, republishMonitoringConcurrency)
+	for _, config := range flipped {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(address string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := publishMonitorToggle(address, enabled)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				published++
+			case errors.Is(err, errPlatformNotMonitored):
+				skipped++
+			default:
+				failures = append(failures, MonitorToggleFailure{Address: address, Error: err.Error()})
+			}
+		}(config.Address)
+	}
+	wg.Wait()
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Address < failures[j].Address })
+
+	middleware.RequestLogger(c).Infof("Set enabled=%v on %d monitor configs (platform=%q), published %d, failed %d",
+		enabled, len(flipped), request.Platform, published, len(failures))
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":         enabled,
+		"platform":        request.Platform,
+		"matched_count":   matched,
+		"updated_count":   len(flipped),
+		"published_count": published,
+		"skipped_count":   skipped,
+		"failed_count":    len(failures),
+		"failures":        failures,
+	})
+}
+
+// checkUniqueKeyIndex writes an error response and returns false when the upsert unique index of table is not
+// usable: 409 when its build failed (duplicate keys must be cleaned up first), 503 while it is still being built.
+func checkUniqueKeyIndex(c *gin.Context, table string) bool {
+	err := dbconfig.UniqueKeyIndexReady(dbconfig.DB, table)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, dbconfig.ErrUniqueIndexFailed):
+		c.JSON(http.StatusConflict, gin.H{"error": "unique index missing: " + err.Error()})
+	case errors.Is(err, dbconfig.ErrUniqueIndexBuilding):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unique index missing: " + err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+	return false
+}
+
+// UpsertTransactionsMonitorConfigRequest represents the request body for upserting a transactions monitor config
+// by address. Omitted fields keep their stored value (or the column default on insert). On update the cursor
+// fields (last_slot, last_signature, last_timestamp, tx_count) only apply when last_slot is ahead of the stored one.
+type UpsertTransactionsMonitorConfigRequest struct {
+	Address        string  `json:"address" binding:"required"`
+	Enabled        *bool   `json:"enabled"`
+	LastSlot       *uint   `json:"last_slot"`
+	StartSlot      *uint   `json:"start_slot"`
+	LastTimestamp  *uint   `json:"last_timestamp"`
+	StartTimestamp *uint   `json:"start_timestamp"`
+	LastSignature  *string `json:"last_signature"`
+	StartSignature *string `json:"start_signature"`
+	TxCount        *uint   `json:"tx_count"`
+	LastExecution  *uint   `json:"last_execution"`
+	Retry          *bool   `json:"retry"`
+}
+
+// UpsertTransactionsMonitorConfig creates the config for an address or updates the fields present in the request,
+// keyed on the unique address. Returns 201 when the config was created and 200 when it was updated.
+func UpsertTransactionsMonitorConfig(c *gin.Context) {
+	var request UpsertTransactionsMonitorConfigRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !checkUniqueKeyIndex(c, models.TransactionsMonitorConfig{}.TableName()) {
+		return
+	}
+
+	config := models.TransactionsMonitorConfig{Address: request.Address}
+	updates := []string{"updated_at"}
+	set := func(column string, present bool, apply func()) {
+		if present {
+			apply()
+			updates = append(updates, column)
+		}
+	}
+	set("enabled", request.Enabled != nil, func() { config.Enabled = *request.Enabled })
+	set("last_slot", request.LastSlot != nil, func() { config.LastSlot = *request.LastSlot })
+	set("start_slot", request.StartSlot != nil, func() { config.StartSlot = *request.StartSlot })
+	set("last_timestamp", request.LastTimestamp != nil, func() { config.LastTimestamp = *request.LastTimestamp })
+	set("start_timestamp", request.StartTimestamp != nil, func() { config.StartTimestamp = *request.StartTimestamp })
+	set("last_signature", request.LastSignature != nil, func() { config.LastSignature = *request.LastSignature })
+	set("start_signature", request.StartSignature != nil, func() { config.StartSignature = *request.StartSignature })
+	set("tx_count", request.TxCount != nil, func() { config.TxCount = *request.TxCount })
+	set("last_execution", request.LastExecution != nil, func() { config.LastExecution = *request.LastExecution })
+	set("retry", request.Retry != nil, func() { config.Retry = *request.Retry })
+
+	var (
+		existing []models.TransactionsMonitorConfig
+		saved    models.TransactionsMonitorConfig
+	)
+	err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("address = ?", request.Address).Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}},
+			DoUpdates: monitorConfigUpsertSet(updates),
+		}).Create(&config).Error; err != nil {
+			return err
+		}
+		return tx.Where("address = ?", request.Address).First(&saved).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if wasEnabled := len(existing) > 0 && existing[0].Enabled; wasEnabled != saved.Enabled {
+		notifyMonitorToggle(middleware.RequestLogger(c), saved.Address, saved.Enabled)
+	}
+
+	if len(existing) == 0 {
+		c.JSON(http.StatusCreated, saved)
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// monitorCursorColumns are only moved forward: see advanceMonitorCursor
+var monitorCursorColumns = []string{"last_slot", "last_signature", "last_timestamp", "tx_count"}
+
+// monitorConfigUpsertSet assigns columns from EXCLUDED on conflict; cursor columns only when EXCLUDED.last_slot
+// is ahead of the stored last_slot, like advanceMonitorCursor
+func monitorConfigUpsertSet(columns []string) clause.Set {
+	table := models.TransactionsMonitorConfig{}.TableName()
+	set := make(clause.Set, 0, len(columns))
+	for _, column := range columns {
+		value := gorm.Expr("EXCLUDED." + column)
+		for _, cursor := range monitorCursorColumns {
+			if column == cursor {
+				value = gorm.Expr(fmt.Sprintf("CASE WHEN EXCLUDED.last_slot > %[1]s.last_slot THEN EXCLUDED.%[2]s ELSE %[1]s.%[2]s END", table, column))
+			}
+		}
+		set = append(set, clause.Assignment{Column: clause.Column{Name: column}, Value: value})
+	}
+	return set
+}
+
+// AdvanceMonitorCursorRequest moves a monitor config's cursor to last_slot; tx_count_delta is added to tx_count
+type AdvanceMonitorCursorRequest struct {
+	LastSlot      uint   `json:"last_slot" binding:"required"`
+	LastSignature string `json:"last_signature"`
+	LastTimestamp *uint  `json:"last_timestamp"`
+	LastExecution *uint  `json:"last_execution"`
+	TxCountDelta  uint   `json:"tx_count_delta"`
+}
+
+// advanceMonitorCursor moves the cursor of config id forward with a single conditional UPDATE
+// (WHERE last_slot < new last_slot), so concurrent writers can never move it back. It reports whether
+// the cursor advanced; when it did not, the stored cursor was already at or past last_slot.
+func advanceMonitorCursor(db *gorm.DB, id uint, request AdvanceMonitorCursorRequest) (bool, error) {
+	updates := map[string]interface{}{
+		"last_slot":      request.LastSlot,
+		"last_signature": request.LastSignature,
+		"tx_count":       gorm.Expr("tx_count + ?", request.TxCountDelta),
+	}
+	if request.LastTimestamp != nil {
+		updates["last_timestamp"] = *request.LastTimestamp
+	}
+	if request.LastExecution != nil {
+		updates["last_execution"] = *request.LastExecution
+	}
+
+	result := db.Model(&models.TransactionsMonitorConfig{}).
+		Where("id = ? AND last_slot < ?", id, request.LastSlot).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AdvanceMonitorCursor moves a monitor config's cursor (last_slot, last_signature, optional last_timestamp and
+// last_execution) forward only and adds tx_count_delta to tx_count, atomically. A stale request (last_slot not
+// greater than the stored one) changes nothing and returns advanced=false with the current config.
+func AdvanceMonitorCursor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var request AdvanceMonitorCursorRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	advanced, err := advanceMonitorCursor(dbconfig.DB, uint(id), request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var config models.TransactionsMonitorConfig
+	if err := dbconfig.DB.First(&config, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "TransactionsMonitorConfig not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"advanced": advanced,
+		"data":     config,
+	})
+}
+
+// DeleteTransactionsMonitorConfig deletes a transactions monitor config
+func DeleteTransactionsMonitorConfig(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := dbconfig.DB.Delete(&models.TransactionsMonitorConfig{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
+}
+
+// MonitorConfigDetail combines a TransactionsMonitorConfig with the pool config and project it belongs to
+type MonitorConfigDetail struct {
+	Config       models.TransactionsMonitorConfig `json:"config"`
+	PoolPlatform models.PoolPlatform              `json:"pool_platform"`
+	PoolID       uint                             `json:"pool_id"`
+	Pool         interface{}                      `json:"pool"`
+	Project      *models.ProjectConfig            `json:"project"`
+}
+
+// resolvePoolByAddress finds the pool config (across all platforms) whose address matches the given address.
+// Returns an empty platform when no pool matches.
+func resolvePoolByAddress(db *gorm.DB, address string) (models.PoolPlatform, uint, interface{}, error) {
+	candidates := []struct {
+		platform models.PoolPlatform
+		query    string
+		newPool  func() interface{}
+		poolID   func(interface{}) uint
+	}{
+		{models.PoolPlatformPumpfunInternal, "associated_bonding_curve = ? OR bonding_curve_pda = ?",
+			func() interface{} { return &models.PumpfuninternalConfig{} },
+			func(p interface{}) uint { return p.(*models.PumpfuninternalConfig).ID }},
+		{models.PoolPlatformPumpfunAmm, "pool_address = ?",
+			func() interface{} { return &models.PumpfunAmmPoolConfig{} },
+			func(p interface{}) uint { return p.(*models.PumpfunAmmPoolConfig).ID }},
+		{models.PoolPlatformRaydiumLaunchpad, "pool_address = ?",
+			func() interface{} { return &models.RaydiumLaunchpadPoolConfig{} },
+			func(p interface{}) uint { return p.(*models.RaydiumLaunchpadPoolConfig).ID }},
+		{models.PoolPlatformRaydiumCpmm, "pool_address = ?",
+			func() interface{} { return &models.RaydiumCpmmPoolConfig{} },
+			func(p interface{}) uint { return p.(*models.RaydiumCpmmPoolConfig).ID }},
+		{models.PoolPlatformMeteoraDbc, "pool_address = ?",
+			func() interface{} { return &models.MeteoradbcConfig{} },
+			func(p interface{}) uint { return p.(*models.MeteoradbcConfig).ID }},
+		{models.PoolPlatformMeteoraCpmm, "pool_address = ?",
+			func() interface{} { return &models.MeteoracpmmConfig{} },
+			func(p interface{}) uint { return p.(*models.MeteoracpmmConfig).ID }},
+		{models.PoolPlatformRaydium, "pool_address = ?",
+			func() interface{} { return &models.PoolConfig{} },
+			func(p interface{}) uint { return p.(*models.PoolConfig).ID }},
+	}
+
+	for _, candidate := range candidates {
+		pool := candidate.newPool()
+		args := []interface{}{address}
+		if candidate.platform == models.PoolPlatformPumpfunInternal {
+			args = append(args, address)
+		}
+		err := db.Where(candidate.query, args...).First(pool).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", 0, nil, err
+		}
+		return candidate.platform, candidate.poolID(pool), pool, nil
+	}
+	return "", 0, nil, nil
+}
+
+// resolvePoolTablesByAddress resolves a pool address to its platform and swap/holder tables.
+// Returns an empty platform when no pool matches, and ok=false when the platform has no per-pool tables.
+func resolvePoolTablesByAddress(db *gorm.DB, address string) (models.PoolPlatform, poolDataTables, bool, error) {
+	platform, _, pool, err := resolvePoolByAddress(db, address)
+	if err != nil || platform == "" {
+		return platform, poolDataTables{}, false, err
+	}
+	tables, ok := resolvePoolDataTables(reflect.Indirect(reflect.ValueOf(pool)).Interface())
+	return platform, tables, ok, nil
+}
+
+// GetMonitorConfigDetail returns a TransactionsMonitorConfig together with its owning pool config and project
+func GetMonitorConfigDetail(c *gin.Context) {
+	var config models.TransactionsMonitorConfig
+	if err := dbconfig.DB.First(&config, c.Param("id")).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	platform, poolID, pool, err := resolvePoolByAddress(dbconfig.DB, config.Address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	detail := MonitorConfigDetail{
+		Config:       config,
+		PoolPlatform: platform,
+		PoolID:       poolID,
+		Pool:         pool,
+	}
+
+	if platform != "" {
+		var project models.ProjectConfig
+		err := dbconfig.DB.Where("pool_platform = ? AND pool_id = ?", platform, poolID).Order("id desc").First(&project).Error
+		// 迁移后的 meteora_cpmm 池子，项目仍引用原 meteora_dbc 池子
+		if errors.Is(err, gorm.ErrRecordNotFound) && platform == models.PoolPlatformMeteoraCpmm {
+			if cpmm := pool.(*models.MeteoracpmmConfig); cpmm.DbcPoolAddress != "" {
+				err = dbconfig.DB.Where("pool_platform = ? AND pool_id = (?)", models.PoolPlatformMeteoraDbc,
+					dbconfig.DB.Model(&models.MeteoradbcConfig{}).Select("id").Where("pool_address = ?", cpmm.DbcPoolAddress).Limit(1)).
+					Order("id desc").First(&project).Error
+			}
+		}
+		if err == nil {
+			detail.Project = &project
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// ValidateMonitorTargetRequest represents the request body for validating a monitoring target
+type ValidateMonitorTargetRequest struct {
+	Address  string `json:"address" binding:"required"`
+	Platform string `json:"platform"` // Optional, resolved from pool configs when empty
+}
+
+// ValidateMonitorTarget checks on-chain that an address exists and is owned by the expected program
+// for its platform, so typos are caught before the monitor is enabled.
+func ValidateMonitorTarget(c *gin.Context) {
+	var request ValidateMonitorTargetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	platform := request.Platform
+	if platform == "" {
+		resolved, _, _, err := resolvePoolByAddress(dbconfig.DB, request.Address)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if resolved == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "platform is required: address does not match any pool config"})
+			return
+		}
+		platform = resolved.String()
+	}
+
+	solanaRPC := os.Getenv("DEFAULT_SOLANA_RPC")
+	if solanaRPC == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Solana RPC endpoint not configured"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	result, err := mcsolana.ValidateMonitorTarget(ctx, rpc.New(solanaRPC), request.Address, platform)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMonitorErrors returns the monitoring error state the worker recorded for an address:
+// current consecutive error count, the last error and whether its queue was cleaned up.
+// Addresses that never failed return a zero state.
+func GetMonitorErrors(c *gin.Context) {
+	address := c.Param("address")
+
+	state := models.MonitorErrorState{Address: address}
+	if err := dbconfig.DB.Where("address = ?", address).First(&state).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// workerStatusURLsEnv lists the worker status endpoints (comma separated) queried for live monitoring state
+const workerStatusURLsEnv = "WORKER_STATUS_URLS"
+
+const workerStatusTimeout = 3 * time.Second
+
+// liveStatusNotMonitored is reported for an address no reachable worker is monitoring
+const liveStatusNotMonitored = "not_monitored"
+
+// WorkerLiveState summarises the worker status endpoints queried for a monitoring status
+type WorkerLiveState struct {
+	Available bool     `json:"available"` // at least one worker answered
+	Workers   int      `json:"workers"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// MonitoredAddressStatus is the monitor config, the recorded start errors and the live worker state of one address
+type MonitoredAddressStatus struct {
+	Address       string                            `json:"address"`
+	Platform      models.PoolPlatform               `json:"platform"`
+	MonitorConfig *models.TransactionsMonitorConfig `json:"monitor_config"`
+	ErrorState    *models.MonitorErrorState         `json:"error_state"`
+	LiveStatus    string                            `json:"live_status,omitempty"`     // connected/connecting/disconnected/not_monitored; empty when unknown
+	LastMessageAt int64                             `json:"last_message_at,omitempty"` // unix time of the last notification or pong the worker received
+	Healthy       bool                              `json:"healthy"`
+	Issues        []string                          `json:"issues"`
+}
+
+// fetchWorkerConnections merges the connections and last message times reported by every WORKER_STATUS_URLS
+// endpoint. A connected state and the latest message time win when several workers report the same address.
+func fetchWorkerConnections(ctx context.Context) (map[string]string, map[string]int64, WorkerLiveState) {
+	connections := make(map[string]string)
+	lastMessageAt := make(map[string]int64)
+	state := WorkerLiveState{}
+	raw := os.Getenv(workerStatusURLsEnv)
+	if strings.TrimSpace(raw) == "" {
+		state.Errors = append(state.Errors, workerStatusURLsEnv+" not configured")
+		return connections, lastMessageAt, state
+	}
+
+	httpClient := &http.Client{Timeout: workerStatusTimeout}
+	for _, url := range strings.Split(raw, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		var status struct {
+			Connections   map[string]string `json:"connections"`
+			LastMessageAt map[string]int64  `json:"last_message_at"`
+		}
+		err := func() error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("status code %d", resp.StatusCode)
+			}
+			return json.NewDecoder(resp.Body).Decode(&status)
+		}()
+		if err != nil {
+			state.Errors = append(state.Errors, fmt.Sprintf("%s: %v", url, err))
+			continue
+		}
+		state.Workers++
+		for address, connStatus := range status.Connections {
+			if connections[address] != meteora.StateConnected {
+				connections[address] = connStatus
+			}
+		}
+		for address, at := range status.LastMessageAt {
+			if at > lastMessageAt[address] {
+				lastMessageAt[address] = at
+			}
+		}
+	}
+	state.Available = state.Workers > 0
+	return connections, lastMessageAt, state
+}
+
+// projectMonitorAddresses returns the addresses a project's pools are monitored under:
+// the DBC/CPMM pool addresses for meteora projects, the bonding curve for pumpfun internal, the pool otherwise
+func projectMonitorAddresses(project *models.ProjectConfig) ([]models.PoolPlatform, []string, error) {
+	if project.PoolPlatform.IsMeteora() {
+		dbcCfg, cpmmCfg, err := loadMeteoraMonitorPools(*project)
+		if err != nil {
+			return nil, nil, err
+		}
+		var platforms []models.PoolPlatform
+		var addresses []string
+		if dbcCfg.PoolAddress != "" {
+			platforms = append(platforms, models.PoolPlatformMeteoraDbc)
+			addresses = append(addresses, dbcCfg.PoolAddress)
+		}
+		if cpmmCfg != nil && cpmmCfg.PoolAddress != "" {
+			platforms = append(platforms, models.PoolPlatformMeteoraCpmm)
+			addresses = append(addresses, cpmmCfg.PoolAddress)
+		}
+		return platforms, addresses, nil
+	}
+
+	resp := buildProjectConfigResp(project)
+	if resp == nil {
+		return nil, nil, nil
+	}
+	if pool, ok := resp.Pool.(models.PumpfuninternalConfig); ok {
+		return []models.PoolPlatform{project.PoolPlatform}, []string{pool.AssociatedBondingCurve}, nil
+	}
+	if tables, ok := resolvePoolDataTables(resp.Pool); ok && tables.PoolValue != "" {
+		return []models.PoolPlatform{project.PoolPlatform}, []string{tables.PoolValue}, nil
+	}
+	return nil, nil, nil
+}
+
+// GetProjectMonitoringStatus returns, for every address a project is monitored under, its
+// TransactionsMonitorConfig, the start errors the worker recorded and the live connection state reported
+// by the worker status endpoints (meteora pools only), plus an overall healthy flag.
+func GetProjectMonitoringStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var project models.ProjectConfig
+	if err := dbconfig.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	platforms, addresses, err := projectMonitorAddresses(&project)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var configs []models.TransactionsMonitorConfig
+	var errorStates []models.MonitorErrorState
+	if len(addresses) > 0 {
+		if err := dbconfig.DB.Where("address IN ?", addresses).Order("id asc").Find(&configs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err := dbconfig.DB.Where("address IN ?", addresses).Find(&errorStates).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	configByAddress := make(map[string]*models.TransactionsMonitorConfig, len(configs))
+	for i := range configs {
+		if _, ok := configByAddress[configs[i].Address]; !ok {
+			configByAddress[configs[i].Address] = &configs[i]
+		}
+	}
+	errorStateByAddress := make(map[string]*models.MonitorErrorState, len(errorStates))
+	for i := range errorStates {
+		errorStateByAddress[errorStates[i].Address] = &errorStates[i]
+	}
+
+	// 只有 meteora 池子由 worker 实时监控
+	var connections map[string]string
+	var lastMessageAt map[string]int64
+	var live WorkerLiveState
+	if project.PoolPlatform.IsMeteora() {
+		connections, lastMessageAt, live = fetchWorkerConnections(c.Request.Context())
+	}
+
+	healthy := len(addresses) > 0
+	statuses := make([]MonitoredAddressStatus, 0, len(addresses))
+	for i, address := range addresses {
+		status := MonitoredAddressStatus{
+			Address:       address,
+			Platform:      platforms[i],
+			MonitorConfig: configByAddress[address],
+			ErrorState:    errorStateByAddress[address],
+			Issues:        []string{},
+		}
+		switch {
+		case status.MonitorConfig == nil:
+			status.Issues = append(status.Issues, "no transactions monitor config")
+		case !status.MonitorConfig.Enabled:
+			status.Issues = append(status.Issues, "transactions monitor config disabled")
+		}
+		if state := status.ErrorState; state != nil {
+			if state.CleanedUp {
+				status.Issues = append(status.Issues, "dropped by the worker after repeated start failures")
+			} else if state.ErrorCount > 0 {
+				status.Issues = append(status.Issues, fmt.Sprintf("%d consecutive start failures", state.ErrorCount))
+			}
+		}
+		if live.Available {
+			status.LiveStatus = liveStatusNotMonitored
+			if connStatus, ok := connections[address]; ok {
+				status.LiveStatus = connStatus
+			}
+			status.LastMessageAt = lastMessageAt[address]
+			if status.LiveStatus != meteora.StateConnected {
+				status.Issues = append(status.Issues, "live status "+status.LiveStatus)
+			}
+		}
+		status.Healthy = len(status.Issues) == 0
+		healthy = healthy && status.Healthy
+		statuses = append(statuses, status)
+	}
+
+	response := gin.H{
+		"project_id":    project.ID,
+		"pool_platform": project.PoolPlatform,
+		"is_active":     project.IsActive,
+		"healthy":       healthy,
+		"addresses":     statuses,
+	}
+	if project.PoolPlatform.IsMeteora() {
+		response["worker"] = live
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DebugSwapParse fetches a transaction by signature and decodes it with the meteora pool monitor's parser,
+// returning the SwapTransaction (including tx_meta) it would produce. Nothing is persisted.
+// Query parameters: signature (required), pool_address (the monitored DBC/CPMM pool, used to look up the mints),
+// base_mint, quote_mint (override or replace the pool's mints)
+func DebugSwapParse(c *gin.Context) {
+	signature := c.Query("signature")
+	if _, err := solana.SignatureFromBase58(signature); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid signature"})
+		return
+	}
+
+	params := meteora.ParseSwapParams{
+		PoolAddress:          c.Query("pool_address"),
+		MeteoraDbcAuthority:  meteora.DbcAuthority(),
+		MeteoraCpmmAuthority: meteora.CpmmAuthority(),
+	}
+	if params.PoolAddress != "" {
+		var cpmmCfgs []models.MeteoracpmmConfig
+		if err := dbconfig.DB.Where("pool_address = ?", params.PoolAddress).Limit(1).Find(&cpmmCfgs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(cpmmCfgs) > 0 {
+			params.BaseTokenMint, params.QuoteTokenMint = cpmmCfgs[0].BaseMint, cpmmCfgs[0].QuoteMint
+		} else {
+			var dbcCfgs []models.MeteoradbcConfig
+			if err := dbconfig.DB.Where("pool_address = ?", params.PoolAddress).Limit(1).Find(&dbcCfgs).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if len(dbcCfgs) > 0 {
+				params.BaseTokenMint, params.QuoteTokenMint = dbcCfgs[0].BaseMint, dbcCfgs[0].QuoteMint
+			}
+		}
+	}
+	if v := c.Query("base_mint"); v != "" {
+		params.BaseTokenMint = v
+	}
+	if v := c.Query("quote_mint"); v != "" {
+		params.QuoteTokenMint = v
+	}
+	if params.BaseTokenMint == "" || params.QuoteTokenMint == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base_mint and quote_mint are required when pool_address is not a configured meteora pool"})
+		return
+	}
+
+	solanaRPC := os.Getenv("DEFAULT_SOLANA_RPC")
+	if solanaRPC == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Solana RPC endpoint not configured"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	swap, err := meteora.ParseSwapBySignature(ctx, rpc.New(solanaRPC), signature, params)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, meteora.ErrNotSwap) {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, gin.H{"error": err.Error(), "params": params})
+		return
+	}
+
+	// 监控会跳过 payer 属于 RoleAddress 的交易，一并返回便于排查“为什么没有入库”
+	var roleAddressCount int64
+	if err := dbconfig.DB.Model(&models.RoleAddress{}).Where("address = ?", swap.Payer).Count(&roleAddressCount).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, config)
+
+	c.JSON(http.StatusOK, gin.H{
+		"params":             params,
+		"swap":               swap,
+		"payer_is_role":      roleAddressCount > 0,
+		"would_be_persisted": roleAddressCount == 0,
+	})
 }
 
-// DeleteTransactionsMonitorConfig deletes a transactions monitor config
-func DeleteTransactionsMonitorConfig(c *gin.Context) {
+// EnqueueBackfillRequest is the body of EnqueueBackfill
+type EnqueueBackfillRequest struct {
+	PoolAddress string `json:"pool_address" binding:"required"`
+	StartSlot   uint64 `json:"start_slot"`
+	EndSlot     uint64 `json:"end_slot" binding:"required"`
+}
+
+// EnqueueBackfill records a queued backfill of a meteora DBC/CPMM pool over [start_slot, end_slot] and
+// publishes it to the pool monitor worker, which re-fetches the pool's transactions in that range
+func EnqueueBackfill(c *gin.Context) {
+	var request EnqueueBackfillRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.StartSlot > request.EndSlot {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_slot must not exceed end_slot"})
+		return
+	}
+
+	monitorMsg := meteora.PoolMonitorMessage{
+		Action:               meteora.BackfillAction,
+		MeteoraDbcAuthority:  meteora.DbcAuthority(),
+		MeteoraCpmmAuthority: meteora.CpmmAuthority(),
+	}
+	platform := models.PoolPlatformMeteoraDbc
+	var dbcCfg models.MeteoradbcConfig
+	var cpmmCfg models.MeteoracpmmConfig
+	if err := dbconfig.DB.Where("pool_address = ?", request.PoolAddress).First(&dbcCfg).Error; err == nil {
+		monitorMsg.MeteoradbcAddress = dbcCfg.PoolAddress
+		monitorMsg.BaseTokenMint = dbcCfg.BaseMint
+		monitorMsg.QuoteTokenMint = dbcCfg.QuoteMint
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	} else if err := dbconfig.DB.Where("pool_address = ?", request.PoolAddress).First(&cpmmCfg).Error; err == nil {
+		platform = models.PoolPlatformMeteoraCpmm
+		monitorMsg.MeteoracpmmAddress = cpmmCfg.PoolAddress
+		monitorMsg.BaseTokenMint = cpmmCfg.BaseMint
+		monitorMsg.QuoteTokenMint = cpmmCfg.QuoteMint
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No meteora DBC/CPMM pool with this address"})
+		return
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	backfill, err := meteora.CreateBackfill(request.PoolAddress, platform, request.StartSlot, request.EndSlot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	monitorMsg.BackfillID = backfill.ID
+	if err := publishMonitorMessage(monitorMsg); err != nil {
+		// 没有 worker 会处理它，直接标记失败
+		backfill.Status = models.BackfillStatusFailed
+		backfill.Error = "publish: " + err.Error()
+		dbconfig.DB.Model(backfill).Updates(map[string]interface{}{
+			"status": backfill.Status, "error": backfill.Error, "finished_at": handlerClock.Now(),
+		})
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to publish backfill", "backfill": backfill})
+		return
+	}
+
+	c.JSON(http.StatusCreated, backfill)
+}
+
+// ListBackfills returns the tracked backfills newest first
+// Query parameters: pool_address, status (optional filters), page (default: 1), page_size (default: 50, max: 500)
+func ListBackfills(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 50
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 500 {
+			pageSize = parsed
+		}
+	}
+
+	query := dbconfig.DB.Model(&models.Backfill{})
+	for _, key := range []string{"pool_address", "status"} {
+		if v := c.Query(key); v != "" {
+			query = query.Where(key+" = ?", v)
+		}
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	backfills := []models.Backfill{}
+	if err := query.Order("id desc").Offset((page - 1) * pageSize).Limit(pageSize).Find(&backfills).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       backfills,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// CancelBackfill stops a backfill. A queued backfill is cancelled right away so the worker never starts it;
+// a running one is marked cancelling and a cancel_backfill message is published for the worker running it.
+// Finished backfills return 409.
+func CancelBackfill(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
-	if err := dbconfig.DB.Delete(&models.TransactionsMonitorConfig{}, id).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var backfill models.Backfill
+	if err := dbconfig.DB.First(&backfill, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Backfill not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
+	if models.BackfillFinished(backfill.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Backfill already " + backfill.Status})
+		return
+	}
+
+	// 按当前状态条件更新，避免与 worker 的状态变更竞争
+	status := models.BackfillStatusCancelling
+	updates := map[string]interface{}{"status": status}
+	if backfill.Status == models.BackfillStatusQueued {
+		status = models.BackfillStatusCancelled
+		updates = map[string]interface{}{"status": status, "finished_at": handlerClock.Now()}
+	}
+	result := dbconfig.DB.Model(&backfill).Where("status = ?", backfill.Status).Updates(updates)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Backfill status changed, retry"})
+		return
+	}
+	backfill.Status = status
+
+	if status == models.BackfillStatusCancelling {
+		if err := publishMonitorMessage(meteora.PoolMonitorMessage{
+			Action:     meteora.CancelBackfillAction,
+			BackfillID: backfill.ID,
+		}); err != nil {
+			// 消息未发出时，运行中的 worker 也会在下次上报进度时看到 cancelling 并停止
+			middleware.RequestLogger(c).Warnf("Failed to publish cancel for backfill %d: %v", backfill.ID, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, backfill)
 }
 
 // ListAddressTransactions returns a list of all address transactions
+// Query parameters: created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListAddressTransactions(c *gin.Context) {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
 	var transactions []models.AddressTransaction
-	if err := dbconfig.DB.Find(&transactions).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, query, &transactions) {
 		return
 	}
 	c.JSON(http.StatusOK, transactions)
 }
 
+// AddressActivitySummary is the wallet-overview aggregate over address_transaction
+type AddressActivitySummary struct {
+	Address        string  `json:"address"`
+	TxCount        int64   `json:"tx_count"`
+	FeePayerCount  int64   `json:"fee_payer_count"`
+	DistinctPools  int64   `json:"distinct_pools"`
+	DistinctMints  int64   `json:"distinct_mints"`
+	FirstSlot      uint    `json:"first_slot"`
+	LastSlot       uint    `json:"last_slot"`
+	FirstTimestamp uint    `json:"first_timestamp"`
+	LastTimestamp  uint    `json:"last_timestamp"`
+	TotalFeesPaid  float64 `json:"total_fees_paid"`
+}
+
+// GetAddressActivitySummary returns tx count, distinct pools/mints, first/last activity and fees paid for an address
+// A transaction belongs to the address when it is either the monitored address or the fee payer;
+// pools are the other monitored addresses it transacted against, mints come from address_balance_change
+func GetAddressActivitySummary(c *gin.Context) {
+	address := strings.TrimSpace(c.Param("address"))
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	summary := AddressActivitySummary{Address: address}
+	if err := dbconfig.DB.Model(&models.AddressTransaction{}).
+		Select(`COUNT(*) AS tx_count,
+			COUNT(*) FILTER (WHERE fee_payer = ?) AS fee_payer_count,
+			COUNT(DISTINCT address) FILTER (WHERE address <> ?) AS distinct_pools,
+			COALESCE(MIN(slot), 0) AS first_slot,
+			COALESCE(MAX(slot), 0) AS last_slot,
+			COALESCE(MIN(timestamp), 0) AS first_timestamp,
+			COALESCE(MAX(timestamp), 0) AS last_timestamp,
+			COALESCE(SUM(fee) FILTER (WHERE fee_payer = ?), 0) AS total_fees_paid`,
+			address, address, address).
+		Where("address = ? OR fee_payer = ?", address, address).
+		Scan(&summary).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	summary.Address = address
+
+	if err := dbconfig.DB.Model(&models.AddressBalanceChange{}).
+		Where("address = ?", address).
+		Distinct("mint").
+		Count(&summary.DistinctMints).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
 // GetAddressTransaction returns a specific address transaction by ID
 func GetAddressTransaction(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -488,11 +1656,143 @@ func DeleteAddressTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
 }
 
+// PurgeOldTransactionsRequest represents the request body for purging old address transactions
+type PurgeOldTransactionsRequest struct {
+	BeforeTimestamp uint `json:"before_timestamp"` // unix seconds, takes precedence over retention_days
+	RetentionDays   int  `json:"retention_days"`
+	BatchSize       int  `json:"batch_size"`
+	Confirm         bool `json:"confirm"`
+}
+
+const (
+	// minPurgeRetentionDays 防止误删近期数据的最小保留天数
+	minPurgeRetentionDays = 7
+	defaultPurgeBatchSize = 1000
+	maxPurgeBatchSize     = 10000
+)
+
+// PurgeOldTransactions deletes AddressTransaction rows older than the cutoff together with their
+// AddressBalanceChange rows (matched by signature), then any remaining balance changes older than the cutoff.
+// Each batch is deleted in its own transaction so a long purge doesn't hold locks on the whole table.
+func PurgeOldTransactions(c *gin.Context) {
+	var request PurgeOldTransactionsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !request.Confirm {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true to purge data"})
+		return
+	}
+
+	var cutoff uint
+	switch {
+	case request.BeforeTimestamp > 0:
+		cutoff = request.BeforeTimestamp
+	case request.RetentionDays > 0:
+		cutoff = uint(handlerClock.Now().AddDate(0, 0, -request.RetentionDays).Unix())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "before_timestamp or retention_days is required"})
+		return
+	}
+
+	latestAllowed := uint(handlerClock.Now().AddDate(0, 0, -minPurgeRetentionDays).Unix())
+	if cutoff > latestAllowed {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":          "cutoff is too recent",
+			"min_age_days":   minPurgeRetentionDays,
+			"latest_allowed": latestAllowed,
+		})
+		return
+	}
+
+	batchSize := request.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+	if batchSize > maxPurgeBatchSize {
+		batchSize = maxPurgeBatchSize
+	}
+
+	var transactionsDeleted, balanceChangesDeleted int64
+	for {
+		var batch []models.AddressTransaction
+		if err := dbconfig.DB.Select("id", "signature").
+			Where("timestamp < ?", cutoff).
+			Order("id asc").
+			Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "transactions_deleted": transactionsDeleted, "balance_changes_deleted": balanceChangesDeleted})
+			return
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		ids := make([]uint, len(batch))
+		signatures := make([]string, len(batch))
+		for i, tx := range batch {
+			ids[i] = tx.ID
+			signatures[i] = tx.Signature
+		}
+
+		err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+			result := tx.Where("signature IN ?", signatures).Delete(&models.AddressBalanceChange{})
+			if result.Error != nil {
+				return result.Error
+			}
+			balanceChangesDeleted += result.RowsAffected
+
+			result = tx.Where("id IN ?", ids).Delete(&models.AddressTransaction{})
+			if result.Error != nil {
+				return result.Error
+			}
+			transactionsDeleted += result.RowsAffected
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "transactions_deleted": transactionsDeleted, "balance_changes_deleted": balanceChangesDeleted})
+			return
+		}
+	}
+
+	// 清理没有对应交易记录、但同样早于截止时间的余额变化
+	for {
+		subQuery := dbconfig.DB.Model(&models.AddressBalanceChange{}).
+			Select("id").
+			Where("timestamp < ?", cutoff).
+			Limit(batchSize)
+		result := dbconfig.DB.Where("id IN (?)", subQuery).Delete(&models.AddressBalanceChange{})
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error(), "transactions_deleted": transactionsDeleted, "balance_changes_deleted": balanceChangesDeleted})
+			return
+		}
+		balanceChangesDeleted += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	logrus.Infof("Purged %d address transactions and %d balance changes older than %d", transactionsDeleted, balanceChangesDeleted, cutoff)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":                 "Purge completed",
+		"before_timestamp":        cutoff,
+		"transactions_deleted":    transactionsDeleted,
+		"balance_changes_deleted": balanceChangesDeleted,
+	})
+}
+
 // ListAddressBalanceChanges returns a list of all address balance changes
+// Query parameters: created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListAddressBalanceChanges(c *gin.Context) {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
 	var changes []models.AddressBalanceChange
-	if err := dbconfig.DB.Find(&changes).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !findBounded(c, query, &changes) {
 		return
 	}
 	c.JSON(http.StatusOK, changes)
@@ -565,72 +1865,262 @@ func UpdateAddressBalanceChange(c *gin.Context) {
 	change.Mint = request.Mint
 	change.AmountChange = request.AmountChange
 
-	if err := dbconfig.DB.Save(&change).Error; err != nil {
+	if err := dbconfig.DB.Save(&change).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, change)
+}
+
+// DeleteAddressBalanceChange deletes an address balance change
+func DeleteAddressBalanceChange(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := dbconfig.DB.Delete(&models.AddressBalanceChange{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
+}
+
+// FilterListAddressBalanceChanges returns a filtered list of address balance changes
+func FilterListAddressBalanceChanges(c *gin.Context) {
+	var request FilterAddressBalanceChangeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 验证至少有一个过滤参数
+	if request.Signature == "" && request.Address == "" && request.Mint == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter (signature, address, or mint) is required"})
+		return
+	}
+
+	// 构建查询
+	query := dbconfig.DB.Model(&models.AddressBalanceChange{}).Order("id desc")
+
+	if request.Signature != "" {
+		query = query.Where("signature = ?", request.Signature)
+	}
+	if request.Address != "" {
+		query = query.Where("address = ?", request.Address)
+	}
+	if request.Mint != "" {
+		query = query.Where("mint = ?", request.Mint)
+	}
+
+	var changes []models.AddressBalanceChange
+	if !findBounded(c, query, &changes) {
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// BalanceChangeSeriesPoint is the net balance change of one mint within one slot
+type BalanceChangeSeriesPoint struct {
+	Slot        uint    `json:"slot"`
+	Timestamp   uint    `json:"timestamp"`
+	Mint        string  `json:"mint"`
+	NetChange   float64 `json:"net_change"`
+	ChangeCount int64   `json:"change_count"`
+}
+
+// GetBalanceChangeTimeSeries aggregates AddressBalanceChange rows of a pool's swap transactions
+// by (slot, mint), returning the net amount_change per slot in slot order.
+// Query params: mint, start_slot, end_slot, start_time, end_time (unix seconds), limit.
+func GetBalanceChangeTimeSeries(c *gin.Context) {
+	poolAddress := c.Param("pool_address")
+
+	bounds := map[string]uint64{}
+	for _, key := range []string{"start_slot", "end_slot", "start_time", "end_time"} {
+		if v := c.Query(key); v != "" {
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + key})
+				return
+			}
+			bounds[key] = parsed
+		}
+	}
+
+	limit := 1000
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 10000 {
+			limit = parsed
+		}
+	}
+
+	platform, tables, ok, err := resolvePoolTablesByAddress(dbconfig.DB, poolAddress)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, change)
-}
-
-// DeleteAddressBalanceChange deletes an address balance change
-func DeleteAddressBalanceChange(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+	if platform == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pool not found"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported pool platform", "pool_platform": platform})
 		return
 	}
 
-	if err := dbconfig.DB.Delete(&models.AddressBalanceChange{}, id).Error; err != nil {
+	// 只统计该池子 swap 交易中产生的余额变动
+	signatures := dbconfig.DB.Model(tables.SwapModel).
+		Select("signature").
+		Where(tables.PoolColumn+" = ?", tables.PoolValue)
+
+	query := dbconfig.DB.Model(&models.AddressBalanceChange{}).
+		Select("slot, MIN(timestamp) AS timestamp, mint, SUM(amount_change) AS net_change, COUNT(*) AS change_count").
+		Where("signature IN (?)", signatures)
+	if mint := c.Query("mint"); mint != "" {
+		query = query.Where("mint = ?", mint)
+	}
+	if v, ok := bounds["start_slot"]; ok {
+		query = query.Where("slot >= ?", v)
+	}
+	if v, ok := bounds["end_slot"]; ok {
+		query = query.Where("slot <= ?", v)
+	}
+	if v, ok := bounds["start_time"]; ok {
+		query = query.Where("timestamp >= ?", v)
+	}
+	if v, ok := bounds["end_time"]; ok {
+		query = query.Where("timestamp <= ?", v)
+	}
+
+	var series []BalanceChangeSeriesPoint
+	if err := query.Group("slot, mint").Order("slot asc, mint asc").Limit(limit).Scan(&series).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_address":  poolAddress,
+		"pool_platform": platform,
+		"count":         len(series),
+		"truncated":     len(series) == limit,
+		"data":          series,
+	})
 }
 
-// FilterListAddressBalanceChanges returns a filtered list of address balance changes
-func FilterListAddressBalanceChanges(c *gin.Context) {
-	var request FilterAddressBalanceChangeRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
+// RunningBalanceEntry is one balance change of an address with the balance after it
+type RunningBalanceEntry struct {
+	ID             uint    `json:"id"`
+	Slot           uint    `json:"slot"`
+	Timestamp      uint    `json:"timestamp"`
+	Signature      string  `json:"signature"`
+	AmountChange   float64 `json:"amount_change"`
+	RunningBalance float64 `json:"running_balance"`
+}
 
-	// 验证至少有一个过滤参数
-	if request.Signature == "" && request.Address == "" && request.Mint == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter (signature, address, or mint) is required"})
+// GetAddressRunningBalance returns the balance changes of an address for one mint as a ledger: each change with
+// the cumulative balance after it (starting_balance + SUM(amount_change) OVER (ORDER BY slot, id)). The window
+// always runs over the full history, so filtering by slot does not reset the running balance. SOL mints ("sol"
+// and WSOL) are treated as one asset.
+// Query parameters: mint (required), starting_balance (default 0), start_slot, end_slot (inclusive),
+// start_time, end_time (unix seconds), page (default: 1), page_size (default: 50, max: 500)
+func GetAddressRunningBalance(c *gin.Context) {
+	address := c.Param("address")
+	mint := c.Query("mint")
+	if mint == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint is required"})
+		return
+	}
+
+	startingBalance := 0.0
+	if v := c.Query("starting_balance"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid starting_balance"})
+			return
+		}
+		startingBalance = parsed
+	}
+	bounds, ok := parseSwapWindow(c)
+	if !ok {
 		return
 	}
 
-	// 构建查询
-	query := dbconfig.DB.Model(&models.AddressBalanceChange{})
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 50
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 500 {
+			pageSize = parsed
+		}
+	}
 
-	if request.Signature != "" {
-		query = query.Where("signature = ?", request.Signature)
+	changes := dbconfig.DB.Model(&models.AddressBalanceChange{}).Where("address = ?", address)
+	if mcsolana.IsSolMint(mint) {
+		changes = changes.Where(mcsolana.CanonicalMintSQL("mint")+" = ?", mcsolana.NativeSolMint)
+	} else {
+		changes = changes.Where("mint = ?", mint)
 	}
-	if request.Address != "" {
-		query = query.Where("address = ?", request.Address)
+	ledger := changes.Select("id, slot, timestamp, signature, amount_change, "+
+		"? + SUM(amount_change) OVER (ORDER BY slot, id ROWS UNBOUNDED PRECEDING) AS running_balance", startingBalance)
+	query := applySwapWindow(dbconfig.DB.Table("(?) AS l", ledger), bounds)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	if request.Mint != "" {
-		query = query.Where("mint = ?", request.Mint)
+	entries := []RunningBalanceEntry{}
+	if err := query.Order("slot ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	var changes []models.AddressBalanceChange
-	if err := query.Find(&changes).Error; err != nil {
+	var netChange float64
+	if err := changes.Session(&gorm.Session{}).Select("COALESCE(SUM(amount_change), 0)").Scan(&netChange).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, changes)
+	c.JSON(http.StatusOK, gin.H{
+		"address":          address,
+		"mint":             mint,
+		"starting_balance": startingBalance,
+		"closing_balance":  startingBalance + netChange,
+		"total":            total,
+		"page":             page,
+		"page_size":        pageSize,
+		"data":             entries,
+		"pagination":       paginationMeta(page, pageSize, total),
+	})
 }
 
 // ListPumpfuninternalSwaps returns a list of all swap records
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListPumpfuninternalSwaps(c *gin.Context) {
-	var swaps []models.PumpfuninternalSwap
-	if err := dbconfig.DB.Find(&swaps).Error; err != nil {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	swaps, ok := findWithFields[models.PumpfuninternalSwap](c, query)
+	if !ok {
+		return
+	}
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfuninternalSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // GetPumpfuninternalSwap returns a specific swap record by ID
@@ -646,7 +2136,7 @@ func GetPumpfuninternalSwap(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newPumpfuninternalSwapResponse(swap))
 }
 
 // CreatePumpfuninternalSwap creates a new swap record
@@ -656,6 +2146,10 @@ func CreatePumpfuninternalSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap := models.PumpfuninternalSwap{
 		Slot:                  request.Slot,
@@ -676,7 +2170,7 @@ func CreatePumpfuninternalSwap(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, swap)
+	c.JSON(http.StatusCreated, newPumpfuninternalSwapResponse(swap))
 }
 
 // UpdatePumpfuninternalSwap updates an existing swap record
@@ -692,6 +2186,10 @@ func UpdatePumpfuninternalSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	var swap models.PumpfuninternalSwap
 	if err := dbconfig.DB.First(&swap, id).Error; err != nil {
@@ -716,7 +2214,7 @@ func UpdatePumpfuninternalSwap(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newPumpfuninternalSwapResponse(swap))
 }
 
 // DeletePumpfuninternalSwap deletes a swap record
@@ -734,56 +2232,215 @@ func DeletePumpfuninternalSwap(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
 }
 
+// defaultSignatureDeleteChunkSize is the number of signatures per DELETE ... WHERE signature IN statement;
+// maxSignatureDeleteChunkSize keeps a statement below SQLite's 999 bind parameter limit
+const (
+	defaultSignatureDeleteChunkSize = 500
+	maxSignatureDeleteChunkSize     = 900
+)
+
+// deleteBySignatures deletes the rows of model whose signature is in signatures, chunkSize signatures per
+// statement so large slot ranges stay below the database's bind parameter limit. A failed chunk is logged
+// and the remaining chunks are still deleted; the first error is returned with the total rows deleted.
+func deleteBySignatures(model interface{}, signatures []string, chunkSize int) (int64, error) {
+	var deleted int64
+	var firstErr error
+	for start := 0; start < len(signatures); start += chunkSize {
+		end := start + chunkSize
+		if end > len(signatures) {
+			end = len(signatures)
+		}
+		result := dbconfig.DB.Where("signature IN ?", signatures[start:end]).Delete(model)
+		if result.Error != nil {
+			logrus.Printf("Error deleting %T records (signatures %d-%d): %v", model, start, end, result.Error)
+			if firstErr == nil {
+				firstErr = result.Error
+			}
+			continue
+		}
+		deleted += result.RowsAffected
+	}
+	return deleted, firstErr
+}
+
+// excludeSignatures adds a "signature <> ALL" condition; the list is bound as a single array parameter
+// so any number of signatures stays below the bind parameter limit. Empty lists are ignored.
+func excludeSignatures(query *gorm.DB, signatures []string) *gorm.DB {
+	if len(signatures) == 0 {
+		return query
+	}
+	return query.Where("signature <> ALL(?)", pq.Array(signatures))
+}
+
+// SwapTokenInfo is the token metadata embedded into swap rows when with_token=true
+type SwapTokenInfo struct {
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals int    `json:"decimals"`
+	LogoURI  string `json:"logo_uri"`
+}
+
+// swapTokenMintKeys maps the JSON mint field of a swap row to the key its token info is embedded under
+var swapTokenMintKeys = map[string]string{
+	"base_mint":  "base_token",
+	"quote_mint": "quote_token",
+	"mint":       "token",
+}
+
+// embedSwapTokens returns rows unchanged unless the request has with_token=true; otherwise each row
+// is returned as an object with base_token/quote_token (or token) resolved from token_info in one query
+func embedSwapTokens(c *gin.Context, rows interface{}) (interface{}, error) {
+	if c.Query("with_token") != "true" {
+		return rows, nil
+	}
+
+	raw, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	items := []map[string]interface{}{}
+	if err := decoder.Decode(&items); err != nil {
+		return nil, err
+	}
+
+	mintSet := make(map[string]bool)
+	for _, item := range items {
+		for mintKey := range swapTokenMintKeys {
+			if mint, ok := item[mintKey].(string); ok && mint != "" {
+				mintSet[mint] = true
+			}
+		}
+	}
+
+	tokens := make(map[string]SwapTokenInfo, len(mintSet))
+	if len(mintSet) > 0 {
+		mints := make([]string, 0, len(mintSet))
+		for mint := range mintSet {
+			mints = append(mints, mint)
+		}
+		var configs []models.TokenConfig
+		if err := dbconfig.DB.Select("mint, symbol, name, decimals, logo_uri").
+			Where("mint IN ?", mints).Find(&configs).Error; err != nil {
+			return nil, err
+		}
+		for _, cfg := range configs {
+			tokens[cfg.Mint] = SwapTokenInfo{
+				Symbol:   cfg.Symbol,
+				Name:     cfg.Name,
+				Decimals: cfg.Decimals,
+				LogoURI:  cfg.LogoURI,
+			}
+		}
+	}
+
+	for _, item := range items {
+		for mintKey, tokenKey := range swapTokenMintKeys {
+			mint, ok := item[mintKey].(string)
+			if !ok {
+				continue
+			}
+			if info, found := tokens[mint]; found {
+				item[tokenKey] = info
+			} else {
+				item[tokenKey] = nil
+			}
+		}
+	}
+	return items, nil
+}
+
+// poolSwapFilterRequest is the request body shared by the Filter...Swaps handlers of pool based platforms
+type poolSwapFilterRequest struct {
+	PoolAddress       string   `json:"pool_address"`
+	Signature         string   `json:"signature"`
+	Address           string   `json:"address"`
+	BaseMint          string   `json:"base_mint"`
+	QuoteMint         string   `json:"quote_mint"`
+	ExcludeSignatures []string `json:"exclude_signatures"`
+}
+
+func (r poolSwapFilterRequest) filter() recordFilter {
+	return recordFilter{
+		"pool_address": r.PoolAddress,
+		"signature":    r.Signature,
+		"address":      r.Address,
+		"base_mint":    r.BaseMint,
+		"quote_mint":   r.QuoteMint,
+	}
+}
+
+func (r poolSwapFilterRequest) excludeScope(query *gorm.DB) *gorm.DB {
+	return excludeSignatures(query, r.ExcludeSignatures)
+}
+
+// poolHolderFilterRequest is the request body shared by the Filter...Holders handlers of pool based platforms
+type poolHolderFilterRequest struct {
+	Address     string `json:"address"`
+	HolderType  string `json:"holder_type"`
+	PoolAddress string `json:"pool_address"`
+	BaseMint    string `json:"base_mint"`
+	QuoteMint   string `json:"quote_mint"`
+}
+
+func (r poolHolderFilterRequest) filter() recordFilter {
+	return recordFilter{
+		"address":      r.Address,
+		"holder_type":  r.HolderType,
+		"pool_address": r.PoolAddress,
+		"base_mint":    r.BaseMint,
+		"quote_mint":   r.QuoteMint,
+	}
+}
+
 // FilterPumpfuninternalSwaps returns a filtered list of swap records
 func FilterPumpfuninternalSwaps(c *gin.Context) {
 	var request struct {
-		Signature       string `json:"signature"`
-		Address         string `json:"address"`
-		Mint            string `json:"mint"`
-		BondingCurvePda string `json:"bonding_curve_pda"`
+		Signature         string   `json:"signature"`
+		Address           string   `json:"address"`
+		Mint              string   `json:"mint"`
+		BondingCurvePda   string   `json:"bonding_curve_pda"`
+		ExcludeSignatures []string `json:"exclude_signatures"`
 	}
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if request.Signature == "" && request.Address == "" && request.Mint == "" && request.BondingCurvePda == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter (signature, address, mint, or bonding_curve_pda) is required"})
+	swaps, ok := filterRecords[models.PumpfuninternalSwap](c, recordFilter{
+		"signature":         request.Signature,
+		"address":           request.Address,
+		"mint":              request.Mint,
+		"bonding_curve_pda": request.BondingCurvePda,
+	}, func(query *gorm.DB) *gorm.DB {
+		return excludeSignatures(query, request.ExcludeSignatures)
+	})
+	if !ok {
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.PumpfuninternalSwap{})
-
-	if request.Signature != "" {
-		query = query.Where("signature = ?", request.Signature)
-	}
-	if request.Address != "" {
-		query = query.Where("address = ?", request.Address)
-	}
-	if request.Mint != "" {
-		query = query.Where("mint = ?", request.Mint)
-	}
-	if request.BondingCurvePda != "" {
-		query = query.Where("bonding_curve_pda = ?", request.BondingCurvePda)
-	}
-
-	var swaps []models.PumpfuninternalSwap
-	if err := query.Find(&swaps).Error; err != nil {
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfuninternalSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // ListPumpfuninternalHolders returns a list of all holder records
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListPumpfuninternalHolders(c *gin.Context) {
-	var holders []models.PumpfuninternalHolder
-	if err := dbconfig.DB.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	holders, ok := findWithFields[models.PumpfuninternalHolder](c, query)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newPumpfuninternalHolderResponse))
 }
 
 // GetPumpfuninternalHolder returns a specific holder record by ID
@@ -799,7 +2456,7 @@ func GetPumpfuninternalHolder(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newPumpfuninternalHolderResponse(holder))
 }
 
 // CreatePumpfuninternalHolder creates a new holder record
@@ -809,6 +2466,10 @@ func CreatePumpfuninternalHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder := models.PumpfuninternalHolder{
 		Address:         request.Address,
@@ -832,7 +2493,7 @@ func CreatePumpfuninternalHolder(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, holder)
+	c.JSON(http.StatusCreated, newPumpfuninternalHolderResponse(holder))
 }
 
 // UpdatePumpfuninternalHolder updates an existing holder record
@@ -848,6 +2509,10 @@ func UpdatePumpfuninternalHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	var holder models.PumpfuninternalHolder
 	if err := dbconfig.DB.First(&holder, id).Error; err != nil {
@@ -875,7 +2540,7 @@ func UpdatePumpfuninternalHolder(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newPumpfuninternalHolderResponse(holder))
 }
 
 // DeletePumpfuninternalHolder deletes a holder record
@@ -907,31 +2572,21 @@ func FilterPumpfuninternalHolders(c *gin.Context) {
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.PumpfuninternalHolder{})
-
-	if request.Address != "" {
-		query = query.Where("address = ?", request.Address)
-	}
-	if request.HolderType != "" {
-		query = query.Where("holder_type = ?", request.HolderType)
-	}
-	if request.BondingCurvePda != "" {
-		query = query.Where("bonding_curve_pda = ?", request.BondingCurvePda)
-	}
-	if request.Mint != "" {
-		query = query.Where("mint = ?", request.Mint)
-	}
-
-	var holders []models.PumpfuninternalHolder
-	if err := query.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	holders, ok := filterRecords[models.PumpfuninternalHolder](c, recordFilter{
+		"address":           request.Address,
+		"holder_type":       request.HolderType,
+		"bonding_curve_pda": request.BondingCurvePda,
+		"mint":              request.Mint,
+	})
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newPumpfuninternalHolderResponse))
 }
 
 // ListPumpfuninternalSwapsByPoolID 根据池子ID获取交换记录
+// 可选 fields 参数（逗号分隔的列名）只返回指定列
 func ListPumpfuninternalSwapsByPoolID(c *gin.Context) {
 	// 获取 pool_id 参数
 	poolID, err := strconv.Atoi(c.Param("pool_id"))
@@ -971,25 +2626,42 @@ func ListPumpfuninternalSwapsByPoolID(c *gin.Context) {
 	}
 
 	// 获取交换记录
-	var swaps []models.PumpfuninternalSwap
-	if err := dbconfig.DB.Where("bonding_curve_pda = ?", pumpConfig.BondingCurvePda).
+	swaps, ok := findWithFields[models.PumpfuninternalSwap](c, dbconfig.DB.Where("bonding_curve_pda = ?", pumpConfig.BondingCurvePda).
 		Order("slot DESC").
-		Offset((page - 1) * pageSize).
-		Limit(pageSize).
-		Find(&swaps).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		Offset((page-1)*pageSize).
+		Limit(pageSize))
+	if !ok {
 		return
 	}
 
 	// 返回结果
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfuninternalSwapResponse))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      swaps,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
+// setMintProportion sets mint_proportion on a holder row; when the token's TotalSupply is not positive
+// the proportion is null and proportion_unavailable is true so clients can show "supply unknown"
+func setMintProportion(holderMap map[string]interface{}, amount, totalSupply float64) {
+	if totalSupply <= 0 {
+		holderMap["mint_proportion"] = nil
+		holderMap["proportion_unavailable"] = true
+		return
+	}
+	holderMap["mint_proportion"] = amount / totalSupply
+	holderMap["proportion_unavailable"] = false
+}
+
 // GetPumpfuninternalHolderByProjectID 根据项目ID获取持有者信息
 func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 	// 获取 project_id 参数
@@ -1028,7 +2700,7 @@ func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 	}
 
 	// 检查 PoolPlatform 是否为 pumpfun_internal
-	if projectConfig.PoolPlatform != "pumpfun_internal" {
+	if projectConfig.PoolPlatform != models.PoolPlatformPumpfunInternal {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project is not using pumpfun_internal platform"})
 		return
 	}
@@ -1088,11 +2760,7 @@ func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 			}
 
 			// 计算 mint_proportion
-			mintProportion := 0.0
-			if tokenConfig.TotalSupply > 0 {
-				mintProportion = holder.MintChange / tokenConfig.TotalSupply
-			}
-			holderMap["mint_proportion"] = mintProportion
+			setMintProportion(holderMap, holder.MintChange, tokenConfig.TotalSupply)
 
 			result[i] = holderMap
 		}
@@ -1125,10 +2793,11 @@ func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(poolHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(poolHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "project":
@@ -1155,10 +2824,11 @@ func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(projectHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(projectHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "retail_investors":
@@ -1185,10 +2855,11 @@ func GetPumpfuninternalHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(retailHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(retailHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 	}
 }
@@ -1212,6 +2883,14 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		return
 	}
 
+	chunkSize := request.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultSignatureDeleteChunkSize
+	}
+	if chunkSize > maxSignatureDeleteChunkSize {
+		chunkSize = maxSignatureDeleteChunkSize
+	}
+
 	// 2. 查找相关的 AddressTransaction
 	var transactions []models.AddressTransaction
 	if err := dbconfig.DB.Where("address = ? AND slot BETWEEN ? AND ?",
@@ -1226,8 +2905,19 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		signatures[i] = tx.Signature
 	}
 
+	// 按签名分批删除；任一批失败时保留监控配置，以便按原 slot 范围重试
+	var deletedRows int64
+	var deleteErrors []string
+	deleteSignatureRows := func(model interface{}) {
+		deleted, err := deleteBySignatures(model, signatures, chunkSize)
+		deletedRows += deleted
+		if err != nil {
+			deleteErrors = append(deleteErrors, fmt.Sprintf("%T: %v", model, err))
+		}
+	}
+
 	// 3. 如果是 pumpfun_internal 平台，处理相关数据
-	if request.PoolPlatform == "pumpfun_internal" {
+	if request.PoolPlatform == models.PoolPlatformPumpfunInternal {
 		// 查找相关的 PumpfuninternalConfig
 		var pumpConfig models.PumpfuninternalConfig
 		if err := dbconfig.DB.Where("associated_bonding_curve = ?", config.Address).First(&pumpConfig).Error; err != nil {
@@ -1245,12 +2935,8 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		}
 
 		// 删除相关的 PumpfuninternalSwap 数据
-		if len(signatures) > 0 {
-			if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.PumpfuninternalSwap{}).Error; err != nil {
-				logrus.Printf("Error deleting PumpfuninternalSwap records: %v", err)
-			}
-		}
-	} else if request.PoolPlatform == "pumpfun_amm" {
+		deleteSignatureRows(&models.PumpfuninternalSwap{})
+	} else if request.PoolPlatform == models.PoolPlatformPumpfunAmm {
 		// 查找相关的 PumpfunAmmPoolConfig
 		var pumpConfig models.PumpfunAmmPoolConfig
 		if err := dbconfig.DB.Where("pool_address = ?", config.Address).First(&pumpConfig).Error; err != nil {
@@ -1268,12 +2954,8 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		}
 
 		// 删除相关的 PumpfunAmmPoolSwap 数据
-		if len(signatures) > 0 {
-			if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.PumpfunAmmPoolSwap{}).Error; err != nil {
-				logrus.Printf("Error deleting PumpfunAmmPoolSwap records: %v", err)
-			}
-		}
-	} else if request.PoolPlatform == "raydium_launchpad" {
+		deleteSignatureRows(&models.PumpfunAmmPoolSwap{})
+	} else if request.PoolPlatform == models.PoolPlatformRaydiumLaunchpad {
 		// 查找相关的 RaydiumLaunchpadPoolConfig
 		var raydiumConfig models.RaydiumLaunchpadPoolConfig
 		if err := dbconfig.DB.Where("pool_address = ?", config.Address).First(&raydiumConfig).Error; err != nil {
@@ -1292,12 +2974,8 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		}
 
 		// 删除相关的 RaydiumPoolSwap 数据
-		if len(signatures) > 0 {
-			if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.RaydiumPoolSwap{}).Error; err != nil {
-				logrus.Printf("Error deleting RaydiumPoolSwap records: %v", err)
-			}
-		}
-	} else if request.PoolPlatform == "raydium_cpmm" {
+		deleteSignatureRows(&models.RaydiumPoolSwap{})
+	} else if request.PoolPlatform == models.PoolPlatformRaydiumCpmm {
 		// 查找相关的 RaydiumCpmmPoolConfig
 		var raydiumConfig models.RaydiumCpmmPoolConfig
 		if err := dbconfig.DB.Where("pool_address = ?", config.Address).First(&raydiumConfig).Error; err != nil {
@@ -1316,25 +2994,25 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 		}
 
 		// 删除相关的 RaydiumPoolSwap 数据
-		if len(signatures) > 0 {
-			if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.RaydiumPoolSwap{}).Error; err != nil {
-				logrus.Printf("Error deleting RaydiumPoolSwap records: %v", err)
-			}
-		}
+		deleteSignatureRows(&models.RaydiumPoolSwap{})
 	}
 
 	// 4. 删除相关的 AddressBalanceChange 数据
-	if len(signatures) > 0 {
-		if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.AddressBalanceChange{}).Error; err != nil {
-			logrus.Printf("Error deleting AddressBalanceChange records: %v", err)
-		}
+	deleteSignatureRows(&models.AddressBalanceChange{})
+
+	// 5. 删除 AddressTransaction 数据；重试时签名从 address_transaction 加载，
+	// 所以前面任一表删除失败时必须保留它，否则剩下的 swap 行无法再被找到
+	if len(deleteErrors) == 0 {
+		deleteSignatureRows(&models.AddressTransaction{})
 	}
 
-	// 5. 删除 AddressTransaction 数据
-	if len(signatures) > 0 {
-		if err := dbconfig.DB.Where("signature IN ?", signatures).Delete(&models.AddressTransaction{}).Error; err != nil {
-			logrus.Printf("Error deleting AddressTransaction records: %v", err)
-		}
+	if len(deleteErrors) > 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":        "Failed to delete some related data, config kept for retry",
+			"details":      deleteErrors,
+			"deleted_rows": deletedRows,
+		})
+		return
 	}
 
 	// 6. 最后删除 TransactionsMonitorConfig
@@ -1346,17 +3024,28 @@ func DeleteTransactionsMonitorConfigWithData(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message":              "Successfully deleted config and related data",
 		"deleted_transactions": len(signatures),
+		"deleted_rows":         deletedRows,
 	})
 }
 
 // ListPumpfunAmmPoolSwaps returns a list of all swap records
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListPumpfunAmmPoolSwaps(c *gin.Context) {
-	var swaps []models.PumpfunAmmPoolSwap
-	if err := dbconfig.DB.Find(&swaps).Error; err != nil {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	swaps, ok := findWithFields[models.PumpfunAmmPoolSwap](c, query)
+	if !ok {
+		return
+	}
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfunAmmPoolSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // GetPumpfunAmmPoolSwap returns a specific swap record by ID
@@ -1372,7 +3061,7 @@ func GetPumpfunAmmPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newPumpfunAmmPoolSwapResponse(swap))
 }
 
 // CreatePumpfunAmmPoolSwap creates a new swap record
@@ -1382,6 +3071,10 @@ func CreatePumpfunAmmPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap := models.PumpfunAmmPoolSwap{
 		Slot:                      request.Slot,
@@ -1405,7 +3098,7 @@ func CreatePumpfunAmmPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, swap)
+	c.JSON(http.StatusCreated, newPumpfunAmmPoolSwapResponse(swap))
 }
 
 // UpdatePumpfunAmmPoolSwap updates an existing swap record
@@ -1421,6 +3114,10 @@ func UpdatePumpfunAmmPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	var swap models.PumpfunAmmPoolSwap
 	if err := dbconfig.DB.First(&swap, id).Error; err != nil {
@@ -1448,7 +3145,7 @@ func UpdatePumpfunAmmPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newPumpfunAmmPoolSwapResponse(swap))
 }
 
 // DeletePumpfunAmmPoolSwap deletes a swap record
@@ -1468,58 +3165,38 @@ func DeletePumpfunAmmPoolSwap(c *gin.Context) {
 
 // FilterPumpfunAmmPoolSwaps returns a filtered list of swap records
 func FilterPumpfunAmmPoolSwaps(c *gin.Context) {
-	var request struct {
-		PoolAddress string `json:"pool_address"`
-		Signature   string `json:"signature"`
-		Address     string `json:"address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-	if err := c.ShouldBindJSON(&request); err != nil {
+	var req poolSwapFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if request.PoolAddress == "" && request.Signature == "" && request.Address == "" && request.BaseMint == "" && request.QuoteMint == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter is required"})
+	swaps, ok := filterRecords[models.PumpfunAmmPoolSwap](c, req.filter(), req.excludeScope)
+	if !ok {
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.PumpfunAmmPoolSwap{})
-
-	if request.PoolAddress != "" {
-		query = query.Where("pool_address = ?", request.PoolAddress)
-	}
-	if request.Signature != "" {
-		query = query.Where("signature = ?", request.Signature)
-	}
-	if request.Address != "" {
-		query = query.Where("address = ?", request.Address)
-	}
-	if request.BaseMint != "" {
-		query = query.Where("base_mint = ?", request.BaseMint)
-	}
-	if request.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", request.QuoteMint)
-	}
-
-	var swaps []models.PumpfunAmmPoolSwap
-	if err := query.Find(&swaps).Error; err != nil {
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfunAmmPoolSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // ListPumpfunAmmpoolHolders lists all holders
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListPumpfunAmmpoolHolders(c *gin.Context) {
-	var holders []models.PumpfunAmmpoolHolder
-	if err := dbconfig.DB.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	holders, ok := findWithFields[models.PumpfunAmmpoolHolder](c, query)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newPumpfunAmmpoolHolderResponse))
 }
 
 // GetPumpfunAmmpoolHolder gets a specific holder by ID
@@ -1529,7 +3206,7 @@ func GetPumpfunAmmpoolHolder(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newPumpfunAmmpoolHolderResponse(holder))
 }
 
 // CreatePumpfunAmmpoolHolder creates a new holder
@@ -1539,6 +3216,10 @@ func CreatePumpfunAmmpoolHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder := models.PumpfunAmmpoolHolder{
 		Address:           req.Address,
@@ -1566,7 +3247,7 @@ func CreatePumpfunAmmpoolHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, holder)
+	c.JSON(http.StatusCreated, newPumpfunAmmpoolHolderResponse(holder))
 }
 
 // UpdatePumpfunAmmpoolHolder updates an existing holder
@@ -1582,6 +3263,10 @@ func UpdatePumpfunAmmpoolHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder.Address = req.Address
 	holder.HolderType = req.HolderType
@@ -1607,7 +3292,7 @@ func UpdatePumpfunAmmpoolHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newPumpfunAmmpoolHolderResponse(holder))
 }
 
 // DeletePumpfunAmmpoolHolder deletes a holder
@@ -1626,49 +3311,24 @@ func DeletePumpfunAmmpoolHolder(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
 }
 
-// FilterPumpfunAmmpoolHolders filters holders based on criteria
-func FilterPumpfunAmmpoolHolders(c *gin.Context) {
-	var req struct {
-		Address     string `json:"address"`
-		HolderType  string `json:"holder_type"`
-		PoolAddress string `json:"pool_address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	query := dbconfig.DB.Model(&models.PumpfunAmmpoolHolder{})
-
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.HolderType != "" {
-		query = query.Where("holder_type = ?", req.HolderType)
-	}
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
+// FilterPumpfunAmmpoolHolders filters holders based on criteria
+func FilterPumpfunAmmpoolHolders(c *gin.Context) {
+	var req poolHolderFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	var holders []models.PumpfunAmmpoolHolder
-	if err := query.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	holders, ok := filterRecords[models.PumpfunAmmpoolHolder](c, req.filter())
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newPumpfunAmmpoolHolderResponse))
 }
 
 // ListPumpfunAmmPoolSwapsByPoolID 根据池子ID获取交换记录
+// 可选 fields 参数（逗号分隔的列名）只返回指定列
 func ListPumpfunAmmPoolSwapsByPoolID(c *gin.Context) {
 	// 获取 pool_id 参数
 	poolID, err := strconv.Atoi(c.Param("pool_id"))
@@ -1708,26 +3368,32 @@ func ListPumpfunAmmPoolSwapsByPoolID(c *gin.Context) {
 	}
 
 	// 获取交换记录
-	var swaps []models.PumpfunAmmPoolSwap
-	if err := dbconfig.DB.Where("pool_address = ?", pumpConfig.PoolAddress).
+	swaps, ok := findWithFields[models.PumpfunAmmPoolSwap](c, dbconfig.DB.Where("pool_address = ?", pumpConfig.PoolAddress).
 		Order("slot DESC").
-		Offset((page - 1) * pageSize).
-		Limit(pageSize).
-		Find(&swaps).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		Offset((page-1)*pageSize).
+		Limit(pageSize))
+	if !ok {
 		return
 	}
 
 	// 返回结果
+	data, err := embedSwapTokens(c, toResponses(swaps, newPumpfunAmmPoolSwapResponse))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      swaps,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
 // ListMeteoradbcSwapsByPoolID returns Meteoradbc swaps by pool ID
+// Query parameters: page, page_size, fields (optional, comma-separated columns to return)
 func ListMeteoradbcSwapsByPoolID(c *gin.Context) {
 	// 获取 pool_id 参数
 	poolID, err := strconv.Atoi(c.Param("pool_id"))
@@ -1767,22 +3433,364 @@ func ListMeteoradbcSwapsByPoolID(c *gin.Context) {
 	}
 
 	// 获取交换记录
-	var swaps []models.MeteoradbcSwap
-	if err := dbconfig.DB.Where("pool_address = ?", meteoradbcConfig.PoolAddress).
+	swaps, ok := findWithFields[models.MeteoradbcSwap](c, dbconfig.DB.Where("pool_address = ?", meteoradbcConfig.PoolAddress).
 		Order("slot DESC").
-		Offset((page - 1) * pageSize).
-		Limit(pageSize).
-		Find(&swaps).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		Offset((page-1)*pageSize).
+		Limit(pageSize))
+	if !ok {
 		return
 	}
 
 	// 返回结果
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoradbcSwapResponse))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
+}
+
+// EarlyBuyer represents a trader's first swap on a pool together with its net position
+type EarlyBuyer struct {
+	Rank             int     `json:"rank"`
+	Address          string  `json:"address"`
+	FirstSlot        uint    `json:"first_slot"`
+	FirstTimestamp   uint    `json:"first_timestamp"`
+	FirstSignature   string  `json:"first_signature"`
+	FirstBaseChange  float64 `json:"first_base_change"`
+	FirstQuoteChange float64 `json:"first_quote_change"`
+	NetBaseChange    float64 `json:"net_base_change"`
+	NetQuoteChange   float64 `json:"net_quote_change"`
+	TxCount          uint    `json:"tx_count"`
+	IsFirstBuyer     bool    `json:"is_first_buyer"`
+}
+
+// GetEarlyBuyers returns the first N distinct traders to swap a meteoradbc pool, ordered by earliest slot
+// Query parameters: limit (default: 20, max: 500), buys_only (default: true, only traders whose first swap is a buy)
+func GetEarlyBuyers(c *gin.Context) {
+	poolID, err := strconv.Atoi(c.Param("pool_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id format"})
+		return
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+	buysOnly := c.DefaultQuery("buys_only", "true") != "false"
+
+	var meteoradbcConfig models.MeteoradbcConfig
+	if err := dbconfig.DB.First(&meteoradbcConfig, poolID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pool not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 每个地址按 slot 取第一笔交易，并汇总其在该池子的净持仓
+	firstSwapFilter := ""
+	if buysOnly {
+		firstSwapFilter = "AND r.trader_base_change > 0"
+	}
+	sql := `
+		WITH ranked AS (
+			SELECT address, slot, timestamp, signature, trader_base_change, trader_quote_change,
+				ROW_NUMBER() OVER (PARTITION BY address ORDER BY slot ASC, id ASC) AS rn
+			FROM meteoradbc_swap
+			WHERE pool_address = @pool
+		), positions AS (
+			SELECT address,
+				SUM(trader_base_change) AS net_base_change,
+				SUM(trader_quote_change) AS net_quote_change,
+				COUNT(*) AS tx_count
+			FROM meteoradbc_swap
+			WHERE pool_address = @pool
+			GROUP BY address
+		)
+		SELECT r.address, r.slot AS first_slot, r.timestamp AS first_timestamp, r.signature AS first_signature,
+			r.trader_base_change AS first_base_change, r.trader_quote_change AS first_quote_change,
+			p.net_base_change, p.net_quote_change, p.tx_count
+		FROM ranked r
+		JOIN positions p ON p.address = r.address
+		WHERE r.rn = 1 ` + firstSwapFilter + `
+		ORDER BY r.slot ASC, r.address ASC
+		LIMIT @limit`
+
+	var buyers []EarlyBuyer
+	if err := dbconfig.DB.Raw(sql, map[string]interface{}{
+		"pool":  meteoradbcConfig.PoolAddress,
+		"limit": limit,
+	}).Scan(&buyers).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range buyers {
+		buyers[i].Rank = i + 1
+		buyers[i].IsFirstBuyer = meteoradbcConfig.FirstBuyer != "" && buyers[i].Address == meteoradbcConfig.FirstBuyer
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_id":      meteoradbcConfig.ID,
+		"pool_address": meteoradbcConfig.PoolAddress,
+		"first_buyer":  meteoradbcConfig.FirstBuyer,
+		"limit":        limit,
+		"buys_only":    buysOnly,
+		"count":        len(buyers),
+		"data":         buyers,
+	})
+}
+
+// HolderAtSlot is a holder position reconstructed from swaps up to a given slot
+type HolderAtSlot struct {
+	Address     string  `json:"address"`
+	BaseChange  float64 `json:"base_change"`
+	QuoteChange float64 `json:"quote_change"`
+	SolChange   float64 `json:"sol_change"`
+	StartSlot   uint    `json:"start_slot"`
+	LastSlot    uint    `json:"last_slot"`
+	TxCount     uint    `json:"tx_count"`
+}
+
+// GetHoldersAtSlot reconstructs the holder set of a meteoradbc pool as of a historical slot
+// Query parameters: slot (required), include_zero (default: false, include traders whose position is back to zero)
+func GetHoldersAtSlot(c *gin.Context) {
+	poolID, err := strconv.Atoi(c.Param("pool_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id format"})
+		return
+	}
+
+	slot, err := strconv.ParseUint(c.Query("slot"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slot query parameter is required and must be a positive integer"})
+		return
+	}
+	includeZero := c.Query("include_zero") == "true"
+
+	var meteoradbcConfig models.MeteoradbcConfig
+	if err := dbconfig.DB.First(&meteoradbcConfig, poolID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pool not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 只累计 slot <= 目标 slot 的交易，得到该时刻的持仓
+	query := dbconfig.DB.Model(&models.MeteoradbcSwap{}).
+		Select(`address,
+			SUM(trader_base_change) AS base_change,
+			SUM(trader_quote_change) AS quote_change,
+			SUM(trader_sol_change) AS sol_change,
+			MIN(slot) AS start_slot,
+			MAX(slot) AS last_slot,
+			COUNT(*) AS tx_count`).
+		Where("pool_address = ? AND slot <= ?", meteoradbcConfig.PoolAddress, slot).
+		Group("address")
+	if !includeZero {
+		query = query.Having("SUM(trader_base_change) <> 0")
+	}
+
+	var holders []HolderAtSlot
+	if err := query.Order("base_change DESC, address ASC").Scan(&holders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var totalBase float64
+	for _, holder := range holders {
+		totalBase += holder.BaseChange
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_id":           meteoradbcConfig.ID,
+		"pool_address":      meteoradbcConfig.PoolAddress,
+		"slot":              slot,
+		"holder_count":      len(holders),
+		"total_base_change": totalBase,
+		"data":              holders,
+	})
+}
+
+// SwapBalanceMismatch is a swap whose trader deltas disagree with the address_balance_change rows
+type SwapBalanceMismatch struct {
+	SwapID             uint     `json:"swap_id"`
+	Signature          string   `json:"signature"`
+	Slot               uint     `json:"slot"`
+	Timestamp          uint     `json:"timestamp"`
+	Address            string   `json:"address"`
+	TraderBaseChange   float64  `json:"trader_base_change"`
+	TraderQuoteChange  float64  `json:"trader_quote_change"`
+	BalanceBaseChange  *float64 `json:"balance_base_change"`
+	BalanceQuoteChange *float64 `json:"balance_quote_change"`
+	BaseDiff           *float64 `json:"base_diff"`
+	QuoteDiff          *float64 `json:"quote_diff"`
+}
+
+// swapReconcileStats 汇总对账结果
+type swapReconcileStats struct {
+	SwapCount       int64 `json:"swap_count"`
+	WithBalanceRows int64 `json:"with_balance_rows"`
+	MissingBalance  int64 `json:"missing_balance"`
+	Mismatched      int64 `json:"mismatched"`
+}
+
+// ReconcileSwapBalances compares trader base/quote changes of a meteoradbc pool's swaps against
+// the address_balance_change rows recorded for the same signature, address and mint
+// Query parameters: tolerance (default: 1e-6), start_slot, end_slot, include_missing (default: false,
+// also report swaps without any balance-change rows), limit (default: 500, max: 5000)
+func ReconcileSwapBalances(c *gin.Context) {
+	poolID, err := strconv.Atoi(c.Param("pool_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pool_id format"})
+		return
+	}
+
+	tolerance := 1e-6
+	if t := c.Query("tolerance"); t != "" {
+		parsed, err := strconv.ParseFloat(t, 64)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tolerance must be a non-negative number"})
+			return
+		}
+		tolerance = parsed
+	}
+
+	limit := 500
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 5000 {
+			limit = parsed
+		}
+	}
+	includeMissing := c.Query("include_missing") == "true"
+
+	var startSlot, endSlot uint64
+	if v := c.Query("start_slot"); v != "" {
+		if startSlot, err = strconv.ParseUint(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_slot"})
+			return
+		}
+	}
+	if v := c.Query("end_slot"); v != "" {
+		if endSlot, err = strconv.ParseUint(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_slot"})
+			return
+		}
+	}
+
+	var meteoradbcConfig models.MeteoradbcConfig
+	if err := dbconfig.DB.First(&meteoradbcConfig, poolID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pool not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	slotFilter := ""
+	if startSlot > 0 {
+		slotFilter += " AND slot >= @start_slot"
+	}
+	if endSlot > 0 {
+		slotFilter += " AND slot <= @end_slot"
+	}
+
+	// 按 (signature, address, mint) 汇总余额变动，再与 swap 的 trader 变动逐笔比对
+	cte := `
+		WITH swaps AS (
+			SELECT id, signature, slot, timestamp, address, base_mint, quote_mint,
+				trader_base_change, trader_quote_change
+			FROM meteoradbc_swap
+			WHERE pool_address = @pool` + slotFilter + `
+		), balances AS (
+			SELECT signature, address, mint, SUM(amount_change) AS amount
+			FROM address_balance_change
+			WHERE signature IN (SELECT signature FROM swaps)
+			GROUP BY signature, address, mint
+		), joined AS (
+			SELECT s.id, s.signature, s.slot, s.timestamp, s.address,
+				s.trader_base_change, s.trader_quote_change,
+				b.amount AS balance_base_change, q.amount AS balance_quote_change,
+				b.amount - s.trader_base_change AS base_diff,
+				q.amount - s.trader_quote_change AS quote_diff
+			FROM swaps s
+			LEFT JOIN balances b ON b.signature = s.signature AND b.address = s.address AND b.mint = s.base_mint
+			LEFT JOIN balances q ON q.signature = s.signature AND q.address = s.address AND q.mint = s.quote_mint
+		), flagged AS (
+			SELECT *,
+				(balance_base_change IS NULL AND balance_quote_change IS NULL) AS missing,
+				(ABS(COALESCE(base_diff, 0)) > @tolerance OR ABS(COALESCE(quote_diff, 0)) > @tolerance) AS mismatched
+			FROM joined
+		)`
+	params := map[string]interface{}{
+		"pool":       meteoradbcConfig.PoolAddress,
+		"start_slot": startSlot,
+		"end_slot":   endSlot,
+		"tolerance":  tolerance,
+		"limit":      limit,
+	}
+
+	var stats swapReconcileStats
+	if err := dbconfig.DB.Raw(cte+`
+		SELECT COUNT(*) AS swap_count,
+			COUNT(*) FILTER (WHERE NOT missing) AS with_balance_rows,
+			COUNT(*) FILTER (WHERE missing) AS missing_balance,
+			COUNT(*) FILTER (WHERE mismatched) AS mismatched
+		FROM flagged`, params).Scan(&stats).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	condition := "mismatched"
+	if includeMissing {
+		condition = "(mismatched OR missing)"
+	}
+	mismatches := []SwapBalanceMismatch{}
+	if err := dbconfig.DB.Raw(cte+`
+		SELECT id AS swap_id, signature, slot, timestamp, address,
+			trader_base_change, trader_quote_change,
+			balance_base_change, balance_quote_change, base_diff, quote_diff
+		FROM flagged
+		WHERE `+condition+`
+		ORDER BY slot ASC, id ASC
+		LIMIT @limit`, params).Scan(&mismatches).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	signatures := []string{}
+	seen := make(map[string]bool)
+	for _, m := range mismatches {
+		if !seen[m.Signature] {
+			seen[m.Signature] = true
+			signatures = append(signatures, m.Signature)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      swaps,
+		"pool_id":               meteoradbcConfig.ID,
+		"pool_address":          meteoradbcConfig.PoolAddress,
+		"tolerance":             tolerance,
+		"include_missing":       includeMissing,
+		"limit":                 limit,
+		"stats":                 stats,
+		"mismatched_signatures": signatures,
+		"data":                  mismatches,
 	})
 }
 
@@ -1824,7 +3832,7 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 	}
 
 	// 检查 PoolPlatform 是否为 pumpfun_amm
-	if projectConfig.PoolPlatform != "pumpfun_amm" {
+	if projectConfig.PoolPlatform != models.PoolPlatformPumpfunAmm {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project is not using pumpfun_amm platform"})
 		return
 	}
@@ -1887,11 +3895,7 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 			}
 
 			// 计算 mint_proportion
-			mintProportion := 0.0
-			if tokenConfig.TotalSupply > 0 {
-				mintProportion = holder.BaseChange / tokenConfig.TotalSupply
-			}
-			holderMap["mint_proportion"] = mintProportion
+			setMintProportion(holderMap, holder.BaseChange, tokenConfig.TotalSupply)
 
 			result[i] = holderMap
 		}
@@ -1924,10 +3928,11 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(poolHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(poolHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "project":
@@ -1954,10 +3959,11 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(projectHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(projectHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "retail_investors":
@@ -1984,10 +3990,11 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(retailHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(retailHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 	}
 }
@@ -1995,13 +4002,18 @@ func GetPumpfunAmmpoolHolderByProjectID(c *gin.Context) {
 // RaydiumPoolHolder CRUD handlers
 
 // ListRaydiumPoolHolders lists all Raydium pool holders
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListRaydiumPoolHolders(c *gin.Context) {
-	var holders []models.RaydiumPoolHolder
-	if err := dbconfig.DB.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	holders, ok := findWithFields[models.RaydiumPoolHolder](c, query)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newRaydiumPoolHolderResponse))
 }
 
 // GetRaydiumPoolHolder gets a specific Raydium pool holder by ID
@@ -2011,7 +4023,7 @@ func GetRaydiumPoolHolder(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newRaydiumPoolHolderResponse(holder))
 }
 
 // CreateRaydiumPoolHolder creates a new Raydium pool holder
@@ -2021,6 +4033,10 @@ func CreateRaydiumPoolHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder := models.RaydiumPoolHolder{
 		Address:        req.Address,
@@ -2045,7 +4061,7 @@ func CreateRaydiumPoolHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, holder)
+	c.JSON(http.StatusCreated, newRaydiumPoolHolderResponse(holder))
 }
 
 // UpdateRaydiumPoolHolder updates an existing Raydium pool holder
@@ -2061,6 +4077,10 @@ func UpdateRaydiumPoolHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder.Address = req.Address
 	holder.HolderType = req.HolderType
@@ -2083,7 +4103,7 @@ func UpdateRaydiumPoolHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newRaydiumPoolHolderResponse(holder))
 }
 
 // DeleteRaydiumPoolHolder deletes a Raydium pool holder
@@ -2104,56 +4124,40 @@ func DeleteRaydiumPoolHolder(c *gin.Context) {
 
 // FilterRaydiumPoolHolders filters Raydium pool holders based on criteria
 func FilterRaydiumPoolHolders(c *gin.Context) {
-	var req struct {
-		Address     string `json:"address"`
-		HolderType  string `json:"holder_type"`
-		PoolAddress string `json:"pool_address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolHolderFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.RaydiumPoolHolder{})
-
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.HolderType != "" {
-		query = query.Where("holder_type = ?", req.HolderType)
-	}
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
-	}
-
-	var holders []models.RaydiumPoolHolder
-	if err := query.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	holders, ok := filterRecords[models.RaydiumPoolHolder](c, req.filter())
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newRaydiumPoolHolderResponse))
 }
 
 // RaydiumPoolSwap CRUD handlers
 
 // ListRaydiumPoolSwaps lists all Raydium pool swaps
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListRaydiumPoolSwaps(c *gin.Context) {
-	var swaps []models.RaydiumPoolSwap
-	if err := dbconfig.DB.Find(&swaps).Error; err != nil {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	swaps, ok := findWithFields[models.RaydiumPoolSwap](c, query)
+	if !ok {
+		return
+	}
+	data, err := embedSwapTokens(c, toResponses(swaps, newRaydiumPoolSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // GetRaydiumPoolSwap gets a specific Raydium pool swap by ID
@@ -2163,7 +4167,7 @@ func GetRaydiumPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newRaydiumPoolSwapResponse(swap))
 }
 
 // CreateRaydiumPoolSwap creates a new Raydium pool swap
@@ -2173,6 +4177,10 @@ func CreateRaydiumPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap := models.RaydiumPoolSwap{
 		Slot:              req.Slot,
@@ -2195,7 +4203,7 @@ func CreateRaydiumPoolSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, swap)
+	c.JSON(http.StatusCreated, newRaydiumPoolSwapResponse(swap))
 }
 
 // UpdateRaydiumPoolSwap updates an existing Raydium pool swap
@@ -2211,6 +4219,10 @@ func UpdateRaydiumPoolSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap.Slot = req.Slot
 	swap.Timestamp = req.Timestamp
@@ -2231,7 +4243,7 @@ func UpdateRaydiumPoolSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newRaydiumPoolSwapResponse(swap))
 }
 
 // DeleteRaydiumPoolSwap deletes a Raydium pool swap
@@ -2252,61 +4264,40 @@ func DeleteRaydiumPoolSwap(c *gin.Context) {
 
 // FilterRaydiumPoolSwaps filters Raydium pool swaps based on criteria
 func FilterRaydiumPoolSwaps(c *gin.Context) {
-	var req struct {
-		PoolAddress string `json:"pool_address"`
-		Signature   string `json:"signature"`
-		Address     string `json:"address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolSwapFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if req.PoolAddress == "" && req.Signature == "" && req.Address == "" && req.BaseMint == "" && req.QuoteMint == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter is required"})
+	swaps, ok := filterRecords[models.RaydiumPoolSwap](c, req.filter(), req.excludeScope)
+	if !ok {
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.RaydiumPoolSwap{})
-
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.Signature != "" {
-		query = query.Where("signature = ?", req.Signature)
-	}
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
-	}
-
-	var swaps []models.RaydiumPoolSwap
-	if err := query.Find(&swaps).Error; err != nil {
+	data, err := embedSwapTokens(c, toResponses(swaps, newRaydiumPoolSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // MeteoradbcHolder CRUD handlers
 
 // ListMeteoradbcHolders lists all Meteoradbc holders
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListMeteoradbcHolders(c *gin.Context) {
-	var holders []models.MeteoradbcHolder
-	if err := dbconfig.DB.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	holders, ok := findWithFields[models.MeteoradbcHolder](c, query)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newMeteoradbcHolderResponse))
 }
 
 // GetMeteoradbcHolder gets a specific Meteoradbc holder by ID
@@ -2316,7 +4307,7 @@ func GetMeteoradbcHolder(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newMeteoradbcHolderResponse(holder))
 }
 
 // CreateMeteoradbcHolder creates a new Meteoradbc holder
@@ -2326,6 +4317,10 @@ func CreateMeteoradbcHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder := models.MeteoradbcHolder{
 		Address:        req.Address,
@@ -2350,7 +4345,7 @@ func CreateMeteoradbcHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, holder)
+	c.JSON(http.StatusCreated, newMeteoradbcHolderResponse(holder))
 }
 
 // UpdateMeteoradbcHolder updates an existing Meteoradbc holder
@@ -2366,6 +4361,10 @@ func UpdateMeteoradbcHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder.Address = req.Address
 	holder.HolderType = req.HolderType
@@ -2388,7 +4387,7 @@ func UpdateMeteoradbcHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newMeteoradbcHolderResponse(holder))
 }
 
 // DeleteMeteoradbcHolder deletes a Meteoradbc holder
@@ -2409,44 +4408,18 @@ func DeleteMeteoradbcHolder(c *gin.Context) {
 
 // FilterMeteoradbcHolders filters Meteoradbc holders based on criteria
 func FilterMeteoradbcHolders(c *gin.Context) {
-	var req struct {
-		Address     string `json:"address"`
-		HolderType  string `json:"holder_type"`
-		PoolAddress string `json:"pool_address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolHolderFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.MeteoradbcHolder{})
-
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.HolderType != "" {
-		query = query.Where("holder_type = ?", req.HolderType)
-	}
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
-	}
-
-	var holders []models.MeteoradbcHolder
-	if err := query.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	holders, ok := filterRecords[models.MeteoradbcHolder](c, req.filter())
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newMeteoradbcHolderResponse))
 }
 
 // GetMeteoradbcHolderByProjectID returns holders data for a project's Meteora DBC pool
@@ -2487,7 +4460,7 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 	}
 
 	// 检查 PoolPlatform 是否为 meteoradbc
-	if projectConfig.PoolPlatform != "meteora_dbc" {
+	if projectConfig.PoolPlatform != models.PoolPlatformMeteoraDbc {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project is not using meteoradbc platform"})
 		return
 	}
@@ -2547,11 +4520,7 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 			}
 
 			// 计算 mint_proportion
-			mintProportion := 0.0
-			if tokenConfig.TotalSupply > 0 {
-				mintProportion = holder.BaseChange / tokenConfig.TotalSupply
-			}
-			holderMap["mint_proportion"] = mintProportion
+			setMintProportion(holderMap, holder.BaseChange, tokenConfig.TotalSupply)
 
 			result[i] = holderMap
 		}
@@ -2584,10 +4553,11 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(poolHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(poolHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "project":
@@ -2614,10 +4584,11 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(projectHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(projectHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "retail_investors":
@@ -2644,10 +4615,11 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(retailHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(retailHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 	}
 }
@@ -2655,13 +4627,23 @@ func GetMeteoradbcHolderByProjectID(c *gin.Context) {
 // MeteoradbcSwap CRUD handlers
 
 // ListMeteoradbcSwaps lists all Meteoradbc swaps
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListMeteoradbcSwaps(c *gin.Context) {
-	var swaps []models.MeteoradbcSwap
-	if err := dbconfig.DB.Find(&swaps).Error; err != nil {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	swaps, ok := findWithFields[models.MeteoradbcSwap](c, query)
+	if !ok {
+		return
+	}
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoradbcSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // GetMeteoradbcSwap gets a specific Meteoradbc swap by ID
@@ -2671,7 +4653,7 @@ func GetMeteoradbcSwap(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newMeteoradbcSwapResponse(swap))
 }
 
 // CreateMeteoradbcSwap creates a new Meteoradbc swap
@@ -2681,6 +4663,10 @@ func CreateMeteoradbcSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap := models.MeteoradbcSwap{
 		Slot:              req.Slot,
@@ -2703,7 +4689,7 @@ func CreateMeteoradbcSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, swap)
+	c.JSON(http.StatusCreated, newMeteoradbcSwapResponse(swap))
 }
 
 // UpdateMeteoradbcSwap updates an existing Meteoradbc swap
@@ -2719,6 +4705,10 @@ func UpdateMeteoradbcSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap.Slot = req.Slot
 	swap.Timestamp = req.Timestamp
@@ -2739,7 +4729,7 @@ func UpdateMeteoradbcSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newMeteoradbcSwapResponse(swap))
 }
 
 // DeleteMeteoradbcSwap deletes a Meteoradbc swap
@@ -2760,59 +4750,38 @@ func DeleteMeteoradbcSwap(c *gin.Context) {
 
 // FilterMeteoradbcSwaps filters Meteoradbc swaps based on criteria
 func FilterMeteoradbcSwaps(c *gin.Context) {
-	var req struct {
-		PoolAddress string `json:"pool_address"`
-		Signature   string `json:"signature"`
-		Address     string `json:"address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolSwapFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if req.PoolAddress == "" && req.Signature == "" && req.Address == "" && req.BaseMint == "" && req.QuoteMint == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter is required"})
-		return
-	}
-
-	query := dbconfig.DB.Model(&models.MeteoradbcSwap{})
-
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.Signature != "" {
-		query = query.Where("signature = ?", req.Signature)
-	}
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
+	swaps, ok := filterRecords[models.MeteoradbcSwap](c, req.filter(), req.excludeScope)
+	if !ok {
+		return
 	}
 
-	var swaps []models.MeteoradbcSwap
-	if err := query.Find(&swaps).Error; err != nil {
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoradbcSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // ListMeteoracpmmHolders lists all Meteoracpmm holders
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListMeteoracpmmHolders(c *gin.Context) {
-	var holders []models.MeteoracpmmHolder
-	if err := dbconfig.DB.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, holders)
+	holders, ok := findWithFields[models.MeteoracpmmHolder](c, query)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, toResponses(holders, newMeteoracpmmHolderResponse))
 }
 
 // GetMeteoracpmmHolder gets a specific Meteoracpmm holder by ID
@@ -2822,7 +4791,7 @@ func GetMeteoracpmmHolder(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newMeteoracpmmHolderResponse(holder))
 }
 
 // CreateMeteoracpmmHolder creates a new Meteoracpmm holder
@@ -2832,6 +4801,10 @@ func CreateMeteoracpmmHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder := models.MeteoracpmmHolder{
 		Address:        req.Address,
@@ -2856,7 +4829,7 @@ func CreateMeteoracpmmHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, holder)
+	c.JSON(http.StatusCreated, newMeteoracpmmHolderResponse(holder))
 }
 
 // UpdateMeteoracpmmHolder updates an existing Meteoracpmm holder
@@ -2872,6 +4845,10 @@ func UpdateMeteoracpmmHolder(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	holder.Address = req.Address
 	holder.HolderType = req.HolderType
@@ -2894,7 +4871,7 @@ func UpdateMeteoracpmmHolder(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, holder)
+	c.JSON(http.StatusOK, newMeteoracpmmHolderResponse(holder))
 }
 
 // DeleteMeteoracpmmHolder deletes a Meteoracpmm holder
@@ -2915,44 +4892,18 @@ func DeleteMeteoracpmmHolder(c *gin.Context) {
 
 // FilterMeteoracpmmHolders filters Meteoracpmm holders based on criteria
 func FilterMeteoracpmmHolders(c *gin.Context) {
-	var req struct {
-		Address     string `json:"address"`
-		HolderType  string `json:"holder_type"`
-		PoolAddress string `json:"pool_address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolHolderFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	query := dbconfig.DB.Model(&models.MeteoracpmmHolder{})
-
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
-	}
-	if req.HolderType != "" {
-		query = query.Where("holder_type = ?", req.HolderType)
-	}
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
-	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
-	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
-	}
-
-	var holders []models.MeteoracpmmHolder
-	if err := query.Find(&holders).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	holders, ok := filterRecords[models.MeteoracpmmHolder](c, req.filter())
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, holders)
+	c.JSON(http.StatusOK, toResponses(holders, newMeteoracpmmHolderResponse))
 }
 
 // GetMeteoracpmmHolderByProjectID returns holders data for a project's Meteora CPMM pool
@@ -2993,7 +4944,7 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 	}
 
 	// 检查 PoolPlatform 是否为 meteoracpmm
-	if projectConfig.PoolPlatform != "meteora_cpmm" {
+	if projectConfig.PoolPlatform != models.PoolPlatformMeteoraCpmm {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Project is not using meteoracpmm platform"})
 		return
 	}
@@ -3053,11 +5004,7 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 			}
 
 			// 计算 mint_proportion
-			mintProportion := 0.0
-			if tokenConfig.TotalSupply > 0 {
-				mintProportion = holder.BaseChange / tokenConfig.TotalSupply
-			}
-			holderMap["mint_proportion"] = mintProportion
+			setMintProportion(holderMap, holder.BaseChange, tokenConfig.TotalSupply)
 
 			result[i] = holderMap
 		}
@@ -3090,10 +5037,11 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(poolHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(poolHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "project":
@@ -3120,10 +5068,11 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(projectHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(projectHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	case "retail_investors":
@@ -3150,10 +5099,11 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"total":     total,
-			"page":      page,
-			"page_size": pageSize,
-			"data":      addMintProportion(retailHolders),
+			"total":      total,
+			"page":       page,
+			"page_size":  pageSize,
+			"data":       addMintProportion(retailHolders),
+			"pagination": paginationMeta(page, pageSize, total),
 		})
 
 	default:
@@ -3162,13 +5112,23 @@ func GetMeteoracpmmHolderByProjectID(c *gin.Context) {
 }
 
 // ListMeteoracpmmSwaps lists all Meteoracpmm swaps
+// Query parameters: fields (optional, comma-separated columns to return),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListMeteoracpmmSwaps(c *gin.Context) {
-	var swaps []models.MeteoracpmmSwap
-	if err := dbconfig.DB.Find(&swaps).Error; err != nil {
+	query, ok := applyCreatedAtRange(c, dbconfig.DB.Order("id desc"))
+	if !ok {
+		return
+	}
+	swaps, ok := findWithFields[models.MeteoracpmmSwap](c, query)
+	if !ok {
+		return
+	}
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoracpmmSwapResponse))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, swaps)
+	c.JSON(http.StatusOK, data)
 }
 
 // GetMeteoracpmmSwap gets a specific Meteoracpmm swap by ID
@@ -3178,7 +5138,7 @@ func GetMeteoracpmmSwap(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
 		return
 	}
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newMeteoracpmmSwapResponse(swap))
 }
 
 // CreateMeteoracpmmSwap creates a new Meteoracpmm swap
@@ -3188,6 +5148,10 @@ func CreateMeteoracpmmSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap := models.MeteoracpmmSwap{
 		Slot:              req.Slot,
@@ -3210,7 +5174,7 @@ func CreateMeteoracpmmSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, swap)
+	c.JSON(http.StatusCreated, newMeteoracpmmSwapResponse(swap))
 }
 
 // UpdateMeteoracpmmSwap updates an existing Meteoracpmm swap
@@ -3226,6 +5190,10 @@ func UpdateMeteoracpmmSwap(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	swap.Slot = req.Slot
 	swap.Timestamp = req.Timestamp
@@ -3246,7 +5214,7 @@ func UpdateMeteoracpmmSwap(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, swap)
+	c.JSON(http.StatusOK, newMeteoracpmmSwapResponse(swap))
 }
 
 // DeleteMeteoracpmmSwap deletes a Meteoracpmm swap
@@ -3267,52 +5235,208 @@ func DeleteMeteoracpmmSwap(c *gin.Context) {
 
 // FilterMeteoracpmmSwaps filters Meteoracpmm swaps based on criteria
 func FilterMeteoracpmmSwaps(c *gin.Context) {
-	var req struct {
-		PoolAddress string `json:"pool_address"`
-		Signature   string `json:"signature"`
-		Address     string `json:"address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-	}
-
+	var req poolSwapFilterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if req.PoolAddress == "" && req.Signature == "" && req.Address == "" && req.BaseMint == "" && req.QuoteMint == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one filter parameter is required"})
+	swaps, ok := filterRecords[models.MeteoracpmmSwap](c, req.filter(), req.excludeScope)
+	if !ok {
+		return
+	}
+
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoracpmmSwapResponse))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, data)
+}
+
+// swapTableSpec describes the swap table of a platform for export queries
+type swapTableSpec struct {
+	newRow     func() interface{}
+	mintColumn string
+	poolColumn string
+}
 
-	query := dbconfig.DB.Model(&models.MeteoracpmmSwap{})
+var swapTableSpecs = map[models.PoolPlatform]swapTableSpec{
+	models.PoolPlatformPumpfunInternal:  {func() interface{} { return &models.PumpfuninternalSwap{} }, "mint", "bonding_curve_pda"},
+	models.PoolPlatformPumpfunAmm:       {func() interface{} { return &models.PumpfunAmmPoolSwap{} }, "base_mint", "pool_address"},
+	models.PoolPlatformRaydiumLaunchpad: {func() interface{} { return &models.RaydiumPoolSwap{} }, "base_mint", "pool_address"},
+	models.PoolPlatformRaydiumCpmm:      {func() interface{} { return &models.RaydiumPoolSwap{} }, "base_mint", "pool_address"},
+	models.PoolPlatformMeteoraDbc:       {func() interface{} { return &models.MeteoradbcSwap{} }, "base_mint", "pool_address"},
+	models.PoolPlatformMeteoraCpmm:      {func() interface{} { return &models.MeteoracpmmSwap{} }, "base_mint", "pool_address"},
+}
 
-	if req.PoolAddress != "" {
-		query = query.Where("pool_address = ?", req.PoolAddress)
+// StreamSwapsNDJSON streams every swap of a mint or pool as newline-delimited JSON, ordered by slot
+// Query parameters: platform (required), mint and/or pool_address (at least one), start_slot, end_slot (inclusive)
+func StreamSwapsNDJSON(c *gin.Context) {
+	platform := models.PoolPlatform(c.Query("platform"))
+	spec, ok := swapTableSpecs[platform]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported or missing platform"})
+		return
 	}
-	if req.Signature != "" {
-		query = query.Where("signature = ?", req.Signature)
+
+	mint := c.Query("mint")
+	poolAddress := c.Query("pool_address")
+	if mint == "" && poolAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mint or pool_address is required"})
+		return
 	}
-	if req.Address != "" {
-		query = query.Where("address = ?", req.Address)
+
+	query := dbconfig.DB.Model(spec.newRow())
+	if mint != "" {
+		query = query.Where(spec.mintColumn+" = ?", mint)
 	}
-	if req.BaseMint != "" {
-		query = query.Where("base_mint = ?", req.BaseMint)
+	if poolAddress != "" {
+		query = query.Where(spec.poolColumn+" = ?", poolAddress)
 	}
-	if req.QuoteMint != "" {
-		query = query.Where("quote_mint = ?", req.QuoteMint)
+	if v := c.Query("start_slot"); v != "" {
+		startSlot, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_slot"})
+			return
+		}
+		query = query.Where("slot >= ?", startSlot)
+	}
+	if v := c.Query("end_slot"); v != "" {
+		endSlot, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_slot"})
+			return
+		}
+		query = query.Where("slot <= ?", endSlot)
+	}
+
+	rows, err := query.Order("slot ASC, id ASC").Rows()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	extendWriteDeadline(c)
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	var count int
+	c.Stream(func(w io.Writer) bool {
+		if !rows.Next() {
+			return false
+		}
+		row := spec.newRow()
+		if err := dbconfig.DB.ScanRows(rows, row); err != nil {
+			logrus.Errorf("StreamSwapsNDJSON: failed to scan row after %d swaps: %v", count, err)
+			return false
+		}
+		if err := json.NewEncoder(w).Encode(row); err != nil {
+			logrus.Errorf("StreamSwapsNDJSON: failed to write row after %d swaps: %v", count, err)
+			return false
+		}
+		count++
+		return true
+	})
+
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("StreamSwapsNDJSON: row iteration stopped after %d swaps: %v", count, err)
+	}
+}
+
+// enrichedTxColumns are the address_transaction columns attached to each enriched swap
+var enrichedTxColumns = []string{"id", "address", "fee_payer", "fee", "type", "source", "slot", "timestamp"}
+
+// GetEnrichedSwaps returns a pool's swaps joined with their AddressTransaction metadata on signature
+// Query parameters: platform (required), pool_address (required), source, type, fee_payer (filter on the
+// joined transaction), page (default: 1), page_size (default: 50, max: 500)
+func GetEnrichedSwaps(c *gin.Context) {
+	platform := models.PoolPlatform(c.Query("platform"))
+	spec, ok := swapTableSpecs[platform]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported or missing platform"})
+		return
+	}
+	poolAddress := c.Query("pool_address")
+	if poolAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pool_address is required"})
+		return
+	}
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	pageSize := 50
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 500 {
+			pageSize = parsed
+		}
+	}
+
+	table := spec.newRow().(interface{ TableName() string }).TableName()
+	query := dbconfig.DB.Table(table+" AS s").
+		Joins("LEFT JOIN address_transaction AS t ON t.signature = s.signature").
+		Where("s."+spec.poolColumn+" = ?", poolAddress)
+	if v := c.Query("source"); v != "" {
+		query = query.Where("t.source = ?", v)
+	}
+	if v := c.Query("type"); v != "" {
+		query = query.Where("t.type = ?", v)
+	}
+	if v := c.Query("fee_payer"); v != "" {
+		query = query.Where("t.fee_payer = ?", v)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	var swaps []models.MeteoracpmmSwap
-	if err := query.Find(&swaps).Error; err != nil {
+	selects := []string{"s.*"}
+	for _, col := range enrichedTxColumns {
+		selects = append(selects, "t."+col+" AS tx_"+col)
+	}
+	var rows []map[string]interface{}
+	if err := query.Select(strings.Join(selects, ", ")).
+		Order("s.slot DESC, s.id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&rows).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, swaps)
+	// 拆分为 swap 与 transaction 两部分，未匹配到交易记录时 transaction 为 null
+	data := make([]gin.H, 0, len(rows))
+	for _, row := range rows {
+		var transaction map[string]interface{}
+		if row["tx_id"] != nil {
+			transaction = make(map[string]interface{}, len(enrichedTxColumns))
+			for _, col := range enrichedTxColumns {
+				transaction[col] = row["tx_"+col]
+			}
+		}
+		for _, col := range enrichedTxColumns {
+			delete(row, "tx_"+col)
+		}
+		data = append(data, gin.H{"swap": row, "transaction": transaction})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"platform":     platform,
+		"pool_address": poolAddress,
+		"data":         data,
+		"pagination":   paginationMeta(page, pageSize, total),
+	})
 }
 
 // ListMeteoracpmmSwapsByPoolID returns Meteoracpmm swaps by pool ID
+// Query parameters: page, page_size, fields (optional, comma-separated columns to return)
 func ListMeteoracpmmSwapsByPoolID(c *gin.Context) {
 	// 获取 pool_id 参数
 	poolID, err := strconv.Atoi(c.Param("pool_id"))
@@ -3352,22 +5476,27 @@ func ListMeteoracpmmSwapsByPoolID(c *gin.Context) {
 	}
 
 	// 获取交换记录
-	var swaps []models.MeteoracpmmSwap
-	if err := dbconfig.DB.Where("pool_address = ?", meteoracpmmConfig.PoolAddress).
+	swaps, ok := findWithFields[models.MeteoracpmmSwap](c, dbconfig.DB.Where("pool_address = ?", meteoracpmmConfig.PoolAddress).
 		Order("slot DESC").
-		Offset((page - 1) * pageSize).
-		Limit(pageSize).
-		Find(&swaps).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		Offset((page-1)*pageSize).
+		Limit(pageSize))
+	if !ok {
 		return
 	}
 
 	// 返回结果
+	data, err := embedSwapTokens(c, toResponses(swaps, newMeteoracpmmSwapResponse))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      swaps,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
@@ -3468,14 +5597,139 @@ func MigrateHolderByPoolAddress(c *gin.Context) {
 	})
 }
 
-// ListSwapTransactions lists all swap transactions
+// MigrateSwapsByPoolAddress 根据 poolAddress 将 MeteoradbcSwap 复制到 DammV2PoolAddress 下的 MeteoracpmmSwap，
+// 使迁移后按 CPMM 池子查询也能看到迁移前的交易。已存在相同 (signature, address) 的记录会跳过，可重复执行。
+// ?dry_run=true 时只返回统计，不写入。
+func MigrateSwapsByPoolAddress(c *gin.Context) {
+	poolAddress := c.Param("poolAddress")
+	if poolAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "poolAddress is required"})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	// 查询 MeteoradbcConfig
+	var meteoradbcConfig models.MeteoradbcConfig
+	if err := dbconfig.DB.Where("pool_address = ?", poolAddress).First(&meteoradbcConfig).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MeteoradbcConfig not found for pool address: " + poolAddress})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// 检查 DammV2PoolAddress 是否存在
+	cpmmPool := meteoradbcConfig.DammV2PoolAddress
+	if cpmmPool == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "DammV2PoolAddress is empty, cannot migrate"})
+		return
+	}
+
+	dbcTable := models.MeteoradbcSwap{}.TableName()
+	cpmmTable := models.MeteoracpmmSwap{}.TableName()
+	columns := "slot, timestamp, signature, fee, address, base_mint, quote_mint, trader_base_change, trader_quote_change, " +
+		"trader_sol_change, pool_base_change, pool_quote_change, created_at"
+	pending := fmt.Sprintf("FROM %[1]s s WHERE s.pool_address = ? AND NOT EXISTS "+
+		"(SELECT 1 FROM %[2]s c WHERE c.pool_address = ? AND c.signature = s.signature AND c.address = s.address)", dbcTable, cpmmTable)
+
+	var totalFound, migratedCount int64
+	err := dbconfig.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.MeteoradbcSwap{}).Where("pool_address = ?", poolAddress).Count(&totalFound).Error; err != nil {
+			return err
+		}
+		if dryRun {
+			return tx.Raw("SELECT COUNT(*) "+pending, poolAddress, cpmmPool).Scan(&migratedCount).Error
+		}
+		result := tx.Exec(fmt.Sprintf("INSERT INTO %s (pool_address, %s) SELECT ?, %s %s", cpmmTable, columns, columns, pending),
+			cpmmPool, poolAddress, cpmmPool)
+		migratedCount = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to migrate MeteoradbcSwap: " + err.Error()})
+		return
+	}
+
+	if !dryRun {
+		logrus.Infof("Migrated %d MeteoradbcSwap rows to MeteoracpmmSwap: pool_address=%s -> %s", migratedCount, poolAddress, cpmmPool)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Migration completed",
+		"dry_run":        dryRun,
+		"pool_address":   poolAddress,
+		"damm_v2_pool":   cpmmPool,
+		"total_found":    totalFound,
+		"migrated_count": migratedCount,
+		"skipped_count":  totalFound - migratedCount,
+	})
+}
+
+// swapTransactionListItem is a SwapTransaction without the tx_meta/tx_error blobs; the shadowing fields
+// stay empty and are omitted from the JSON
+type swapTransactionListItem struct {
+	models.SwapTransaction
+	TxMeta  string `json:"tx_meta,omitempty"`
+	TxError string `json:"tx_error,omitempty"`
+}
+
+// ListSwapTransactions lists swap transactions, newest first, as a plain array (capped by MAX_UNBOUNDED_ROWS) as it
+// always has; passing page or page_size returns that page wrapped in {total, page, page_size, data, pagination}.
+// Query parameters: page (default: 1), page_size (default: 50, max: 500),
+// include_tx_meta (true to include the tx_meta/tx_error blobs, omitted by default),
+// created_after, created_before (ingestion time, RFC3339 or unix seconds)
 func ListSwapTransactions(c *gin.Context) {
-	var transactions []models.SwapTransaction
-	if err := dbconfig.DB.Find(&transactions).Error; err != nil {
+	filtered, ok := applyCreatedAtRange(c, dbconfig.DB.Model(&models.SwapTransaction{}))
+	if !ok {
+		return
+	}
+	includeTxMeta := c.Query("include_tx_meta") == "true"
+	listed := filtered.Session(&gorm.Session{}).Order("id desc")
+	if !includeTxMeta {
+		listed = listed.Omit("tx_meta", "tx_error")
+	}
+	// 未传 page/page_size 时保持原有的数组返回
+	paginated := c.Query("page") != "" || c.Query("page_size") != ""
+
+	var total int64
+	page, pageSize := requestedPage(c)
+	if paginated {
+		if err := filtered.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	transactions := []models.SwapTransaction{}
+	if !findBounded(c, listed, &transactions) {
+		return
+	}
+
+	var rows interface{} = transactions
+	if !includeTxMeta {
+		items := make([]swapTransactionListItem, len(transactions))
+		for i, tx := range transactions {
+			items[i] = swapTransactionListItem{SwapTransaction: tx}
+		}
+		rows = items
+	}
+	data, err := embedSwapTokens(c, rows)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, transactions)
+	if !paginated {
+		c.JSON(http.StatusOK, data)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
+	})
 }
 
 // GetSwapTransaction gets a specific swap transaction by ID
@@ -3495,6 +5749,10 @@ func CreateSwapTransaction(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	transaction := models.SwapTransaction{
 		Signature:   req.Signature,
@@ -3533,6 +5791,10 @@ func UpdateSwapTransaction(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := validateAmounts(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	transaction.Signature = req.Signature
 	transaction.Slot = req.Slot
@@ -3586,7 +5848,7 @@ func CleanSwapTransaction(c *gin.Context) {
 		return
 	}
 
-	cutoff := time.Now().AddDate(0, 0, -req.Before)
+	cutoff := handlerClock.Now().AddDate(0, 0, -req.Before)
 	result := dbconfig.DB.Where("created_at < ?", cutoff).Delete(&models.SwapTransaction{})
 	if result.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
@@ -3604,13 +5866,14 @@ func CleanSwapTransaction(c *gin.Context) {
 // FilterSwapTransactions filters swap transactions based on criteria
 func FilterSwapTransactions(c *gin.Context) {
 	var req struct {
-		Signature   string `json:"signature"`
-		PoolAddress string `json:"pool_address"`
-		BaseMint    string `json:"base_mint"`
-		QuoteMint   string `json:"quote_mint"`
-		PayerType   string `json:"payer_type"`
-		Payer       string `json:"payer"`
-		IsSuccess   *bool  `json:"is_success"`
+		Signature         string   `json:"signature"`
+		PoolAddress       string   `json:"pool_address"`
+		BaseMint          string   `json:"base_mint"`
+		QuoteMint         string   `json:"quote_mint"`
+		PayerType         string   `json:"payer_type"`
+		Payer             string   `json:"payer"`
+		IsSuccess         *bool    `json:"is_success"`
+		ExcludeSignatures []string `json:"exclude_signatures"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -3647,13 +5910,20 @@ func FilterSwapTransactions(c *gin.Context) {
 		query = query.Where("is_success = ?", *req.IsSuccess)
 	}
 
+	query = excludeSignatures(query, req.ExcludeSignatures)
+
 	var transactions []models.SwapTransaction
-	if err := query.Find(&transactions).Error; err != nil {
+	if err := query.Order("id desc").Find(&transactions).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, transactions)
+	data, err := embedSwapTokens(c, transactions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
 }
 
 // ListSwapTransactionsByPoolID returns swap transactions by pool address
@@ -3695,15 +5965,63 @@ func ListSwapTransactionsByPoolID(c *gin.Context) {
 	}
 
 	// 返回结果
+	data, err := embedSwapTokens(c, transactions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      transactions,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"data":       data,
+		"pagination": paginationMeta(page, pageSize, total),
 	})
 }
 
+// projectPayerRole is the current role of a swap payer within a project, nil when the payer has none
+type projectPayerRole struct {
+	RoleID   *uint
+	RoleName *string
+}
+
+// holderType classifies the payer of tx the way holders are classified: project role addresses are
+// "project", the pool itself is "pool" and everyone else is "retail_investors"
+func (r projectPayerRole) holderType(tx models.SwapTransaction) string {
+	switch {
+	case r.RoleID != nil:
+		return "project"
+	case tx.Payer == tx.PoolAddress:
+		return "pool"
+	}
+	return "retail_investors"
+}
+
+// swapWithPayerRole is a swap_transaction row with its payer's role, see projectRoleClassifiedSwaps
+type swapWithPayerRole struct {
+	models.SwapTransaction
+	RoleID   *uint
+	RoleName *string
+}
+
+// projectRoleClassifiedSwaps selects swap_transaction rows left-joined to the project's current role
+// addresses on the payer (the lowest role ID wins when an address has several roles)
+func projectRoleClassifiedSwaps(db *gorm.DB, projectID uint) *gorm.DB {
+	projectRoles := db.Table("role_address").
+		Select("DISTINCT ON (role_address.address) role_address.address, role_address.role_id, role_config.role_name").
+		Joins("JOIN role_config_relation ON role_config_relation.role_id = role_address.role_id").
+		Joins("JOIN role_config ON role_config.id = role_address.role_id").
+		Where("role_config_relation.project_id = ?", projectID).
+		Order("role_address.address, role_address.role_id")
+	return db.Table("swap_transaction").
+		Select("swap_transaction.*, project_roles.role_id, project_roles.role_name").
+		Joins("LEFT JOIN (?) AS project_roles ON project_roles.address = swap_transaction.payer", projectRoles)
+}
+
 // GetSwapTransactionsByProject returns swap transactions by project ID and calculates RetailSolAmount
+// Query parameters: classify_by_role (true to annotate each swap with the payer's current role in the project
+// and its holder_type: project, pool or retail_investors; the stored payer_type is left unchanged)
 func GetSwapTransactionsByProject(c *gin.Context) {
 	projectID, err := strconv.Atoi(c.Param("project_id"))
 	if err != nil {
@@ -3734,25 +6052,47 @@ func GetSwapTransactionsByProject(c *gin.Context) {
 	}
 
 	// 3. Query SwapTransaction: BaseMint = mint AND IsSuccess = true, ordered by Slot DESC
+	classifyByRole := c.Query("classify_by_role") == "true"
 	var transactions []models.SwapTransaction
-	if err := dbconfig.DB.Where("base_mint = ? AND is_success = ?", tokenConfig.Mint, true).
+	var payerRoles []projectPayerRole
+	if classifyByRole {
+		var rows []swapWithPayerRole
+		if err := projectRoleClassifiedSwaps(dbconfig.DB, projectConfig.ID).
+			Where("swap_transaction.base_mint = ? AND swap_transaction.is_success = ?", tokenConfig.Mint, true).
+			Order("swap_transaction.slot DESC").
+			Scan(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		transactions = make([]models.SwapTransaction, len(rows))
+		payerRoles = make([]projectPayerRole, len(rows))
+		for i, row := range rows {
+			transactions[i] = row.SwapTransaction
+			payerRoles[i] = projectPayerRole{RoleID: row.RoleID, RoleName: row.RoleName}
+		}
+	} else if err := dbconfig.DB.Where("base_mint = ? AND is_success = ?", tokenConfig.Mint, true).
 		Order("slot DESC").
 		Find(&transactions).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// 4. Calculate RetailSolAmount: SUM(-QuoteChange)
+	// 4. Calculate RetailSolAmount: SOL that retail swaps moved into the pool (SOL-quoted swaps only,
+	// matching RecalculateAllRetailSolAmounts)
 	var retailSolAmount float64
 	for _, tx := range transactions {
-		retailSolAmount += -tx.QuoteChange
+		if mcsolana.IsSolMint(tx.QuoteMint) {
+			retailSolAmount += utils.PoolSideChange(tx.QuoteChange)
+		}
 	}
 
-	// 5. Save RetailSolAmount to ProjectConfig
-	projectConfig.RetailSolAmount = retailSolAmount
-	if err := dbconfig.DB.Save(&projectConfig).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save RetailSolAmount: " + err.Error()})
-		return
+	// 5. Save RetailSolAmount to ProjectConfig (skipped when stat updates are disabled for the project)
+	if projectConfig.UpdateStatEnabled {
+		projectConfig.RetailSolAmount = retailSolAmount
+		if err := dbconfig.DB.Save(&projectConfig).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save RetailSolAmount: " + err.Error()})
+			return
+		}
 	}
 
 	// 6. If RetailSolAmount < 0, return 0
@@ -3778,23 +6118,19 @@ func GetSwapTransactionsByProject(c *gin.Context) {
 		PoolQuoteChange float64   `json:"pool_quote_change"`
 		IsSuccess       bool      `json:"is_success"`
 		CreatedAt       time.Time `json:"created_at"`
+		RoleID          *uint     `json:"role_id,omitempty"`
+		RoleName        string    `json:"role_name,omitempty"`
+		HolderType      string    `json:"holder_type,omitempty"`
 	}
 
 	transactionResponses := make([]SwapTransactionResponse, len(transactions))
 	for i, tx := range transactions {
-		// Convert Timestamp (uint, seconds) to datetime string format "2006-01-02 15:04:05"
-		datetime := ""
-		if tx.Timestamp > 0 {
-			t := time.Unix(int64(tx.Timestamp), 0)
-			datetime = t.Format("2006-01-02 15:04:05")
-		}
-
 		transactionResponses[i] = SwapTransactionResponse{
 			ID:              tx.ID,
 			Signature:       tx.Signature,
 			Slot:            tx.Slot,
 			Timestamp:       tx.Timestamp,
-			Datetime:        datetime,
+			Datetime:        formatTimestamp(tx.Timestamp),
 			PayerType:       tx.PayerType,
 			Payer:           tx.Payer,
 			PoolAddress:     tx.PoolAddress,
@@ -3802,11 +6138,19 @@ func GetSwapTransactionsByProject(c *gin.Context) {
 			QuoteMint:       tx.QuoteMint,
 			BaseChange:      tx.BaseChange,
 			QuoteChange:     tx.QuoteChange,
-			PoolBaseChange:  tx.BaseChange * -1,
-			PoolQuoteChange: tx.QuoteChange * -1,
+			PoolBaseChange:  utils.PoolSideChange(tx.BaseChange),
+			PoolQuoteChange: utils.PoolSideChange(tx.QuoteChange),
 			IsSuccess:       tx.IsSuccess,
 			CreatedAt:       tx.CreatedAt,
 		}
+		if classifyByRole {
+			role := payerRoles[i]
+			transactionResponses[i].RoleID = role.RoleID
+			if role.RoleName != nil {
+				transactionResponses[i].RoleName = *role.RoleName
+			}
+			transactionResponses[i].HolderType = role.holderType(tx)
+		}
 	}
 
 	// Return result
@@ -3868,10 +6212,10 @@ func GetSwapTransactionsByProjectV2(c *gin.Context) {
 		return
 	}
 
-	// RetailSolAmount: SUM(-QuoteChange)
+	// RetailSolAmount: SOL that retail swaps moved into the pool
 	var retailSolAmount float64
 	for _, tx := range transactions {
-		retailSolAmount += -tx.QuoteChange
+		retailSolAmount += utils.PoolSideChange(tx.QuoteChange)
 	}
 
 	// Aggregate by Payer: sum BaseChange, QuoteChange; min/max CreatedAt; count
@@ -3948,3 +6292,87 @@ func GetSwapTransactionsByProjectV2(c *gin.Context) {
 		"transactions":      transactionResponses,
 	})
 }
+
+// signatureSwapModels and signatureHolderModels are the per-platform tables searched by
+// GetSwapsBySignatureAllPlatforms (raydium launchpad and cpmm share one table)
+var (
+	signatureSwapModels = []interface{}{
+		&models.PumpfuninternalSwap{}, &models.PumpfunAmmPoolSwap{}, &models.RaydiumPoolSwap{},
+		&models.MeteoradbcSwap{}, &models.MeteoracpmmSwap{},
+	}
+	signatureHolderModels = []interface{}{
+		&models.PumpfuninternalHolder{}, &models.PumpfunAmmpoolHolder{}, &models.RaydiumPoolHolder{},
+		&models.MeteoradbcHolder{}, &models.MeteoracpmmHolder{},
+	}
+)
+
+// findRowsBySignature loads the rows of model's table matching the condition, ordered by id.
+// It returns the table name, a pointer to the loaded slice and the row count.
+func findRowsBySignature(db *gorm.DB, model interface{}, condition string, args ...interface{}) (string, interface{}, int, error) {
+	table := model.(interface{ TableName() string }).TableName()
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+	if err := db.Where(condition, args...).Order("id asc").Find(rows.Interface()).Error; err != nil {
+		return table, nil, 0, fmt.Errorf("%s: %w", table, err)
+	}
+	return table, rows.Interface(), rows.Elem().Len(), nil
+}
+
+// GetSwapsBySignatureAllPlatforms returns everything stored about one on-chain transaction: the swap rows of
+// every platform table, holders whose start_signature or end_signature is the signature, the
+// address_balance_change, address_transaction and swap_transaction rows. Swaps and holders are grouped by
+// table name and only tables with matching rows are included.
+func GetSwapsBySignatureAllPlatforms(c *gin.Context) {
+	signature := c.Param("signature")
+
+	swaps := gin.H{}
+	holders := gin.H{}
+	total := 0
+	for _, model := range signatureSwapModels {
+		table, rows, n, err := findRowsBySignature(dbconfig.DB, model, "signature = ?", signature)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if n > 0 {
+			swaps[table] = rows
+			total += n
+		}
+	}
+	for _, model := range signatureHolderModels {
+		table, rows, n, err := findRowsBySignature(dbconfig.DB, model, "start_signature = ? OR end_signature = ?", signature, signature)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if n > 0 {
+			holders[table] = rows
+			total += n
+		}
+	}
+
+	var balanceChanges []models.AddressBalanceChange
+	var addressTransactions []models.AddressTransaction
+	var swapTransactions []models.SwapTransaction
+	for _, dest := range []interface{}{&balanceChanges, &addressTransactions, &swapTransactions} {
+		if err := dbconfig.DB.Where("signature = ?", signature).Order("id asc").Find(dest).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	total += len(balanceChanges) + len(addressTransactions) + len(swapTransactions)
+
+	if total == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No records found for signature", "signature": signature})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"signature":            signature,
+		"total":                total,
+		"swaps":                swaps,
+		"holders":              holders,
+		"balance_changes":      balanceChanges,
+		"address_transactions": addressTransactions,
+		"swap_transactions":    swapTransactions,
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"marketcontrol/internal/models"
+	"marketcontrol/pkg/clock"
+	dbconfig "marketcontrol/pkg/config"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestMonitorConfigUpsertSetKeepsCursorMonotonic(t *testing.T) {
+	db, _ := newMockDB(t)
+	dry := db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true})
+
+	config := models.TransactionsMonitorConfig{Address: "addr", Enabled: true, LastSlot: 10, TxCount: 3}
+	stmt := dry.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "address"}},
+		DoUpdates: monitorConfigUpsertSet([]string{"updated_at", "enabled", "last_slot", "tx_count"}),
+	}).Create(&config).Statement
+
+	assert.NoError(t, stmt.Error)
+	sql := stmt.SQL.String()
+	assert.Contains(t, sql, `"enabled"=EXCLUDED.enabled`)
+	assert.Contains(t, sql, `"last_slot"=CASE WHEN EXCLUDED.last_slot > transactions_monitor_config.last_slot THEN EXCLUDED.last_slot ELSE transactions_monitor_config.last_slot END`)
+	assert.Contains(t, sql, `"tx_count"=CASE WHEN EXCLUDED.last_slot > transactions_monitor_config.last_slot THEN EXCLUDED.tx_count ELSE transactions_monitor_config.tx_count END`)
+}
+
+// useFakeClock replaces handlerClock with a clock.Fake set to now for the duration of the test
+func useFakeClock(t *testing.T, now time.Time) *clock.Fake {
+	t.Helper()
+	fake := clock.NewFake(now)
+	previous := handlerClock
+	handlerClock = fake
+	t.Cleanup(func() { handlerClock = previous })
+	return fake
+}
+
+// useMockDB points dbconfig.DB at a sqlmock database for the duration of the test
+func useMockDB(t *testing.T) sqlmock.Sqlmock {
+	t.Helper()
+	db, mock := newMockDB(t)
+	previous := dbconfig.DB
+	dbconfig.DB = db
+	t.Cleanup(func() { dbconfig.DB = previous })
+	return mock
+}
+
+func postPurge(t *testing.T, body gin.H) (int, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/purge", PurgeOldTransactions)
+
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/purge", bytes.NewReader(raw)))
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestPurgeOldTransactionsMinRetention(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	useFakeClock(t, now)
+	mock := useMockDB(t)
+	latestAllowed := now.AddDate(0, 0, -minPurgeRetentionDays).Unix()
+
+	t.Run("retention below the minimum", func(t *testing.T) {
+		code, resp := postPurge(t, gin.H{"retention_days": minPurgeRetentionDays - 1, "confirm": true})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "cutoff is too recent", resp["error"])
+		assert.EqualValues(t, latestAllowed, resp["latest_allowed"])
+	})
+
+	t.Run("before_timestamp one second too recent", func(t *testing.T) {
+		code, _ := postPurge(t, gin.H{"before_timestamp": latestAllowed + 1, "confirm": true})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "rejected purges must not touch the database")
+}
+
+func TestPurgeOldTransactionsCutoff(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	useFakeClock(t, now)
+	mock := useMockDB(t)
+	cutoff := uint(now.AddDate(0, 0, -30).Unix())
+
+	mock.ExpectQuery(`SELECT "id","signature" FROM "address_transaction" WHERE timestamp < \$1 ORDER BY id asc LIMIT \$2`).
+		WithArgs(cutoff, defaultPurgeBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "signature"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "address_balance_change" WHERE id IN \(SELECT "id" FROM "address_balance_change" WHERE timestamp < \$1 LIMIT \$2\)`).
+		WithArgs(cutoff, defaultPurgeBatchSize).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	code, resp := postPurge(t, gin.H{"retention_days": 30, "confirm": true})
+	assert.Equal(t, http.StatusOK, code, resp)
+	assert.EqualValues(t, cutoff, resp["before_timestamp"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -192,7 +192,7 @@ func BuildPoolStatResp(poolStat *models.PoolStat) *PoolStatResp {
 	return &PoolStatResp{
 		ID:                  poolStat.ID,
 		PoolID:              poolStat.PoolID,
-		Platform:            "raydium",
+		Platform:            models.PoolPlatformRaydium.String(),
 		BaseAmount:          poolStat.BaseAmount,
 		QuoteAmount:         poolStat.QuoteAmount,
 		BaseAmountReadable:  poolStat.BaseAmountReadable,
@@ -216,7 +216,7 @@ func BuildPoolStatRespSimple(poolStat *models.PoolStat) *PoolStatRespSimple {
 	return &PoolStatRespSimple{
 		ID:                  poolStat.ID,
 		PoolID:              poolStat.PoolID,
-		Platform:            "raydium",
+		Platform:            models.PoolPlatformRaydium.String(),
 		BaseAmount:          poolStat.BaseAmount,
 		QuoteAmount:         poolStat.QuoteAmount,
 		BaseAmountReadable:  poolStat.BaseAmountReadable,
@@ -239,7 +239,7 @@ func BuildPumpfuninternalStatRespSimple(stat *models.PumpfuninternalStat) *Pumpf
 	return &PumpfuninternalStatResp{
 		ID:                  stat.ID,
 		PumpfuninternalID:  stat.PumpfuninternalID,
-		Platform:           "pumpfun_internal",
+		Platform:           models.PoolPlatformPumpfunInternal.String(),
 		Mint:               stat.Mint,
 		UnknownData:        stat.UnknownData,
 		VirtualTokenReserves: stat.VirtualTokenReserves,
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultMaxUnboundedRows is the most rows a list endpoint returns without pagination,
+// configurable via MAX_UNBOUNDED_ROWS
+const defaultMaxUnboundedRows = 10000
+
+// maxUnboundedRows returns the MAX_UNBOUNDED_ROWS limit, falling back to defaultMaxUnboundedRows
+func maxUnboundedRows() int {
+	if v := os.Getenv("MAX_UNBOUNDED_ROWS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultMaxUnboundedRows
+}
+
+// Every endpoint listed through findBounded accepts page (default: 1) and page_size (default: 50, max: 500)
+const (
+	defaultBoundedPageSize = 50
+	maxBoundedPageSize     = 500
+)
+
+// requestedPage parses page and page_size, falling back to the defaults for missing or invalid values
+func requestedPage(c *gin.Context) (int, int) {
+	page := 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	pageSize := defaultBoundedPageSize
+	if ps, err := strconv.Atoi(c.Query("page_size")); err == nil && ps > 0 && ps <= maxBoundedPageSize {
+		pageSize = ps
+	}
+	return page, pageSize
+}
+
+// findBounded runs query into rows. A query that already carries a LIMIT (the handler paginated itself) runs as is;
+// when the client sent page or page_size that page is read; otherwise at most MAX_UNBOUNDED_ROWS+1 rows are read
+// and a larger result is rejected with 400 instead of being serialized.
+// On failure the error response has already been written and it returns false.
+func findBounded[R any](c *gin.Context, query *gorm.DB, rows *[]R) bool {
+	limit := maxUnboundedRows()
+	_, paginated := query.Statement.Clauses["LIMIT"]
+	if !paginated && (c.Query("page") != "" || c.Query("page_size") != "") {
+		page, pageSize := requestedPage(c)
+		query = query.Offset((page - 1) * pageSize).Limit(pageSize)
+		paginated = true
+	}
+	if !paginated {
+		query = query.Limit(limit + 1)
+	}
+	if err := query.Find(rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !paginated && len(*rows) > limit {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    fmt.Sprintf("Result exceeds %d rows, please paginate (page, page_size) or narrow the filters", limit),
+			"max_rows": limit,
+		})
+		return false
+	}
+	return true
+}
@@ -164,7 +164,7 @@ func ListWashMapNodes(c *gin.Context) {
 	mapID := c.Param("id")
 
 	var nodes []models.AddressNode
-	if err := dbconfig.DB.Where("map_id = ?", mapID).Find(&nodes).Error; err != nil {
+	if err := dbconfig.DB.Where("map_id = ?", mapID).Order("id desc").Find(&nodes).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -178,7 +178,7 @@ func ListWashMapEdges(c *gin.Context) {
 	var edges []models.AddressEdge
 	if err := dbconfig.DB.Where(
 		"from_node_id IN (SELECT id FROM address_nodes WHERE map_id = ?) AND to_node_id IN (SELECT id FROM address_nodes WHERE map_id = ?)",
-		mapID, mapID).Preload("FromNode").Preload("ToNode").Find(&edges).Error; err != nil {
+		mapID, mapID).Preload("FromNode").Preload("ToNode").Order("id desc").Find(&edges).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -484,7 +484,7 @@ func GetWashTaskByManageID(c *gin.Context) {
 	}
 
 	// 创建包含余额信息的响应切片
-	var tasksWithBalance []WashTaskWithBalance
+	tasksWithBalance := []WashTaskWithBalance{}
 
 	for _, task := range tasks {
 		taskWithBalance := WashTaskWithBalance{
@@ -516,21 +516,9 @@ func GetWashTaskByManageID(c *gin.Context) {
 		tasksWithBalance = append(tasksWithBalance, taskWithBalance)
 	}
 
-	// 计算分页信息
-	totalPages := int(math.Ceil(float64(total) / float64(pageSizeNum)))
-	hasNext := pageNum < totalPages
-	hasPrev := pageNum > 1
-
 	c.JSON(http.StatusOK, gin.H{
-		"data": tasksWithBalance,
-		"pagination": gin.H{
-			"page":        pageNum,
-			"page_size":   pageSizeNum,
-			"total":       total,
-			"total_pages": totalPages,
-			"has_next":    hasNext,
-			"has_prev":    hasPrev,
-		},
+		"data":       tasksWithBalance,
+		"pagination": paginationMeta(pageNum, pageSizeNum, total),
 		"sorting": gin.H{
 			"order_field": orderField,
 			"order_type":  orderType,
@@ -809,7 +797,7 @@ func ListWashMapsByProjectID(c *gin.Context) {
 	}
 
 	var washMaps []models.WashMap
-	if err := dbconfig.DB.Where("project_id = ?", req.ProjectID).Find(&washMaps).Error; err != nil {
+	if err := dbconfig.DB.Where("project_id = ?", req.ProjectID).Order("id desc").Find(&washMaps).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -832,7 +820,7 @@ func ListWashTaskManageByProjectID(c *gin.Context) {
 
 	// 首先查找所有符合 ProjectID 的 WashMap
 	var washMaps []models.WashMap
-	if err := dbconfig.DB.Where("project_id = ?", req.ProjectID).Find(&washMaps).Error; err != nil {
+	if err := dbconfig.DB.Where("project_id = ?", req.ProjectID).Order("id desc").Find(&washMaps).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
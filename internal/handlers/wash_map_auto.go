@@ -73,7 +73,7 @@ func AutoCreateWashMapWithTask(c *gin.Context) {
 	}
 
 	// 检查最近5分钟内是否有使用相同根节点地址的任务
-	timeWindow := time.Now().Add(-2 * time.Minute)
+	timeWindow := handlerClock.Now().Add(-2 * time.Minute)
 	var recentTaskManages []models.WashTaskManage
 	if err := dbconfig.DB.Where("created_at > ?", timeWindow).Find(&recentTaskManages).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("查询最近任务失败: %v", err)})
@@ -371,7 +371,7 @@ func AutoCreateWashMapWithTaskV2(c *gin.Context) {
 	}
 
 	// 检查最近 2 分钟内是否有使用相同根节点地址的任务
-	timeWindow := time.Now().Add(-1 * time.Minute)
+	timeWindow := handlerClock.Now().Add(-1 * time.Minute)
 	var recentTaskManages []models.WashTaskManage
 	if err := dbconfig.DB.Where("created_at > ?", timeWindow).Find(&recentTaskManages).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("查询最近任务失败: %v", err)})
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AmountsAsStringParam is the query parameter that switches amount fields to string encoding
+const AmountsAsStringParam = "amounts_as_string"
+
+// amountKeySuffixes identify JSON keys that carry token/SOL amounts
+var amountKeySuffixes = []string{"_change", "_volume", "_amount", "_balance", "_reserves"}
+
+// isAmountKey reports whether a JSON key holds an amount
+func isAmountKey(key string) bool {
+	if key == "fee" || key == "amount" || key == "balance" {
+		return true
+	}
+	for _, suffix := range amountKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringifyAmounts rewrites numeric amount fields of a JSON document as strings, keeping the
+// exact digits produced by the encoder so JavaScript clients don't round them to doubles again
+func stringifyAmounts(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(convertAmounts(doc))
+}
+
+func convertAmounts(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if number, ok := value.(json.Number); ok && isAmountKey(key) {
+				v[key] = number.String()
+				continue
+			}
+			v[key] = convertAmounts(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = convertAmounts(v[i])
+		}
+	}
+	return v
+}
+
+// bufferedWriter holds the response body so it can be rewritten before being sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// AmountStringMiddleware encodes amount fields (*_change, *_volume, *_amount, fee, ...) of JSON
+// responses as strings when the request has amounts_as_string=true. Other responses are untouched.
+func AmountStringMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query(AmountsAsStringParam) != "true" {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		body := writer.buf.Bytes()
+		if strings.HasPrefix(writer.Header().Get("Content-Type"), "application/json") && len(body) > 0 {
+			if converted, err := stringifyAmounts(body); err == nil {
+				body = converted
+			}
+		}
+		writer.ResponseWriter.Write(body)
+	}
+}
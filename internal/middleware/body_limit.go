@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodyBytes is the request body limit used when MAX_BODY_BYTES is not set.
+// The multipart imports are exempt and limited by IMPORT_MAX_UPLOAD_BYTES instead.
+const DefaultMaxBodyBytes int64 = 16 << 20
+
+// BodySizeLimitMiddleware rejects requests whose body exceeds maxBytes.
+// Declared oversize bodies are rejected up front; chunked bodies are cut off by http.MaxBytesReader
+// and surface as a read error in the handler. Routes in exemptPaths (gin full paths) enforce their own limit.
+func BodySizeLimitMiddleware(maxBytes int64, exemptPaths ...string) gin.HandlerFunc {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = true
+	}
+	return func(c *gin.Context) {
+		if maxBytes <= 0 || c.Request.Body == nil || exempt[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > maxBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error":     "Request body too large",
+				"max_bytes": maxBytes,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
@@ -13,6 +13,8 @@ import (
 type RateLimiterConfig struct {
 	RequestsPerSecond float64
 	Burst             int
+	Global            bool // share one limiter across all clients instead of one per IP
+	PerRoute          bool // keep a separate budget per route (c.FullPath()) when one middleware guards many routes
 }
 
 // rateLimiterMap stores rate limiters per IP address
@@ -71,8 +73,14 @@ func RateLimiterMiddleware(config RateLimiterConfig) gin.HandlerFunc {
 	limiterMap := NewRateLimiterMap(config)
 
 	return func(c *gin.Context) {
-		// Get client IP
+		// Get client IP (or the shared key in global mode)
 		ip := c.ClientIP()
+		if config.Global {
+			ip = "global"
+		}
+		if config.PerRoute {
+			ip = c.FullPath() + "|" + ip
+		}
 
 		// Get or create limiter for this IP
 		limiter := limiterMap.getLimiter(ip)
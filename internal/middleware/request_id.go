@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the gin context key holding the request ID
+const requestIDKey = "request_id"
+
+// maxRequestIDLength bounds client-supplied IDs so they can't bloat logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware keeps the client's X-Request-ID (or generates one), stores it in the gin context,
+// echoes it in the response header and logs failed requests (status >= 500) with it
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+
+		if status := c.Writer.Status(); status >= http.StatusInternalServerError {
+			entry := RequestLogger(c).WithFields(log.Fields{
+				"method": c.Request.Method,
+				"path":   c.FullPath(),
+				"status": status,
+			})
+			if len(c.Errors) > 0 {
+				entry = entry.WithField("errors", c.Errors.String())
+			}
+			entry.Warn("Request failed")
+		}
+	}
+}
+
+// GetRequestID returns the request ID set by RequestIDMiddleware, or "" outside of it
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// RequestLogger returns a logrus entry tagged with the request ID. Capture it before starting a
+// goroutine so asynchronous work (e.g. RabbitMQ publishes) logs under the same ID.
+func RequestLogger(c *gin.Context) *log.Entry {
+	if id := GetRequestID(c); id != "" {
+		return log.WithField(requestIDKey, id)
+	}
+	return log.NewEntry(log.StandardLogger())
+}
+
+// validRequestID accepts non-empty printable ASCII IDs up to maxRequestIDLength
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+package models
+
+// PoolPlatform identifies which pool table a project's pool_id points to
+type PoolPlatform string
+
+const (
+	PoolPlatformRaydium          PoolPlatform = "raydium"
+	PoolPlatformPumpfunInternal  PoolPlatform = "pumpfun_internal"
+	PoolPlatformPumpfunAmm       PoolPlatform = "pumpfun_amm"
+	PoolPlatformRaydiumLaunchpad PoolPlatform = "raydium_launchpad"
+	PoolPlatformRaydiumCpmm      PoolPlatform = "raydium_cpmm"
+	PoolPlatformMeteoraDbc       PoolPlatform = "meteora_dbc"
+	PoolPlatformMeteoraCpmm      PoolPlatform = "meteora_cpmm"
+)
+
+// AllPoolPlatforms returns every supported pool platform, in display order.
+// New platforms must be added here so validation and listings pick them up.
+func AllPoolPlatforms() []PoolPlatform {
+	return []PoolPlatform{
+		PoolPlatformRaydium,
+		PoolPlatformPumpfunInternal,
+		PoolPlatformPumpfunAmm,
+		PoolPlatformRaydiumLaunchpad,
+		PoolPlatformRaydiumCpmm,
+		PoolPlatformMeteoraDbc,
+		PoolPlatformMeteoraCpmm,
+	}
+}
+
+// IsValid reports whether p is one of AllPoolPlatforms
+func (p PoolPlatform) IsValid() bool {
+	for _, platform := range AllPoolPlatforms() {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMeteora reports whether p is a Meteora DBC or CPMM (DAMM v2) pool
+func (p PoolPlatform) IsMeteora() bool {
+	return p == PoolPlatformMeteoraDbc || p == PoolPlatformMeteoraCpmm
+}
+
+func (p PoolPlatform) String() string {
+	return string(p)
+}
+
+// PoolPlatformNames returns AllPoolPlatforms as plain strings, e.g. for error messages
+func PoolPlatformNames() []string {
+	platforms := AllPoolPlatforms()
+	names := make([]string, len(platforms))
+	for i, platform := range platforms {
+		names[i] = string(platform)
+	}
+	return names
+}
@@ -10,7 +10,7 @@ import (
 type ProjectConfig struct {
 	ID                uint            `gorm:"primarykey" json:"id"`
 	Name              string          `gorm:"size:64;not null" json:"name"`
-	PoolPlatform      string          `gorm:"size:20;not null;default:'raydium'" json:"pool_platform"` // 'raydium' or 'pumpfun_internal'
+	PoolPlatform      PoolPlatform    `gorm:"size:20;not null;default:'raydium'" json:"pool_platform"` // 见 AllPoolPlatforms
 	PoolID            uint            `gorm:"not null" json:"pool_id"`
 	TokenID           uint            `gorm:"not null" json:"token_id"`
 	TokenMetadataID   uint            `gorm:"default:0" json:"token_metadata_id"`
@@ -22,6 +22,8 @@ type ProjectConfig struct {
 	IsLocked          bool            `gorm:"default:false" json:"is_locked"`
 	AssetsBalance     float64         `gorm:"default:0" json:"assets_balance"`
 	RetailSolAmount   float64         `gorm:"default:0" json:"retail_sol_amount"`
+	ProjectProfit     float64         `gorm:"default:0;index" json:"project_profit"` // assets_balance 减去基线快照的 assets_balance
+	ProfitBaselineID  uint            `gorm:"default:0" json:"profit_baseline_id"`   // 基线快照 ID（同一项目最近的 ProjectSnapshot），0 表示无基线
 	PoolConfig        string          `json:"pool_config" gorm:"size:44"`
 	Event             json.RawMessage `json:"event" gorm:"type:jsonb"`
 	Vesting           json.RawMessage `json:"vesting" gorm:"type:jsonb"`
@@ -53,6 +53,18 @@ type PumpfuninternalSnapshot struct {
 	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
+// ProjectSnapshot is a point-in-time record of a project's balances, used for time-series charts
+type ProjectSnapshot struct {
+	ID              uint      `gorm:"primarykey" json:"id"`
+	ProjectID       uint      `gorm:"index" json:"project_id"`
+	SnapshotNo      int       `json:"snapshot_no"` // ProjectConfig.SnapshotCount after this snapshot
+	AssetsBalance   float64   `json:"assets_balance"`
+	RetailSolAmount float64   `json:"retail_sol_amount"`
+	ProjectProfit   float64   `json:"project_profit"`
+	HolderCount     int64     `json:"holder_count"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
 func (WalletTokenSnapshot) TableName() string {
 	return "wallet_token_snapshots"
 }
@@ -64,3 +76,7 @@ func (PoolSnapshot) TableName() string {
 func (PumpfuninternalSnapshot) TableName() string {
 	return "pumpfuninternal_snapshots"
 }
+
+func (ProjectSnapshot) TableName() string {
+	return "project_snapshots"
+}
@@ -344,3 +344,58 @@ type SwapTransaction struct {
 func (SwapTransaction) TableName() string {
 	return "swap_transaction"
 }
+
+// MonitorErrorState records the consecutive monitoring start failures of an address
+type MonitorErrorState struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Address     string     `json:"address" gorm:"type:varchar(128);uniqueIndex"`
+	ErrorCount  int        `json:"error_count" gorm:"default:0"`
+	LastError   string     `json:"last_error" gorm:"type:text;default:''"`
+	LastErrorAt *time.Time `json:"last_error_at"`
+	CleanedUp   bool       `json:"cleaned_up" gorm:"default:false"`
+	CleanedUpAt *time.Time `json:"cleaned_up_at"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for MonitorErrorState
+func (MonitorErrorState) TableName() string {
+	return "monitor_error_state"
+}
+
+// Backfill status values
+const (
+	BackfillStatusQueued     = "queued"
+	BackfillStatusRunning    = "running"
+	BackfillStatusCancelling = "cancelling" // cancel requested, waiting for the worker
+	BackfillStatusCancelled  = "cancelled"
+	BackfillStatusCompleted  = "completed"
+	BackfillStatusFailed     = "failed"
+)
+
+// Backfill tracks the re-fetch of a pool's historical swaps over a slot range
+type Backfill struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	PoolAddress    string     `json:"pool_address" gorm:"type:varchar(128);index"`
+	PoolPlatform   string     `json:"pool_platform" gorm:"type:varchar(32)"`
+	StartSlot      uint64     `json:"start_slot"`
+	EndSlot        uint64     `json:"end_slot"`
+	Status         string     `json:"status" gorm:"type:varchar(16);index;default:'queued'"`
+	ProcessedSlot  uint64     `json:"processed_slot"` // slot reached so far; backfills walk from end_slot down to start_slot
+	ProcessedCount int64      `json:"processed_count" gorm:"default:0"`
+	Error          string     `json:"error" gorm:"type:text;default:''"`
+	StartedAt      *time.Time `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for Backfill
+func (Backfill) TableName() string {
+	return "backfill"
+}
+
+// BackfillFinished reports whether status is terminal
+func BackfillFinished(status string) bool {
+	return status == BackfillStatusCancelled || status == BackfillStatusCompleted || status == BackfillStatusFailed
+}
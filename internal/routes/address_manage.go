@@ -6,11 +6,22 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// addressImportPaths are the multipart import routes; they are exempt from MAX_BODY_BYTES and
+// limited by IMPORT_MAX_UPLOAD_BYTES in the handlers
+var addressImportPaths = []string{
+	"/address-manage/import-and-verify-password",
+	"/address-manage/import-csv",
+	"/address-manage/import-csv-with-base58",
+	"/disposable-address-manage/import-and-verify-password",
+	"/disposable-address-manage/import-csv",
+}
+
 // SetupAddressManageRoutes sets up all routes related to Address Management
 func SetupAddressManageRoutes(r *gin.Engine) {
 	address := r.Group("/address-manage")
 	{
 		address.GET("", handlers.ListAddresses)
+		address.GET("/orphans", heavyRead, handlers.ListOrphanAddresses)
 		address.GET("/:address", handlers.GetAddress)
 		address.GET("/role/:role_id", handlers.ListAddressesByRole)
 		address.POST("/generate", handlers.GenerateAddresses)
@@ -21,6 +32,7 @@ func SetupAddressManageRoutes(r *gin.Engine) {
 		address.POST("/export-with-new-password/role/:rold_id", handlers.ExportWithNewPasswordFromRole)
 		address.POST("/export-with-gmgn-track-format/role/:role_id", handlers.ExportWithGmgnTrackFormatFromRole)
 		address.POST("/import-and-verify-password", handlers.ImportAndVerifyPassword)
+		address.POST("/upgrade-encryption", handlers.UpgradeAddressEncryption)
 		address.GET("/review-by-role-count", handlers.ReviewAddressesByRoleCount)
 		address.POST("/review-by-token-stat", handlers.ReviewAddressesByTokenStat)
 		address.POST("/check-exists", handlers.CheckAddressExists)
@@ -70,6 +70,7 @@ func SetupCommonUtilsRoutes(r *gin.Engine) {
 	websocket := r.Group("/common_utils/websocket")
 	{
 		websocket.POST("/pool-monitor", handlers.ControlPoolMonitor)
+		websocket.POST("/pool-monitor/republish-all", handlers.RepublishAllMonitoring)
 	}
 
 	// RPC status check endpoint with rate limiting
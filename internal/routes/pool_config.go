@@ -11,6 +11,17 @@ func SetupPoolConfigRoutes(r *gin.Engine) {
 	{
 		pool.GET("", handlers.ListPoolConfigs)
 		pool.GET("/:id", handlers.GetPoolConfig)
+		pool.GET("/by-address/:address", handlers.GetPoolConfigByAddress)
+		pool.GET("/by-address/:address/fee-summary", heavyRead, handlers.GetPoolFeeSummary)
+		pool.GET("/by-address/:address/active-traders", heavyRead, handlers.GetActiveTraderCount)
+		pool.GET("/by-address/:address/buy-sell-pressure", heavyRead, handlers.GetBuySellPressure)
+		pool.GET("/by-address/:address/trade-size-distribution", heavyRead, handlers.GetTradeSizeDistribution)
+		pool.GET("/by-address/:address/candles", heavyRead, handlers.GetPoolCandles)
+		pool.GET("/by-address/:address/swap-slot-gaps", heavyRead, handlers.DetectSwapSlotGaps)
+		pool.GET("/by-address/:address/pumpfun-revenue", heavyRead, handlers.GetPumpfunFeeAndCreatorRevenue)
+		pool.GET("/by-address/:address/status-history", handlers.GetPoolStatusHistory)
+		pool.GET("/by-address/:address/genesis", handlers.GetPoolGenesis)
+		pool.POST("/by-address/:address/classify-holders", handlers.ClassifyUntypedHolders)
 		pool.POST("", handlers.CreatePoolConfig)
 		pool.PUT("/:id", handlers.UpdatePoolConfig)
 		pool.DELETE("/:id", handlers.DeletePoolConfig)
@@ -10,11 +10,15 @@ import (
 func SetupProjectConfigRoutes(r *gin.Engine) {
 	project := r.Group("/project-config")
 	{
-		project.GET("", handlers.ListProjectConfigs)
+		project.GET("", heavyRead, handlers.ListProjectConfigs)
 		project.GET("/slice", handlers.ListProjectConfigsBySlice)
 		project.GET("/latest", handlers.GetLatestProjectConfig)
 		project.GET("/latest/active", handlers.GetLatestActiveProjectConfig)
+		project.GET("/latest/list", handlers.ListLatestProjects)
 		project.GET("/:id", handlers.GetProjectConfig)
+		project.POST("/batch", handlers.GetProjectsByIDs)
+		project.GET("/:id/pool-graph", handlers.GetProjectPoolGraph)
+		project.GET("/:id/holder-growth", heavyRead, handlers.GetHolderGrowth)
 		project.POST("", handlers.CreateProjectConfig)
 		project.PUT("/:id", handlers.UpdateProjectConfig)
 		project.DELETE("/:id", handlers.DeleteProjectConfig)
@@ -23,10 +27,21 @@ func SetupProjectConfigRoutes(r *gin.Engine) {
 		project.POST("/auto-create-pumpfunamm", handlers.AutoCreatePumpfunAmmProject)
 		project.POST("/auto-create-meteoradbc", handlers.AutoCreateMeteoradbcProject)
 		project.POST("/auto-create-meteoradbc-v2", handlers.AutoCreateMeteoradbcProjectV2)
+		project.POST("/bulk-import", handlers.BulkImportProjects)
+		project.GET("/missing-token-metadata", handlers.ListProjectsMissingTokenMetadata)
 		project.POST("/refill-token-metadata-id", handlers.RefillTokenMetadataID)
 		project.POST("/update-assets-balance", handlers.UpdateAssetsBalance)
+		project.POST("/recompute-profit", handlers.RecomputeProjectProfit)
+		project.POST("/recalculate-retail-sol", handlers.RecalculateAllRetailSolAmounts)
 		project.POST("/update-vesting", handlers.UpdateVesting)
 		project.POST("/toggle/:id", handlers.ToggleProjectConfigLocker)
+		project.POST("/toggle-update-stat/:id", handlers.ToggleProjectUpdateStat)
+		project.POST("/:id/teardown", handlers.TeardownProject)
+		project.GET("/:id/trader/:address/position", handlers.GetTraderPositionInProject)
+		project.GET("/:id/trader/:address/swaps", heavyRead, handlers.GetTraderSwapsInProject)
+		project.GET("/:id/holders/export-csv", heavyRead, handlers.ExportProjectHoldersCSV)
+		project.GET("/:id/sol-reconciliation", heavyRead, handlers.ReconcileProjectSol)
+		project.GET("/:id/monitoring-status", handlers.GetProjectMonitoringStatus)
 	}
 }
 
@@ -53,6 +68,7 @@ func SetupProjectExtraAddressRoutes(r *gin.Engine) {
 		extraAddress.POST("", handlers.CreateProjectExtraAddress)
 		extraAddress.PUT("/:id", handlers.UpdateProjectExtraAddress)
 		extraAddress.DELETE("/:id", handlers.DeleteProjectExtraAddress)
+		extraAddress.POST("/batch-toggle", handlers.BatchToggleProjectExtraAddress)
 		extraAddress.GET("/project/:project_id", handlers.GetProjectExtraAddressesByProjectID)
 	}
 }
@@ -24,6 +24,13 @@ func SetupProjectSnapshotRoutes(r *gin.Engine) {
 		pool.GET("/by-snapshot/:snapshot_id", handlers.ListPoolSnapshotsBySnapshotID)
 	}
 
+	project := r.Group("/project-snapshot")
+	{
+		project.POST("/by-project/:project_id", handlers.CreateProjectSnapshot)
+		project.GET("/by-project/:project_id", handlers.ListProjectSnapshots)
+		project.GET("/by-project/:project_id/profit-history", handlers.GetProjectProfitHistory)
+	}
+
 	settle := r.Group("/settle-snapshot")
 	{
 		settle.POST("/by-project/:project_id", handlers.GetSettleSnapshotByProject)
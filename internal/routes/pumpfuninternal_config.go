@@ -14,6 +14,7 @@ func SetupPumpfuninternalConfigRoutes(r *gin.Engine) {
 		pumpfun.GET("/:id", handlers.GetPumpfuninternalConfig)
 		pumpfun.GET("/mint/:mint", handlers.GetPumpfuninternalConfigByMint)
 		pumpfun.POST("", handlers.CreatePumpfuninternalConfig)
+		pumpfun.POST("/backfill-pdas", handlers.BackfillPumpfunPDAs)
 		// pumpfun.PUT("/:id", handlers.UpdatePumpfuninternalConfig)
 		pumpfun.PATCH("/:id/status", handlers.UpdatePumpfuninternalConfigStatus)
 		pumpfun.DELETE("/:id", handlers.DeletePumpfuninternalConfig)
@@ -1,12 +1,45 @@
 package routes
 
 import (
+	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
+	"marketcontrol/internal/handlers"
+	"marketcontrol/internal/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
+// heavyRead throttles expensive list/aggregation endpoints; set by SetupRouter before routes are registered
+var heavyRead gin.HandlerFunc = func(c *gin.Context) { c.Next() }
+
+// newHeavyReadLimiter builds the rate limiter for heavy read endpoints from the environment.
+// HEAVY_READ_RPS (default 5, 0 disables), HEAVY_READ_BURST (default 10), HEAVY_READ_SCOPE (ip or global, default ip).
+// The one middleware is shared by every heavy route but keeps a separate budget per route (and per client IP
+// unless global), so a page loading several heavy endpoints at once is not throttled by their combined rate.
+func newHeavyReadLimiter() gin.HandlerFunc {
+	cfg := middleware.RateLimiterConfig{RequestsPerSecond: 5, Burst: 10, PerRoute: true}
+	if v := os.Getenv("HEAVY_READ_RPS"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
+			cfg.RequestsPerSecond = parsed
+		}
+	}
+	if v := os.Getenv("HEAVY_READ_BURST"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			cfg.Burst = parsed
+		}
+	}
+	cfg.Global = strings.EqualFold(os.Getenv("HEAVY_READ_SCOPE"), "global")
+
+	if cfg.RequestsPerSecond == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.RateLimiterMiddleware(cfg)
+}
+
 // SetupRoutes initializes and returns the Gin router with all routes configured
 func SetupRouter() *gin.Engine {
 	r := gin.Default()
@@ -15,6 +48,10 @@ func SetupRouter() *gin.Engine {
 	r.Any("/health", func(c *gin.Context) {
 		c.String(200, "ok")
 	})
+	r.GET("/healthz", handlers.Healthz)
+
+	// Tag every request with an X-Request-ID (kept from the client or generated) for log correlation
+	r.Use(middleware.RequestIDMiddleware())
 
 	// Configure CORS middleware
 	r.Use(func(c *gin.Context) {
@@ -58,9 +95,9 @@ func SetupRouter() *gin.Engine {
 
 		// 确保包含所有必要的请求头
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Origin, Cache-Control, X-Requested-With, X-Request-ID")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, X-Request-ID")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 
 		// Handle preflight requests
@@ -72,6 +109,18 @@ func SetupRouter() *gin.Engine {
 		c.Next()
 	})
 
+	// Limit request body size, configurable via MAX_BODY_BYTES; the file imports use IMPORT_MAX_UPLOAD_BYTES
+	maxBodyBytes := middleware.DefaultMaxBodyBytes
+	if v := os.Getenv("MAX_BODY_BYTES"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			maxBodyBytes = parsed
+		}
+	}
+	r.Use(middleware.BodySizeLimitMiddleware(maxBodyBytes, addressImportPaths...))
+
+	// Throttle expensive list/aggregation endpoints, configurable via HEAVY_READ_RPS / HEAVY_READ_BURST / HEAVY_READ_SCOPE
+	heavyRead = newHeavyReadLimiter()
+
 	// Setup routes for each module
 	SetupRpcConfigRoutes(r)
 	SetupBlockchainConfigRoutes(r)
@@ -102,5 +151,38 @@ func SetupRouter() *gin.Engine {
 	SetupMeteoracpmmConfigRoutes(r)
 	SetupSystemConfigRoutes(r)
 
+	// Unknown routes and wrong methods answer with the same JSON error shape as the handlers
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Route not found", "path": c.Request.URL.Path})
+	})
+	r.NoMethod(func(c *gin.Context) {
+		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Method not allowed", "method": c.Request.Method, "path": c.Request.URL.Path})
+	})
+
+	// Route listing for development, enabled with ENABLE_ROUTE_LISTING=true
+	if strings.EqualFold(os.Getenv("ENABLE_ROUTE_LISTING"), "true") {
+		setupRouteListing(r)
+	}
+
 	return r
 }
+
+// setupRouteListing registers GET /routes returning every registered method and path
+func setupRouteListing(r *gin.Engine) {
+	r.GET("/routes", func(c *gin.Context) {
+		routes := r.Routes()
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+			return routes[i].Method < routes[j].Method
+		})
+
+		data := make([]gin.H, 0, len(routes))
+		for _, route := range routes {
+			data = append(data, gin.H{"method": route.Method, "path": route.Path})
+		}
+		c.JSON(http.StatusOK, gin.H{"total": len(data), "data": data})
+	})
+}
@@ -19,6 +19,7 @@ func SetupStrategyConfigRoutes(r *gin.Engine) {
 
 		// Special operations requested by user
 		strategy.GET("/project/:project_id", handlers.ListStrategyConfigsByProjectId)
+		strategy.GET("/overview", handlers.ListStrategies)
 		strategy.POST("/close-all/:project_id", handlers.CloseStrategyConfigsByProjectId)
 		strategy.POST("/close-type", handlers.CloseStrategyTypeByProjectId)
 		strategy.POST("/check-close", handlers.CheckStrategyCloseByProjectId)
@@ -2,21 +2,35 @@ package routes
 
 import (
 	"marketcontrol/internal/handlers"
+	"marketcontrol/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupTransactionsMonitorConfigRoutes sets up all routes related to Transactions Monitor Config management
 func SetupTransactionsMonitorConfigRoutes(r *gin.Engine) {
+	// Swap/holder responses can encode amounts as strings (?amounts_as_string=true)
+	amountsAsString := middleware.AmountStringMiddleware()
+
 	// Setup monitor config routes
 	monitorGroup := r.Group("/api/transactions-monitor-config")
 	{
 		monitorGroup.POST("", handlers.CreateTransactionsMonitorConfig)
+		monitorGroup.PUT("/upsert", handlers.UpsertTransactionsMonitorConfig)
+		monitorGroup.POST("/set-enabled", handlers.SetMonitorConfigsEnabled)
 		monitorGroup.GET("/:id", handlers.GetTransactionsMonitorConfig)
+		monitorGroup.GET("/:id/detail", handlers.GetMonitorConfigDetail)
 		monitorGroup.GET("", handlers.ListTransactionsMonitorConfigs)
 		monitorGroup.PUT("/:id", handlers.UpdateTransactionsMonitorConfig)
+		monitorGroup.POST("/:id/advance-cursor", handlers.AdvanceMonitorCursor)
 		monitorGroup.DELETE("/:id", handlers.DeleteTransactionsMonitorConfig)
 		monitorGroup.POST("/delete-with-data", handlers.DeleteTransactionsMonitorConfigWithData)
+		monitorGroup.POST("/validate", handlers.ValidateMonitorTarget)
+		monitorGroup.GET("/errors/:address", handlers.GetMonitorErrors)
+		monitorGroup.GET("/unconfigured-pools", heavyRead, handlers.ListUnconfiguredPools)
+		monitorGroup.POST("/backfills", handlers.EnqueueBackfill)
+		monitorGroup.GET("/backfills", handlers.ListBackfills)
+		monitorGroup.POST("/backfills/:id/cancel", handlers.CancelBackfill)
 	}
 
 	// Setup address transaction routes
@@ -24,9 +38,11 @@ func SetupTransactionsMonitorConfigRoutes(r *gin.Engine) {
 	{
 		transactionGroup.POST("", handlers.CreateAddressTransaction)
 		transactionGroup.GET("/:id", handlers.GetAddressTransaction)
-		transactionGroup.GET("", handlers.ListAddressTransactions)
+		transactionGroup.GET("", heavyRead, handlers.ListAddressTransactions)
 		transactionGroup.PUT("/:id", handlers.UpdateAddressTransaction)
 		transactionGroup.DELETE("/:id", handlers.DeleteAddressTransaction)
+		transactionGroup.POST("/purge", handlers.PurgeOldTransactions)
+		transactionGroup.GET("/address/:address/summary", heavyRead, handlers.GetAddressActivitySummary)
 	}
 
 	// Setup address balance change routes
@@ -34,144 +50,180 @@ func SetupTransactionsMonitorConfigRoutes(r *gin.Engine) {
 	{
 		balanceGroup.POST("", handlers.CreateAddressBalanceChange)
 		balanceGroup.GET("/:id", handlers.GetAddressBalanceChange)
-		balanceGroup.GET("", handlers.ListAddressBalanceChanges)
+		balanceGroup.GET("", heavyRead, handlers.ListAddressBalanceChanges)
 		balanceGroup.PUT("/:id", handlers.UpdateAddressBalanceChange)
 		balanceGroup.DELETE("/:id", handlers.DeleteAddressBalanceChange)
-		balanceGroup.POST("/filter", handlers.FilterListAddressBalanceChanges)
+		balanceGroup.POST("/filter", heavyRead, handlers.FilterListAddressBalanceChanges)
+		balanceGroup.GET("/pool/:pool_address/series", heavyRead, handlers.GetBalanceChangeTimeSeries)
+		balanceGroup.GET("/address/:address/running-balance", heavyRead, handlers.GetAddressRunningBalance)
 	}
 
 	// Setup pumpfuninternal swap routes
-	swapGroup := r.Group("/api/pumpfuninternal-swap")
+	swapGroup := r.Group("/api/pumpfuninternal-swap", amountsAsString)
 	{
 		swapGroup.POST("", handlers.CreatePumpfuninternalSwap)
 		swapGroup.GET("/:id", handlers.GetPumpfuninternalSwap)
-		swapGroup.GET("", handlers.ListPumpfuninternalSwaps)
+		swapGroup.GET("", heavyRead, handlers.ListPumpfuninternalSwaps)
 		swapGroup.PUT("/:id", handlers.UpdatePumpfuninternalSwap)
 		swapGroup.DELETE("/:id", handlers.DeletePumpfuninternalSwap)
-		swapGroup.POST("/filter", handlers.FilterPumpfuninternalSwaps)
+		swapGroup.POST("/filter", heavyRead, handlers.FilterPumpfuninternalSwaps)
 		swapGroup.GET("/pool/:pool_id", handlers.ListPumpfuninternalSwapsByPoolID)
 	}
 
 	// Setup pumpfuninternal holder routes
-	holderGroup := r.Group("/api/pumpfuninternal-holder")
+	holderGroup := r.Group("/api/pumpfuninternal-holder", amountsAsString)
 	{
 		holderGroup.POST("", handlers.CreatePumpfuninternalHolder)
 		holderGroup.GET("/:id", handlers.GetPumpfuninternalHolder)
-		holderGroup.GET("", handlers.ListPumpfuninternalHolders)
+		holderGroup.GET("", heavyRead, handlers.ListPumpfuninternalHolders)
 		holderGroup.PUT("/:id", handlers.UpdatePumpfuninternalHolder)
 		holderGroup.DELETE("/:id", handlers.DeletePumpfuninternalHolder)
-		holderGroup.POST("/filter", handlers.FilterPumpfuninternalHolders)
+		holderGroup.POST("/filter", heavyRead, handlers.FilterPumpfuninternalHolders)
+		holderGroup.POST("/batch-upsert", handlers.BatchUpsertPumpfuninternalHolders)
 		holderGroup.POST("/project/:project_id", handlers.GetPumpfuninternalHolderByProjectID)
 	}
 
 	// Setup pumpfunammpool swap routes
-	ammSwapGroup := r.Group("/api/pumpfunammpool-swap")
+	ammSwapGroup := r.Group("/api/pumpfunammpool-swap", amountsAsString)
 	{
 		ammSwapGroup.POST("", handlers.CreatePumpfunAmmPoolSwap)
 		ammSwapGroup.GET("/:id", handlers.GetPumpfunAmmPoolSwap)
-		ammSwapGroup.GET("", handlers.ListPumpfunAmmPoolSwaps)
+		ammSwapGroup.GET("", heavyRead, handlers.ListPumpfunAmmPoolSwaps)
 		ammSwapGroup.PUT("/:id", handlers.UpdatePumpfunAmmPoolSwap)
 		ammSwapGroup.DELETE("/:id", handlers.DeletePumpfunAmmPoolSwap)
-		ammSwapGroup.POST("/filter", handlers.FilterPumpfunAmmPoolSwaps)
+		ammSwapGroup.POST("/filter", heavyRead, handlers.FilterPumpfunAmmPoolSwaps)
 		ammSwapGroup.GET("/pool/:pool_id", handlers.ListPumpfunAmmPoolSwapsByPoolID)
 	}
 
 	// Setup pumpfunammpool holder routes
-	ammHolderGroup := r.Group("/api/pumpfunammpool-holder")
+	ammHolderGroup := r.Group("/api/pumpfunammpool-holder", amountsAsString)
 	{
 		ammHolderGroup.POST("", handlers.CreatePumpfunAmmpoolHolder)
 		ammHolderGroup.GET("/:id", handlers.GetPumpfunAmmpoolHolder)
-		ammHolderGroup.GET("", handlers.ListPumpfunAmmpoolHolders)
+		ammHolderGroup.GET("", heavyRead, handlers.ListPumpfunAmmpoolHolders)
 		ammHolderGroup.PUT("/:id", handlers.UpdatePumpfunAmmpoolHolder)
 		ammHolderGroup.DELETE("/:id", handlers.DeletePumpfunAmmpoolHolder)
-		ammHolderGroup.POST("/filter", handlers.FilterPumpfunAmmpoolHolders)
+		ammHolderGroup.POST("/filter", heavyRead, handlers.FilterPumpfunAmmpoolHolders)
+		ammHolderGroup.POST("/batch-upsert", handlers.BatchUpsertPumpfunAmmpoolHolders)
 		ammHolderGroup.POST("/project/:project_id", handlers.GetPumpfunAmmpoolHolderByProjectID)
 	}
 
 	// Setup raydium pool holder routes
-	raydiumHolderGroup := r.Group("/api/raydium-pool-holder")
+	raydiumHolderGroup := r.Group("/api/raydium-pool-holder", amountsAsString)
 	{
 		raydiumHolderGroup.POST("", handlers.CreateRaydiumPoolHolder)
 		raydiumHolderGroup.GET("/:id", handlers.GetRaydiumPoolHolder)
-		raydiumHolderGroup.GET("", handlers.ListRaydiumPoolHolders)
+		raydiumHolderGroup.GET("", heavyRead, handlers.ListRaydiumPoolHolders)
 		raydiumHolderGroup.PUT("/:id", handlers.UpdateRaydiumPoolHolder)
 		raydiumHolderGroup.DELETE("/:id", handlers.DeleteRaydiumPoolHolder)
-		raydiumHolderGroup.POST("/filter", handlers.FilterRaydiumPoolHolders)
+		raydiumHolderGroup.POST("/filter", heavyRead, handlers.FilterRaydiumPoolHolders)
+		raydiumHolderGroup.POST("/batch-upsert", handlers.BatchUpsertRaydiumPoolHolders)
 	}
 
 	// Setup raydium pool swap routes
-	raydiumSwapGroup := r.Group("/api/raydium-pool-swap")
+	raydiumSwapGroup := r.Group("/api/raydium-pool-swap", amountsAsString)
 	{
 		raydiumSwapGroup.POST("", handlers.CreateRaydiumPoolSwap)
 		raydiumSwapGroup.GET("/:id", handlers.GetRaydiumPoolSwap)
-		raydiumSwapGroup.GET("", handlers.ListRaydiumPoolSwaps)
+		raydiumSwapGroup.GET("", heavyRead, handlers.ListRaydiumPoolSwaps)
 		raydiumSwapGroup.PUT("/:id", handlers.UpdateRaydiumPoolSwap)
 		raydiumSwapGroup.DELETE("/:id", handlers.DeleteRaydiumPoolSwap)
-		raydiumSwapGroup.POST("/filter", handlers.FilterRaydiumPoolSwaps)
+		raydiumSwapGroup.POST("/filter", heavyRead, handlers.FilterRaydiumPoolSwaps)
 	}
 
 	// Setup meteoradbc holder routes
-	meteoradbcHolderGroup := r.Group("/api/meteoradbc-holder")
+	meteoradbcHolderGroup := r.Group("/api/meteoradbc-holder", amountsAsString)
 	{
 		meteoradbcHolderGroup.POST("", handlers.CreateMeteoradbcHolder)
 		meteoradbcHolderGroup.GET("/:id", handlers.GetMeteoradbcHolder)
-		meteoradbcHolderGroup.GET("", handlers.ListMeteoradbcHolders)
+		meteoradbcHolderGroup.GET("", heavyRead, handlers.ListMeteoradbcHolders)
 		meteoradbcHolderGroup.PUT("/:id", handlers.UpdateMeteoradbcHolder)
 		meteoradbcHolderGroup.DELETE("/:id", handlers.DeleteMeteoradbcHolder)
-		meteoradbcHolderGroup.POST("/filter", handlers.FilterMeteoradbcHolders)
+		meteoradbcHolderGroup.POST("/filter", heavyRead, handlers.FilterMeteoradbcHolders)
+		meteoradbcHolderGroup.POST("/batch-upsert", handlers.BatchUpsertMeteoradbcHolders)
 		meteoradbcHolderGroup.POST("/project/:project_id", handlers.GetMeteoradbcHolderByProjectID)
 		meteoradbcHolderGroup.POST("/migrate/:poolAddress", handlers.MigrateHolderByPoolAddress)
 	}
 
 	// Setup meteoradbc swap routes
-	meteoradbcSwapGroup := r.Group("/api/meteoradbc-swap")
+	meteoradbcSwapGroup := r.Group("/api/meteoradbc-swap", amountsAsString)
 	{
 		meteoradbcSwapGroup.POST("", handlers.CreateMeteoradbcSwap)
 		meteoradbcSwapGroup.GET("/:id", handlers.GetMeteoradbcSwap)
-		meteoradbcSwapGroup.GET("", handlers.ListMeteoradbcSwaps)
+		meteoradbcSwapGroup.GET("", heavyRead, handlers.ListMeteoradbcSwaps)
 		meteoradbcSwapGroup.PUT("/:id", handlers.UpdateMeteoradbcSwap)
 		meteoradbcSwapGroup.DELETE("/:id", handlers.DeleteMeteoradbcSwap)
-		meteoradbcSwapGroup.POST("/filter", handlers.FilterMeteoradbcSwaps)
+		meteoradbcSwapGroup.POST("/filter", heavyRead, handlers.FilterMeteoradbcSwaps)
+		meteoradbcSwapGroup.POST("/migrate/:poolAddress", handlers.MigrateSwapsByPoolAddress)
 		meteoradbcSwapGroup.GET("/pool/:pool_id", handlers.ListMeteoradbcSwapsByPoolID)
+		meteoradbcSwapGroup.GET("/pool/:pool_id/early-buyers", handlers.GetEarlyBuyers)
+		meteoradbcSwapGroup.GET("/pool/:pool_id/holders-at-slot", heavyRead, handlers.GetHoldersAtSlot)
+		meteoradbcSwapGroup.GET("/pool/:pool_id/reconcile-balances", heavyRead, handlers.ReconcileSwapBalances)
 	}
 
 	// Setup meteoracpmm holder routes
-	meteoracpmmHolderGroup := r.Group("/api/meteoracpmm-holder")
+	meteoracpmmHolderGroup := r.Group("/api/meteoracpmm-holder", amountsAsString)
 	{
 		meteoracpmmHolderGroup.POST("", handlers.CreateMeteoracpmmHolder)
 		meteoracpmmHolderGroup.GET("/:id", handlers.GetMeteoracpmmHolder)
-		meteoracpmmHolderGroup.GET("", handlers.ListMeteoracpmmHolders)
+		meteoracpmmHolderGroup.GET("", heavyRead, handlers.ListMeteoracpmmHolders)
 		meteoracpmmHolderGroup.PUT("/:id", handlers.UpdateMeteoracpmmHolder)
 		meteoracpmmHolderGroup.DELETE("/:id", handlers.DeleteMeteoracpmmHolder)
-		meteoracpmmHolderGroup.POST("/filter", handlers.FilterMeteoracpmmHolders)
+		meteoracpmmHolderGroup.POST("/filter", heavyRead, handlers.FilterMeteoracpmmHolders)
+		meteoracpmmHolderGroup.POST("/batch-upsert", handlers.BatchUpsertMeteoracpmmHolders)
 		meteoracpmmHolderGroup.POST("/project/:project_id", handlers.GetMeteoracpmmHolderByProjectID)
 	}
 
 	// Setup meteoracpmm swap routes
-	meteoracpmmSwapGroup := r.Group("/api/meteoracpmm-swap")
+	meteoracpmmSwapGroup := r.Group("/api/meteoracpmm-swap", amountsAsString)
 	{
 		meteoracpmmSwapGroup.POST("", handlers.CreateMeteoracpmmSwap)
 		meteoracpmmSwapGroup.GET("/:id", handlers.GetMeteoracpmmSwap)
-		meteoracpmmSwapGroup.GET("", handlers.ListMeteoracpmmSwaps)
+		meteoracpmmSwapGroup.GET("", heavyRead, handlers.ListMeteoracpmmSwaps)
 		meteoracpmmSwapGroup.PUT("/:id", handlers.UpdateMeteoracpmmSwap)
 		meteoracpmmSwapGroup.DELETE("/:id", handlers.DeleteMeteoracpmmSwap)
-		meteoracpmmSwapGroup.POST("/filter", handlers.FilterMeteoracpmmSwaps)
+		meteoracpmmSwapGroup.POST("/filter", heavyRead, handlers.FilterMeteoracpmmSwaps)
 		meteoracpmmSwapGroup.GET("/pool/:pool_id", handlers.ListMeteoracpmmSwapsByPoolID)
 	}
 
+	// Setup swap export routes
+	swapExportGroup := r.Group("/api/swap-export")
+	{
+		swapExportGroup.GET("/ndjson", heavyRead, handlers.StreamSwapsNDJSON)
+	}
+
+	// Setup enriched swap routes (swaps joined with address transactions)
+	enrichedSwapGroup := r.Group("/api/swap-enriched", amountsAsString)
+	{
+		enrichedSwapGroup.GET("", heavyRead, handlers.GetEnrichedSwaps)
+	}
+
+	// Setup transaction view routes (every stored row sharing a signature)
+	transactionViewGroup := r.Group("/api/transaction-view", amountsAsString)
+	{
+		transactionViewGroup.GET("/:signature", handlers.GetSwapsBySignatureAllPlatforms)
+	}
+
 	// Setup swap transaction routes
-	swapTransactionGroup := r.Group("/api/swap-transaction")
+	swapTransactionGroup := r.Group("/api/swap-transaction", amountsAsString)
 	{
 		swapTransactionGroup.POST("", handlers.CreateSwapTransaction)
 		swapTransactionGroup.GET("/:id", handlers.GetSwapTransaction)
-		swapTransactionGroup.GET("", handlers.ListSwapTransactions)
+		swapTransactionGroup.GET("", heavyRead, handlers.ListSwapTransactions)
 		swapTransactionGroup.PUT("/:id", handlers.UpdateSwapTransaction)
 		swapTransactionGroup.DELETE("/:id", handlers.DeleteSwapTransaction)
 		swapTransactionGroup.POST("/clean", handlers.CleanSwapTransaction)
-		swapTransactionGroup.POST("/filter", handlers.FilterSwapTransactions)
+		swapTransactionGroup.POST("/filter", heavyRead, handlers.FilterSwapTransactions)
 		swapTransactionGroup.GET("/pool/:pool_id", handlers.ListSwapTransactionsByPoolID)
 		swapTransactionGroup.GET("/project/v2/:project_id", handlers.GetSwapTransactionsByProjectV2)
 		swapTransactionGroup.GET("/project/:project_id", handlers.GetSwapTransactionsByProject)
 	}
 
+	// Setup debug routes (re-run the monitor's swap parser on a signature, nothing is stored)
+	debugGroup := r.Group("/debug")
+	{
+		debugGroup.GET("/swap-parse", handlers.DebugSwapParse)
+		// Row counts of the swap/holder/config/transaction tables, for capacity planning
+		debugGroup.GET("/table-counts", heavyRead, handlers.GetTableCounts)
+	}
 }
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_address_transaction_created_at;
+DROP INDEX IF EXISTS idx_address_balance_change_created_at;
+DROP INDEX IF EXISTS idx_swap_transaction_created_at;
+DROP INDEX IF EXISTS idx_pumpfuninternal_swap_created_at;
+DROP INDEX IF EXISTS idx_pumpfunammpool_swap_created_at;
+DROP INDEX IF EXISTS idx_raydiumpool_swap_created_at;
+DROP INDEX IF EXISTS idx_meteoradbc_swap_created_at;
+DROP INDEX IF EXISTS idx_meteoracpmm_swap_created_at;
+DROP INDEX IF EXISTS idx_pumpfuninternal_holder_created_at;
+DROP INDEX IF EXISTS idx_pumpfunammpool_holder_created_at;
+DROP INDEX IF EXISTS idx_raydiumpool_holder_created_at;
+DROP INDEX IF EXISTS idx_meteoradbc_holder_created_at;
+DROP INDEX IF EXISTS idx_meteoracpmm_holder_created_at;
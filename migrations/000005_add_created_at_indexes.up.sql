@@ -0,0 +1,14 @@
+-- 为按入库时间（created_at）过滤的列表接口建立索引
+CREATE INDEX IF NOT EXISTS idx_address_transaction_created_at ON address_transaction (created_at);
+CREATE INDEX IF NOT EXISTS idx_address_balance_change_created_at ON address_balance_change (created_at);
+CREATE INDEX IF NOT EXISTS idx_swap_transaction_created_at ON swap_transaction (created_at);
+CREATE INDEX IF NOT EXISTS idx_pumpfuninternal_swap_created_at ON pumpfuninternal_swap (created_at);
+CREATE INDEX IF NOT EXISTS idx_pumpfunammpool_swap_created_at ON pumpfunammpool_swap (created_at);
+CREATE INDEX IF NOT EXISTS idx_raydiumpool_swap_created_at ON raydiumpool_swap (created_at);
+CREATE INDEX IF NOT EXISTS idx_meteoradbc_swap_created_at ON meteoradbc_swap (created_at);
+CREATE INDEX IF NOT EXISTS idx_meteoracpmm_swap_created_at ON meteoracpmm_swap (created_at);
+CREATE INDEX IF NOT EXISTS idx_pumpfuninternal_holder_created_at ON pumpfuninternal_holder (created_at);
+CREATE INDEX IF NOT EXISTS idx_pumpfunammpool_holder_created_at ON pumpfunammpool_holder (created_at);
+CREATE INDEX IF NOT EXISTS idx_raydiumpool_holder_created_at ON raydiumpool_holder (created_at);
+CREATE INDEX IF NOT EXISTS idx_meteoradbc_holder_created_at ON meteoradbc_holder (created_at);
+CREATE INDEX IF NOT EXISTS idx_meteoracpmm_holder_created_at ON meteoracpmm_holder (created_at);
@@ -0,0 +1 @@
+-- 不需要回滚：旧基线（上一个项目）的值无法也不应恢复
@@ -0,0 +1,14 @@
+-- 重新计算 project_profit：基线为同一项目最近的一次快照，没有快照时为 0
+-- 覆盖早先以"id 更小的上一个项目"为基线写入的值（profit_baseline_id 当时存的是项目 ID）
+UPDATE project_config pc
+SET project_profit = COALESCE(pc.assets_balance - latest.assets_balance, 0),
+    profit_baseline_id = COALESCE(latest.id, 0)
+FROM project_config p
+LEFT JOIN LATERAL (
+    SELECT ps.id, ps.assets_balance
+    FROM project_snapshots ps
+    WHERE ps.project_id = p.id
+    ORDER BY ps.id DESC
+    LIMIT 1
+) latest ON true
+WHERE pc.id = p.id;
@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	c := NewFake(start)
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), c.Now())
+
+	c.Advance(-time.Hour)
+	assert.Equal(t, start.Add(30*time.Minute), c.Now())
+
+	later := time.Unix(1800000000, 0)
+	c.Set(later)
+	assert.Equal(t, later, c.Now())
+}
+
+func TestRealClock(t *testing.T) {
+	var c Clock = Real{}
+	before := time.Now()
+	now := c.Now()
+	assert.False(t, now.Before(before))
+}
@@ -1,76 +1,135 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// consumerReopenInterval is how often a consumer checks whether the supervisor has re-dialed RabbitMQ
+const consumerReopenInterval = time.Second
+
 type Consumer struct {
+	mu      sync.Mutex
 	conn    *amqp.Connection
 	channel *amqp.Channel
 	queue   string
+	closed  bool
 }
 
+// NewConsumer creates a consumer for the given queue, namespaced with RABBITMQ_QUEUE_PREFIX
 func NewConsumer(queueName string) (*Consumer, error) {
-	ch, err := RabbitMQ.Channel()
-	if err != nil {
+	c := &Consumer{queue: QueueName(queueName)}
+	conn := currentRabbitMQ()
+	if conn == nil {
+		return nil, fmt.Errorf("RabbitMQ connection not initialized")
+	}
+	if err := c.open(conn); err != nil {
 		return nil, err
 	}
+	return c, nil
+}
 
-	q, err := ch.QueueDeclare(
-		queueName,
-		true,  // durable
-		false, // autoDelete
-		false, // exclusive
-		false, // noWait
-		nil,   // args
-	)
+// open declares the queue on a new channel of conn and makes it the consumer's channel
+func (c *Consumer) open(conn *amqp.Connection) error {
+	ch, err := conn.Channel()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &Consumer{
-		conn:    RabbitMQ,
-		channel: ch,
-		queue:   q.Name,
-	}, nil
-}
-
-func (c *Consumer) Consume(handler func([]byte) error) error {
-	msgs, err := c.channel.Consume(
+	_, err = ch.QueueDeclare(
 		c.queue,
-		"",    // consumer
-		false, // autoAck
+		true,  // durable
+		false, // autoDelete
 		false, // exclusive
-		false, // noLocal
 		false, // noWait
 		nil,   // args
 	)
 	if err != nil {
+		ch.Close()
 		return err
 	}
 
-	forever := make(chan bool)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = conn
+	c.channel = ch
+	return nil
+}
+
+// reopen waits for the supervisor to replace the lost connection and opens the queue again.
+// It returns false when the consumer was closed meanwhile.
+func (c *Consumer) reopen() bool {
+	for {
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return false
+		}
 
-	go func() {
-		for msg := range msgs {
-			if err := handler(msg.Body); err != nil {
-				log.Printf("Handle msg failed: %v", err)
-				msg.Nack(false, true) // requeue the message
-			} else {
-				msg.Ack(false) // successfully processed the message
+		// 连接仍可用时只是通道被关闭，直接在原连接上重开
+		if conn := currentRabbitMQ(); conn != nil && !conn.IsClosed() {
+			err := c.open(conn)
+			if err == nil {
+				return true
 			}
+			log.Printf("Failed to re-open consumer for %s: %v", c.queue, err)
 		}
-	}()
+		time.Sleep(consumerReopenInterval)
+	}
+}
 
-	log.Printf("Consumer is running... the port is: %s", c.queue)
-	<-forever
+// Consume delivers messages to handler until Close is called. When the connection drops the consumer
+// waits for the supervisor to re-dial RabbitMQ and resumes on the new connection.
+func (c *Consumer) Consume(handler func([]byte) error) error {
+	for reopened := false; ; reopened = true {
+		c.mu.Lock()
+		ch := c.channel
+		c.mu.Unlock()
 
-	return nil
+		msgs, err := ch.Consume(
+			c.queue,
+			"",    // consumer
+			false, // autoAck
+			false, // exclusive
+			false, // noLocal
+			false, // noWait
+			nil,   // args
+		)
+		switch {
+		case err != nil && !reopened:
+			return err
+		case err != nil:
+			log.Printf("Failed to resume consumer for %s: %v", c.queue, err)
+			time.Sleep(consumerReopenInterval)
+		default:
+			log.Printf("Consumer is running... the port is: %s", c.queue)
+			for msg := range msgs {
+				if err := handler(msg.Body); err != nil {
+					log.Printf("Handle msg failed: %v", err)
+					msg.Nack(false, true) // requeue the message
+				} else {
+					msg.Ack(false) // successfully processed the message
+				}
+			}
+			// 投递通道关闭：主动 Close 时退出，否则等待重连后重新订阅
+			log.Printf("Consumer channel for %s closed, waiting for RabbitMQ to reconnect", c.queue)
+		}
+
+		if !c.reopen() {
+			return nil
+		}
+	}
 }
 
 func (c *Consumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
 	if err := c.channel.Close(); err != nil {
 		return err
 	}
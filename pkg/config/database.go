@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"marketcontrol/internal/models"
@@ -14,15 +18,111 @@ import (
 
 var DB *gorm.DB
 
-// InitDB initializes the database connection
+// dbMu serializes InitDB and CloseDB so concurrent or repeated calls open a single pool
+var dbMu sync.Mutex
+
+// secureSSLModes are the libpq sslmode values that guarantee an encrypted connection
+var secureSSLModes = map[string]bool{
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+var validSSLModes = map[string]bool{
+	"disable":     true,
+	"allow":       true,
+	"prefer":      true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+// dsnValue quotes a DSN value so passwords and paths containing spaces or quotes survive parsing
+func dsnValue(v string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// buildDSN assembles the postgres DSN from environment variables.
+//
+// DB_SSLMODE sets the libpq sslmode (default: disable). DB_TLS=true requires an encrypted
+// connection: sslmode defaults to require and insecure modes are rejected.
+// DB_SSLROOTCERT, DB_SSLCERT and DB_SSLKEY configure server verification and client certificates.
+// DB_PASSWORD_FILE reads the password from a file (e.g. a mounted secret) instead of DB_PASSWORD.
+func buildDSN() (string, error) {
+	requireTLS, _ := strconv.ParseBool(os.Getenv("DB_TLS"))
+
+	sslMode := strings.ToLower(strings.TrimSpace(os.Getenv("DB_SSLMODE")))
+	if sslMode == "" {
+		sslMode = "disable"
+		if requireTLS {
+			sslMode = "require"
+		}
+	}
+	if !validSSLModes[sslMode] {
+		return "", fmt.Errorf("invalid DB_SSLMODE %q", sslMode)
+	}
+	if requireTLS && !secureSSLModes[sslMode] {
+		return "", fmt.Errorf("DB_TLS=true requires DB_SSLMODE to be require, verify-ca or verify-full, got %q", sslMode)
+	}
+
+	rootCert := os.Getenv("DB_SSLROOTCERT")
+	if (sslMode == "verify-ca" || sslMode == "verify-full") && rootCert == "" {
+		return "", fmt.Errorf("DB_SSLMODE=%s requires DB_SSLROOTCERT", sslMode)
+	}
+	clientCert, clientKey := os.Getenv("DB_SSLCERT"), os.Getenv("DB_SSLKEY")
+	if (clientCert == "") != (clientKey == "") {
+		return "", fmt.Errorf("DB_SSLCERT and DB_SSLKEY must be set together")
+	}
+	for _, path := range []string{rootCert, clientCert, clientKey} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("database TLS file not accessible: %w", err)
+		}
+	}
+
+	password := os.Getenv("DB_PASSWORD")
+	if passwordFile := os.Getenv("DB_PASSWORD_FILE"); passwordFile != "" {
+		content, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read DB_PASSWORD_FILE: %w", err)
+		}
+		password = strings.TrimRight(string(content), "\r\n")
+	}
+
+	parts := []string{
+		"host=" + dsnValue(os.Getenv("DB_HOST")),
+		"user=" + dsnValue(os.Getenv("DB_USER")),
+		"password=" + dsnValue(password),
+		"dbname=" + dsnValue(os.Getenv("DB_NAME")),
+		"port=" + dsnValue(os.Getenv("DB_PORT")),
+		"sslmode=" + sslMode,
+	}
+	if rootCert != "" {
+		parts = append(parts, "sslrootcert="+dsnValue(rootCert))
+	}
+	if clientCert != "" {
+		parts = append(parts, "sslcert="+dsnValue(clientCert), "sslkey="+dsnValue(clientKey))
+	}
+	parts = append(parts, "TimeZone=Asia/Shanghai")
+
+	return strings.Join(parts, " "), nil
+}
+
+// InitDB initializes the database connection and migrates the schema.
+// It is idempotent: once DB is set, further calls return immediately until CloseDB is called.
 func InitDB() {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-	)
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	if DB != nil {
+		return
+	}
+
+	dsn, err := buildDSN()
+	if err != nil {
+		log.Fatal("Invalid database configuration: ", err)
+	}
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
@@ -69,6 +169,7 @@ func InitDB() {
 		&models.WalletTokenSnapshot{},
 		&models.PoolSnapshot{},
 		&models.PumpfuninternalSnapshot{},
+		&models.ProjectSnapshot{},
 		&models.TokenAccount{},
 		&models.TokenMetadata{},
 		&models.TransactionsMonitorConfig{},
@@ -104,10 +205,162 @@ func InitDB() {
 		&models.MeteoracpmmPoolStat{},
 		&models.SystemLog{},
 		&models.SwapTransaction{},
+		&models.MonitorErrorState{},
+		&models.Backfill{},
 		&models.SystemParams{},
 		&models.SystemCommand{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+
+	ensureUniqueKeyIndexes()
+	ensureCreatedAtIndexes()
+}
+
+// CloseDB closes the connection pool opened by InitDB and resets DB, so a later InitDB reconnects
+func CloseDB() error {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	if DB == nil {
+		return nil
+	}
+	sqlDB, err := DB.DB()
+	DB = nil
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// uniqueKeyIndexes are the conflict targets used by the upsert endpoints (holder batch-upsert,
+// transactions monitor config upsert)
+var uniqueKeyIndexes = []struct {
+	name    string
+	table   string
+	columns string
+}{
+	{"idx_pumpfuninternal_holder_key", "pumpfuninternal_holder", "address, bonding_curve_pda, mint"},
+	{"idx_pumpfunammpool_holder_key", "pumpfunammpool_holder", "address, pool_address, base_mint, quote_mint"},
+	{"idx_raydiumpool_holder_key", "raydiumpool_holder", "address, pool_address, base_mint, quote_mint"},
+	{"idx_meteoradbc_holder_key", "meteoradbc_holder", "address, pool_address, base_mint, quote_mint"},
+	{"idx_meteoracpmm_holder_key", "meteoracpmm_holder", "address, pool_address, base_mint, quote_mint"},
+	{"idx_transactions_monitor_config_address", "transactions_monitor_config", "address"},
+}
+
+var (
+	// ErrUniqueIndexBuilding means the upsert unique index of a table is not built (yet); retry later
+	ErrUniqueIndexBuilding = errors.New("unique index is not built yet")
+	// ErrUniqueIndexFailed means building the upsert unique index failed, usually because of duplicate keys
+	ErrUniqueIndexFailed = errors.New("unique index could not be built")
+)
+
+var (
+	uniqueIndexMu     sync.Mutex
+	uniqueIndexReady  = make(map[string]bool)  // table -> index valid
+	uniqueIndexErrors = make(map[string]error) // table -> last build error
+)
+
+// ensureUniqueKeyIndexes builds the upsert unique indexes concurrently in the background, like the created_at
+// indexes. 已有重复数据时构建失败只记录警告，清理重复记录后重启即可补建；在此之前 UniqueKeyIndexReady 报告失败原因
+func ensureUniqueKeyIndexes() {
+	go buildUniqueKeyIndexes(DB)
+}
+
+func buildUniqueKeyIndexes(db *gorm.DB) {
+	for _, idx := range uniqueKeyIndexes {
+		sql := fmt.Sprintf("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s)", idx.name, idx.table, idx.columns)
+		err := buildIndexConcurrently(db, idx.name, sql)
+		uniqueIndexMu.Lock()
+		if err != nil {
+			uniqueIndexErrors[idx.table] = err
+		} else {
+			delete(uniqueIndexErrors, idx.table)
+		}
+		uniqueIndexMu.Unlock()
+		if err != nil {
+			log.Printf("Warning: failed to create unique index %s on %s: %v", idx.name, idx.table, err)
+		}
+	}
+}
+
+// UniqueKeyIndexReady reports whether the upsert unique index of table exists and is valid. It returns
+// ErrUniqueIndexFailed (wrapping the build error) when the build failed and ErrUniqueIndexBuilding otherwise.
+func UniqueKeyIndexReady(db *gorm.DB, table string) error {
+	uniqueIndexMu.Lock()
+	ready := uniqueIndexReady[table]
+	uniqueIndexMu.Unlock()
+	if ready {
+		return nil
+	}
+
+	name := ""
+	for _, idx := range uniqueKeyIndexes {
+		if idx.table == table {
+			name = idx.name
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("no unique index is defined for %s", table)
+	}
+
+	var valid []bool
+	if err := db.Raw("SELECT indisvalid FROM pg_index WHERE indexrelid = to_regclass(?)", name).Scan(&valid).Error; err != nil {
+		return err
+	}
+	uniqueIndexMu.Lock()
+	defer uniqueIndexMu.Unlock()
+	if len(valid) == 1 && valid[0] {
+		uniqueIndexReady[table] = true
+		return nil
+	}
+	if err := uniqueIndexErrors[table]; err != nil {
+		return fmt.Errorf("%w: %s on %s: %v", ErrUniqueIndexFailed, name, table, err)
+	}
+	return fmt.Errorf("%w: %s on %s", ErrUniqueIndexBuilding, name, table)
+}
+
+// buildIndexConcurrently runs a CREATE INDEX CONCURRENTLY IF NOT EXISTS statement for index name. An interrupted
+// or failed CONCURRENTLY build leaves an INVALID index that IF NOT EXISTS would keep forever, so such an index is
+// dropped and rebuilt.
+func buildIndexConcurrently(db *gorm.DB, name, createSQL string) error {
+	var invalid bool
+	if err := db.Raw("SELECT NOT indisvalid FROM pg_index WHERE indexrelid = to_regclass(?)", name).Scan(&invalid).Error; err != nil {
+		return fmt.Errorf("failed to check index: %w", err)
+	}
+	if invalid {
+		log.Printf("Index %s is invalid, rebuilding it", name)
+		if err := db.Exec("DROP INDEX CONCURRENTLY IF EXISTS " + name).Error; err != nil {
+			return fmt.Errorf("failed to drop invalid index: %w", err)
+		}
+	}
+	return db.Exec(createSQL).Error
+}
+
+// createdAtIndexedTables back the created_after/created_before filters of the swap, holder and transaction lists
+var createdAtIndexedTables = []string{
+	"address_transaction", "address_balance_change", "swap_transaction",
+	"pumpfuninternal_swap", "pumpfunammpool_swap", "raydiumpool_swap", "meteoradbc_swap", "meteoracpmm_swap",
+	"pumpfuninternal_holder", "pumpfunammpool_holder", "raydiumpool_holder", "meteoradbc_holder", "meteoracpmm_holder",
+}
+
+// ensureCreatedAtIndexes builds the created_at indexes concurrently in the background, so large tables stay
+// writable and startup does not wait for the builds.
+// DB_CREATED_AT_INDEXES=false skips it (e.g. when migration 000005 is applied by hand instead)
+func ensureCreatedAtIndexes() {
+	if enabled, err := strconv.ParseBool(os.Getenv("DB_CREATED_AT_INDEXES")); err == nil && !enabled {
+		return
+	}
+	go buildCreatedAtIndexes(DB)
+}
+
+// buildCreatedAtIndexes creates the missing created_at indexes one at a time
+func buildCreatedAtIndexes(db *gorm.DB) {
+	for _, table := range createdAtIndexedTables {
+		name := fmt.Sprintf("idx_%s_created_at", table)
+		sql := fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (created_at)", name, table)
+		if err := buildIndexConcurrently(db, name, sql); err != nil {
+			log.Printf("Warning: failed to create created_at index on %s: %v", table, err)
+		}
+	}
 }
@@ -16,23 +16,27 @@ type Publisher struct {
 
 // NewPublisher creates a new RabbitMQ publisher
 func NewPublisher() (*Publisher, error) {
-	if RabbitMQ == nil {
+	conn := currentRabbitMQ()
+	if conn == nil {
 		return nil, fmt.Errorf("RabbitMQ connection not initialized")
 	}
 
-	ch, err := RabbitMQ.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
 	return &Publisher{
-		conn:    RabbitMQ,
+		conn:    conn,
 		channel: ch,
 	}, nil
 }
 
 // Publish publishes a message to the specified queue
+// The queue name is namespaced with RABBITMQ_QUEUE_PREFIX
 func (p *Publisher) Publish(queueName string, message interface{}) error {
+	queueName = QueueName(queueName)
+
 	// Declare queue
 	_, err := p.channel.QueueDeclare(
 		queueName,
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -11,46 +13,285 @@ import (
 
 var RabbitMQ *amqp.Connection
 
-// InitRabbitMQ RabbitMQ with retry logic
+var (
+	rabbitMQInitMu     sync.Mutex
+	rabbitMQMu         sync.Mutex
+	rabbitMQState      RabbitMQState
+	rabbitMQSupervisor sync.Once
+	rabbitMQClosed     bool // set by CloseRabbitMQ; later dials are discarded
+	rabbitMQStop       = make(chan struct{})
+	rabbitMQStopOnce   sync.Once
+)
+
+// RabbitMQState describes the RabbitMQ connection as reported by health checks
+type RabbitMQState struct {
+	Connected           bool       `json:"connected"`
+	CircuitOpen         bool       `json:"circuit_open"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastAttempt         *time.Time `json:"last_attempt,omitempty"`
+	NextAttempt         *time.Time `json:"next_attempt,omitempty"`
+}
+
+// rabbitMQRetryConfig controls connection retries, configured from the environment:
+// RABBITMQ_INIT_RETRIES (default 10), RABBITMQ_RETRY_DELAY (default 3s, doubled per attempt),
+// RABBITMQ_RETRY_MAX_DELAY (default 30s) and RABBITMQ_CIRCUIT_COOLDOWN (default 1m).
+type rabbitMQRetryConfig struct {
+	attempts  int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	cooldown  time.Duration
+}
+
+func loadRabbitMQRetryConfig() rabbitMQRetryConfig {
+	cfg := rabbitMQRetryConfig{
+		attempts:  10,
+		baseDelay: 3 * time.Second,
+		maxDelay:  30 * time.Second,
+		cooldown:  time.Minute,
+	}
+	if v := os.Getenv("RABBITMQ_INIT_RETRIES"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			cfg.attempts = parsed
+		}
+	}
+	for key, target := range map[string]*time.Duration{
+		"RABBITMQ_RETRY_DELAY":      &cfg.baseDelay,
+		"RABBITMQ_RETRY_MAX_DELAY":  &cfg.maxDelay,
+		"RABBITMQ_CIRCUIT_COOLDOWN": &cfg.cooldown,
+	} {
+		if v := os.Getenv(key); v != "" {
+			if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+				*target = parsed
+			} else {
+				log.Printf("Invalid %s=%q, using default %s", key, v, *target)
+			}
+		}
+	}
+	if cfg.maxDelay < cfg.baseDelay {
+		cfg.maxDelay = cfg.baseDelay
+	}
+	return cfg
+}
+
+// nextDelay doubles the retry delay up to the configured maximum
+func (cfg rabbitMQRetryConfig) nextDelay(delay time.Duration) time.Duration {
+	delay *= 2
+	if delay > cfg.maxDelay {
+		delay = cfg.maxDelay
+	}
+	return delay
+}
+
+// InitRabbitMQ RabbitMQ with retry logic; exits when the broker stays unreachable.
+// Once connected, a background supervisor re-dials if the connection drops.
+// Calls while a connection is open are no-ops.
 func InitRabbitMQ() {
-	url := fmt.Sprintf("amqp://%s:%s@%s:%s/",
+	rabbitMQInitMu.Lock()
+	defer rabbitMQInitMu.Unlock()
+	if rabbitMQConnected() {
+		return
+	}
+	if err := ConnectRabbitMQ(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	StartRabbitMQSupervisor()
+}
+
+// InitRabbitMQOptional makes a single connection attempt and never blocks or exits: if the broker is
+// unreachable at boot, the background supervisor keeps retrying so it gets connected without a restart.
+// Callers must keep treating CurrentRabbitMQ() == nil as "not available".
+func InitRabbitMQOptional() {
+	rabbitMQInitMu.Lock()
+	defer rabbitMQInitMu.Unlock()
+	if rabbitMQConnected() {
+		return
+	}
+	if err := dialRabbitMQ(); err != nil {
+		log.Printf("Failed to connect to RabbitMQ: %v; will keep retrying in the background", err)
+	}
+	StartRabbitMQSupervisor()
+}
+
+func rabbitMQConnected() bool {
+	conn := currentRabbitMQ()
+	return conn != nil && !conn.IsClosed()
+}
+
+// currentRabbitMQ reads RabbitMQ under rabbitMQMu; the supervisor replaces it when it re-dials
+func currentRabbitMQ() *amqp.Connection {
+	rabbitMQMu.Lock()
+	defer rabbitMQMu.Unlock()
+	return RabbitMQ
+}
+
+// CurrentRabbitMQ returns the current RabbitMQ connection, or nil when it is not (yet) connected.
+// Use it instead of reading RabbitMQ directly: the supervisor replaces the connection from another goroutine.
+func CurrentRabbitMQ() *amqp.Connection {
+	return currentRabbitMQ()
+}
+
+// CloseRabbitMQ stops the supervisor and closes the connection, so shutting down does not trigger a re-dial
+func CloseRabbitMQ() {
+	rabbitMQStopOnce.Do(func() { close(rabbitMQStop) })
+
+	rabbitMQMu.Lock()
+	defer rabbitMQMu.Unlock()
+	rabbitMQClosed = true
+	if conn := RabbitMQ; conn != nil && !conn.IsClosed() {
+		conn.Close()
+	}
+	rabbitMQState.Connected = false
+}
+
+// ConnectRabbitMQ dials RabbitMQ with exponential backoff and sets RabbitMQ on success
+func ConnectRabbitMQ() error {
+	cfg := loadRabbitMQRetryConfig()
+	delay := cfg.baseDelay
+
+	var err error
+	for i := 0; i < cfg.attempts; i++ {
+		if err = dialRabbitMQ(); err == nil {
+			return nil
+		}
+
+		if i < cfg.attempts-1 {
+			log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v. Retrying in %v...", i+1, cfg.attempts, err, delay)
+			time.Sleep(delay)
+			delay = cfg.nextDelay(delay)
+		}
+	}
+	return fmt.Errorf("failed to connect to RabbitMQ after %d attempts: %w", cfg.attempts, err)
+}
+
+// dialRabbitMQ makes a single connection attempt and records the outcome
+func dialRabbitMQ() error {
+	conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%s/",
 		os.Getenv("RABBITMQ_USER"),
 		os.Getenv("RABBITMQ_PASSWORD"),
 		os.Getenv("RABBITMQ_HOST"),
 		os.Getenv("RABBITMQ_PORT"),
-	)
+	))
 
-	maxRetries := 10
-	retryDelay := 3 * time.Second
+	now := time.Now()
+	rabbitMQMu.Lock()
+	defer rabbitMQMu.Unlock()
+	if rabbitMQClosed {
+		if err == nil {
+			conn.Close()
+		}
+		return fmt.Errorf("RabbitMQ connection closed")
+	}
+	rabbitMQState.LastAttempt = &now
+	rabbitMQState.NextAttempt = nil
+	if err != nil {
+		rabbitMQState.Connected = false
+		rabbitMQState.ConsecutiveFailures++
+		rabbitMQState.LastError = err.Error()
+		return err
+	}
 
-	var conn *amqp.Connection
-	var err error
+	// 重复初始化时关闭旧连接，避免泄漏
+	if previous := RabbitMQ; previous != nil && !previous.IsClosed() {
+		previous.Close()
+	}
+	RabbitMQ = conn
+	rabbitMQState = RabbitMQState{Connected: true, LastAttempt: &now}
+	log.Printf("Successfully connected to RabbitMQ at %s", os.Getenv("RABBITMQ_HOST"))
+	return nil
+}
 
-	for i := 0; i < maxRetries; i++ {
-		conn, err = amqp.Dial(url)
-		if err == nil {
-			RabbitMQ = conn
-			log.Printf("Successfully connected to RabbitMQ at %s", os.Getenv("RABBITMQ_HOST"))
+// StartRabbitMQSupervisor starts (once) a goroutine that re-dials RabbitMQ whenever the connection
+// is missing or closed. Retries back off exponentially; after RABBITMQ_INIT_RETRIES consecutive
+// failures the circuit opens and attempts pause for RABBITMQ_CIRCUIT_COOLDOWN before probing again.
+// CloseRabbitMQ stops it.
+func StartRabbitMQSupervisor() {
+	rabbitMQSupervisor.Do(func() {
+		go superviseRabbitMQ(loadRabbitMQRetryConfig())
+	})
+}
+
+func superviseRabbitMQ(cfg rabbitMQRetryConfig) {
+	delay := cfg.baseDelay
+	for {
+		select {
+		case <-rabbitMQStop:
 			return
+		default:
+		}
+
+		if conn := currentRabbitMQ(); conn != nil && !conn.IsClosed() {
+			// 阻塞直到连接关闭
+			select {
+			case amqpErr := <-conn.NotifyClose(make(chan *amqp.Error, 1)):
+				if amqpErr != nil {
+					log.Printf("RabbitMQ connection lost: %v, reconnecting...", amqpErr)
+				}
+			case <-rabbitMQStop:
+				return
+			}
+			rabbitMQMu.Lock()
+			rabbitMQState.Connected = false
+			rabbitMQMu.Unlock()
+			delay = cfg.baseDelay
+			continue
+		}
+
+		if err := dialRabbitMQ(); err == nil {
+			delay = cfg.baseDelay
+			continue
+		}
+
+		wait := delay
+		rabbitMQMu.Lock()
+		if rabbitMQState.ConsecutiveFailures >= cfg.attempts {
+			if !rabbitMQState.CircuitOpen {
+				log.Printf("RabbitMQ unreachable after %d attempts, pausing retries for %v", rabbitMQState.ConsecutiveFailures, cfg.cooldown)
+			}
+			rabbitMQState.CircuitOpen = true
+			wait = cfg.cooldown
+		} else {
+			delay = cfg.nextDelay(delay)
 		}
+		next := time.Now().Add(wait)
+		rabbitMQState.NextAttempt = &next
+		rabbitMQMu.Unlock()
 
-		if i < maxRetries-1 {
-			log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v. Retrying in %v...", i+1, maxRetries, err, retryDelay)
-			time.Sleep(retryDelay)
+		select {
+		case <-time.After(wait):
+		case <-rabbitMQStop:
+			return
 		}
 	}
+}
+
+// RabbitMQStatus returns a snapshot of the current RabbitMQ connection state
+func RabbitMQStatus() RabbitMQState {
+	rabbitMQMu.Lock()
+	defer rabbitMQMu.Unlock()
+	state := rabbitMQState
+	if conn := RabbitMQ; conn == nil || conn.IsClosed() {
+		state.Connected = false
+	}
+	return state
+}
 
-	log.Fatalf("Failed to connect to RabbitMQ after %d attempts: %v", maxRetries, err)
+// QueueName applies the RABBITMQ_QUEUE_PREFIX namespace to a queue name
+// An empty prefix (default) keeps the original name for backward compatibility
+func QueueName(name string) string {
+	return os.Getenv("RABBITMQ_QUEUE_PREFIX") + name
 }
 
 // DeleteQueue deletes a RabbitMQ queue by name
 // If the queue doesn't exist, it will return an error
 func DeleteQueue(queueName string) error {
-	if RabbitMQ == nil {
+	conn := currentRabbitMQ()
+	if conn == nil {
 		return fmt.Errorf("RabbitMQ connection not initialized")
 	}
+	queueName = QueueName(queueName)
 
-	ch, err := RabbitMQ.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open channel: %w", err)
 	}
@@ -76,11 +317,13 @@ func DeleteQueue(queueName string) error {
 
 // PurgeQueue removes all messages from a queue without deleting the queue itself
 func PurgeQueue(queueName string) error {
-	if RabbitMQ == nil {
+	conn := currentRabbitMQ()
+	if conn == nil {
 		return fmt.Errorf("RabbitMQ connection not initialized")
 	}
+	queueName = QueueName(queueName)
 
-	ch, err := RabbitMQ.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open channel: %w", err)
 	}
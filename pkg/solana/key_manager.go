@@ -12,9 +12,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/blocto/solana-go-sdk/types"
 	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/scrypt"
 )
 
 // KeyStoreEntry represents a keystore entry with metadata
@@ -24,14 +28,19 @@ type KeyStoreEntry struct {
 	Version      int    `json:"version"`
 }
 
-// KeyManager handles Solana key pair generation, encryption, and decryption
+// KeyManager handles Solana key pair generation, encryption, and decryption.
+// Create one per request or batch: blobs it encrypts share one salt, so the scrypt key is derived once
+// for the batch, and derived keys are cached so blobs sharing a salt are decrypted without re-deriving.
+// It is safe for concurrent use.
 type KeyManager struct {
-	// No fields needed for now
+	mu      sync.Mutex
+	keys    map[string][]byte // derived keys by version, salt and password
+	encSalt map[string][]byte // salt used for new blobs, by password
 }
 
 // NewKeyManager creates a new KeyManager instance
 func NewKeyManager() *KeyManager {
-	return &KeyManager{}
+	return &KeyManager{keys: make(map[string][]byte), encSalt: make(map[string][]byte)}
 }
 
 // GenerateKeyPair generates a new Solana key pair
@@ -40,17 +49,112 @@ func (km *KeyManager) GenerateKeyPair() (*types.Account, error) {
 	return &account, nil
 }
 
-// EncryptPrivateKey encrypts a private key using AES-256-GCM
+// Encrypted key formats
+//
+// v1 (legacy): base64(nonce | ciphertext), key = SHA-256(password). No prefix.
+// v2:          "v2:" + base64(salt | nonce | ciphertext), key = scrypt(password, salt).
+//
+// New blobs are always written with CurrentKeyVersion; older versions stay readable
+// and can be rewritten with UpgradeEncryptedKey. To strengthen the KDF, add a new
+// entry to kdfVersions and bump CurrentKeyVersion.
+const (
+	LegacyKeyVersion  = 1
+	CurrentKeyVersion = 2
+
+	keyVersionPrefix = "v"
+	keyVersionSep    = ":"
+	kdfSaltSize      = 16
+)
+
+// kdfParams holds the scrypt parameters for a blob version
+type kdfParams struct {
+	N, R, P int
+}
+
+var kdfVersions = map[int]kdfParams{
+	2: {N: 1 << 15, R: 8, P: 1}, // ~32 MB and tens of ms per derivation
+}
+
+// defaultKDFConcurrency bounds simultaneous scrypt derivations across the process (4 x 32 MB);
+// override with KEY_KDF_CONCURRENCY
+const defaultKDFConcurrency = 4
+
+var kdfSlots = make(chan struct{}, kdfConcurrency())
+
+func kdfConcurrency() int {
+	if v := os.Getenv("KEY_KDF_CONCURRENCY"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultKDFConcurrency
+}
+
+// derive returns the key for version/salt/password, deriving it at most once per KeyManager.
+// Derivations wait for a kdfSlots slot so concurrent callers stay within the memory budget.
+func (km *KeyManager) derive(version int, salt []byte, password string) ([]byte, error) {
+	params, ok := kdfVersions[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encrypted key version: %d", version)
+	}
+	cacheKey := strconv.Itoa(version) + keyVersionSep + string(salt) + keyVersionSep + password
+
+	km.mu.Lock()
+	if km.keys == nil {
+		km.keys = make(map[string][]byte)
+	}
+	key, ok := km.keys[cacheKey]
+	km.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	kdfSlots <- struct{}{}
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, 32)
+	<-kdfSlots
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	km.mu.Lock()
+	km.keys[cacheKey] = key
+	km.mu.Unlock()
+	return key, nil
+}
+
+// encryptionSalt returns the salt this KeyManager writes new blobs with for password, generating it once
+func (km *KeyManager) encryptionSalt(password string) ([]byte, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if km.encSalt == nil {
+		km.encSalt = make(map[string][]byte)
+	}
+	if salt, ok := km.encSalt[password]; ok {
+		return salt, nil
+	}
+	salt := make([]byte, kdfSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	km.encSalt[password] = salt
+	return salt, nil
+}
+
+// EncryptPrivateKey encrypts a private key using AES-256-GCM with a key derived by the current KDF version
 func (km *KeyManager) EncryptPrivateKey(privateKey []byte, password string) (string, error) {
-	key := deriveKey(password) // 32-byte key for AES-256
-	block, err := aes.NewCipher(key)
+	salt, err := km.encryptionSalt(password)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+		return "", err
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	key, err := km.derive(CurrentKeyVersion, salt, password)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(key)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return "", err
 	}
 
 	nonce := make([]byte, gcm.NonceSize())
@@ -58,28 +162,44 @@ func (km *KeyManager) EncryptPrivateKey(privateKey []byte, password string) (str
 		return "", fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// Combine nonce and ciphertext for storage
-	ciphertext := gcm.Seal(nonce, nonce, privateKey, nil)
-	encoded := base64.StdEncoding.EncodeToString(ciphertext)
-	return encoded, nil
+	// Combine salt, nonce and ciphertext for storage
+	blob := append(append([]byte{}, salt...), nonce...)
+	blob = gcm.Seal(blob, nonce, privateKey, nil)
+	return fmt.Sprintf("%s%d%s%s", keyVersionPrefix, CurrentKeyVersion, keyVersionSep, base64.StdEncoding.EncodeToString(blob)), nil
 }
 
-// DecryptPrivateKey decrypts a private key using AES-256-GCM
+// DecryptPrivateKey decrypts a private key in any supported blob version
 func (km *KeyManager) DecryptPrivateKey(encryptedKey string, password string) ([]byte, error) {
-	ciphertext, err := base64.StdEncoding.DecodeString(encryptedKey)
+	version, payload, err := parseEncryptedKey(encryptedKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64: %w", err)
+		return nil, err
 	}
 
-	key := deriveKey(password)
-	block, err := aes.NewCipher(key)
+	ciphertext, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cipher: %w", err)
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	var key []byte
+	if version == LegacyKeyVersion {
+		key = deriveKey(password)
+	} else {
+		if _, ok := kdfVersions[version]; !ok {
+			return nil, fmt.Errorf("unsupported encrypted key version: %d", version)
+		}
+		if len(ciphertext) < kdfSaltSize {
+			return nil, errors.New("ciphertext too short")
+		}
+		salt := ciphertext[:kdfSaltSize]
+		ciphertext = ciphertext[kdfSaltSize:]
+		if key, err = km.derive(version, salt, password); err != nil {
+			return nil, err
+		}
+	}
+
+	gcm, err := newGCM(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GCM: %w", err)
+		return nil, err
 	}
 
 	if len(ciphertext) < gcm.NonceSize() {
@@ -97,6 +217,66 @@ func (km *KeyManager) DecryptPrivateKey(encryptedKey string, password string) ([
 	return plaintext, nil
 }
 
+// EncryptedKeyVersion returns the blob version of an encrypted key
+func (km *KeyManager) EncryptedKeyVersion(encryptedKey string) (int, error) {
+	version, _, err := parseEncryptedKey(encryptedKey)
+	return version, err
+}
+
+// NeedsUpgrade reports whether an encrypted key was written with an older KDF version
+func (km *KeyManager) NeedsUpgrade(encryptedKey string) bool {
+	version, _, err := parseEncryptedKey(encryptedKey)
+	return err == nil && version < CurrentKeyVersion
+}
+
+// UpgradeEncryptedKey re-encrypts a key with the current version if it uses an older one.
+// It returns the (possibly unchanged) blob and whether it was rewritten.
+func (km *KeyManager) UpgradeEncryptedKey(encryptedKey string, password string) (string, bool, error) {
+	if !km.NeedsUpgrade(encryptedKey) {
+		return encryptedKey, false, nil
+	}
+	privateKey, err := km.DecryptPrivateKey(encryptedKey, password)
+	if err != nil {
+		return "", false, err
+	}
+	upgraded, err := km.EncryptPrivateKey(privateKey, password)
+	if err != nil {
+		return "", false, err
+	}
+	return upgraded, true, nil
+}
+
+// parseEncryptedKey splits a blob into its version and base64 payload.
+// Blobs without a version prefix are legacy v1 (':' never occurs in standard base64).
+func parseEncryptedKey(encryptedKey string) (int, string, error) {
+	if !strings.HasPrefix(encryptedKey, keyVersionPrefix) {
+		return LegacyKeyVersion, encryptedKey, nil
+	}
+	idx := strings.Index(encryptedKey, keyVersionSep)
+	if idx < 0 {
+		return LegacyKeyVersion, encryptedKey, nil
+	}
+	version, err := strconv.Atoi(encryptedKey[len(keyVersionPrefix):idx])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid encrypted key version: %w", err)
+	}
+	return version, encryptedKey[idx+len(keyVersionSep):], nil
+}
+
+// newGCM creates an AES-256-GCM cipher for the given key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
 // SaveEncryptedKeyToFile saves an encrypted private key to a file
 func (km *KeyManager) SaveEncryptedKeyToFile(encryptedKey string, filename string) error {
 	// Ensure the keystore directory exists
@@ -143,7 +323,7 @@ func (km *KeyManager) SaveKeyStoreEntry(account *types.Account, password string)
 	entry := KeyStoreEntry{
 		Address:      address,
 		EncryptedKey: encrypted,
-		Version:      1,
+		Version:      CurrentKeyVersion,
 	}
 
 	// Convert to JSON
@@ -213,7 +393,7 @@ func (km *KeyManager) GetSolanaAddressFromPrivateKey(privateKey []byte) (string,
 	return account.PublicKey.ToBase58(), nil
 }
 
-// deriveKey creates a 32-byte key from a password using SHA-256
+// deriveKey creates a 32-byte key from a password using SHA-256 (legacy v1 blobs only)
 func deriveKey(password string) []byte {
 	hash := sha256.Sum256([]byte(password))
 	return hash[:]
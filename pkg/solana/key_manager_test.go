@@ -1,6 +1,7 @@
 package solana
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -47,6 +48,66 @@ func TestKeyManager(t *testing.T) {
 		}
 	})
 
+	// Test legacy (v1) blobs stay readable and can be upgraded
+	t.Run("Decrypt and Upgrade Legacy Key", func(t *testing.T) {
+		account, err := km.GenerateKeyPair()
+		require.NoError(t, err)
+
+		password := "test-password"
+		gcm, err := newGCM(deriveKey(password))
+		require.NoError(t, err)
+		nonce := make([]byte, gcm.NonceSize())
+		legacy := base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, account.PrivateKey, nil))
+
+		assert.True(t, km.NeedsUpgrade(legacy))
+		decrypted, err := km.DecryptPrivateKey(legacy, password)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(account.PrivateKey[:], decrypted))
+
+		upgraded, changed, err := km.UpgradeEncryptedKey(legacy, password)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.False(t, km.NeedsUpgrade(upgraded))
+
+		version, err := km.EncryptedKeyVersion(upgraded)
+		require.NoError(t, err)
+		assert.Equal(t, CurrentKeyVersion, version)
+
+		decrypted, err = km.DecryptPrivateKey(upgraded, password)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(account.PrivateKey[:], decrypted))
+
+		_, err = km.DecryptPrivateKey(upgraded, "wrong-password")
+		assert.Error(t, err)
+	})
+
+	// Test a batch derives its key once: blobs of one KeyManager share a salt
+	t.Run("Batch Encryption Shares Salt", func(t *testing.T) {
+		batch := NewKeyManager()
+		first, err := batch.EncryptPrivateKey([]byte("first-key"), "test-password")
+		require.NoError(t, err)
+		second, err := batch.EncryptPrivateKey([]byte("second-key"), "test-password")
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Len(t, batch.keys, 1)
+
+		_, firstPayload, err := parseEncryptedKey(first)
+		require.NoError(t, err)
+		_, secondPayload, err := parseEncryptedKey(second)
+		require.NoError(t, err)
+		firstBlob, _ := base64.StdEncoding.DecodeString(firstPayload)
+		secondBlob, _ := base64.StdEncoding.DecodeString(secondPayload)
+		assert.Equal(t, firstBlob[:kdfSaltSize], secondBlob[:kdfSaltSize])
+
+		other := NewKeyManager()
+		decrypted, err := other.DecryptPrivateKey(second, "test-password")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("second-key"), decrypted)
+		_, err = other.DecryptPrivateKey(first, "test-password")
+		require.NoError(t, err)
+		assert.Len(t, other.keys, 1)
+	})
+
 	// Test file operations with JSON format
 	t.Run("Save and Load Encrypted Key as JSON", func(t *testing.T) {
 		account, err := km.GenerateKeyPair()
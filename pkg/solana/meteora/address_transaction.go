@@ -0,0 +1,183 @@
+package meteora
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+)
+
+// PersistAddressTransactionsEnv enables writing address_transaction and address_balance_change
+// rows for every transaction the monitor parses (default: disabled)
+const PersistAddressTransactionsEnv = "METEORA_PERSIST_ADDRESS_TX"
+
+// addressTransactionSource is stored in address_transaction.source for rows written by the monitor
+const addressTransactionSource = "METEORA"
+
+// solBalanceMint is the mint recorded for native SOL balance changes, as in ParseAddressBalanceChangesFromTransaction
+const solBalanceMint = "sol"
+
+// persistAddressTransactionsFromEnv reads PersistAddressTransactionsEnv
+func persistAddressTransactionsFromEnv() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(PersistAddressTransactionsEnv))
+	return enabled
+}
+
+// SetPersistAddressTransactions overrides whether parsed transactions are also stored as
+// AddressTransaction/AddressBalanceChange rows
+func (m *PoolMonitorManager) SetPersistAddressTransactions(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.persistAddressTx = enabled
+}
+
+func (m *PoolMonitorManager) persistAddressTransactions() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.persistAddressTx
+}
+
+// addressTransactionType maps a swap action to the Helius-style type used in address_transaction
+func addressTransactionType(action string) string {
+	switch action {
+	case "buy", "sell":
+		return "SWAP"
+	case "add liquidity":
+		return "ADD_LIQUIDITY"
+	case "remove liquidity":
+		return "WITHDRAW_LIQUIDITY"
+	}
+	return "UNKNOWN"
+}
+
+// buildAddressRecords derives the address_transaction row of a parsed transaction and the balance
+// changes it caused: the token changes of every owner of the pool's base/quote mints and the fee
+// payer's SOL change (fee included). Owners whose balance did not change are omitted.
+func buildAddressRecords(conn *PoolConnection, tx *rpc.GetParsedTransactionResult, swapTx *SwapTransaction) (models.AddressTransaction, []models.AddressBalanceChange) {
+	timestamp := uint(0)
+	if swapTx.Timestamp > 0 {
+		timestamp = uint(swapTx.Timestamp / 1000)
+	}
+
+	// Data 保存解析后的交易信息，不包含体积较大的 TxMeta
+	summary := *swapTx
+	summary.TxMeta = ""
+	data, err := json.Marshal(summary)
+	if err != nil {
+		data = nil
+	}
+
+	record := models.AddressTransaction{
+		Address:   conn.Address,
+		Signature: swapTx.Signature,
+		FeePayer:  swapTx.Payer,
+		Slot:      uint(swapTx.Slot),
+		Timestamp: timestamp,
+		Type:      addressTransactionType(swapTx.Action),
+		Source:    addressTransactionSource,
+		Data:      data,
+	}
+	if tx.Meta == nil {
+		return record, nil
+	}
+	record.Fee = float64(tx.Meta.Fee) / 1e9
+
+	type ownerMint struct{ owner, mint string }
+	deltas := make(map[ownerMint]float64)
+	tracked := map[string]bool{conn.BaseTokenMint: true, conn.QuoteTokenMint: true}
+	apply := func(balances []rpc.TokenBalance, sign float64) {
+		for _, bal := range balances {
+			mint := bal.Mint.String()
+			if !tracked[mint] || bal.Owner == nil || bal.UiTokenAmount == nil || bal.UiTokenAmount.UiAmount == nil {
+				continue
+			}
+			deltas[ownerMint{bal.Owner.String(), mint}] += sign * *bal.UiTokenAmount.UiAmount
+		}
+	}
+	apply(tx.Meta.PreTokenBalances, -1)
+	apply(tx.Meta.PostTokenBalances, 1)
+
+	// 手续费支付者是第一个账户，其 SOL 变化包含手续费
+	if swapTx.Payer != "" && len(tx.Meta.PreBalances) > 0 && len(tx.Meta.PostBalances) > 0 {
+		lamports := int64(tx.Meta.PostBalances[0]) - int64(tx.Meta.PreBalances[0])
+		deltas[ownerMint{swapTx.Payer, solBalanceMint}] += float64(lamports) / 1e9
+	}
+
+	keys := make([]ownerMint, 0, len(deltas))
+	for key, delta := range deltas {
+		if delta != 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].owner != keys[j].owner {
+			return keys[i].owner < keys[j].owner
+		}
+		return keys[i].mint < keys[j].mint
+	})
+
+	changes := make([]models.AddressBalanceChange, 0, len(keys))
+	for _, key := range keys {
+		changes = append(changes, models.AddressBalanceChange{
+			Slot:         record.Slot,
+			Timestamp:    record.Timestamp,
+			Signature:    record.Signature,
+			Address:      key.owner,
+			Mint:         key.mint,
+			AmountChange: deltas[key],
+		})
+	}
+	return record, changes
+}
+
+// saveAddressTransactionToDB stores the AddressTransaction and its AddressBalanceChange rows in one
+// transaction. The unique signature index makes it idempotent: when the signature is already stored
+// (redelivered notification or another monitored pool of the same transaction) nothing is written.
+func (m *PoolMonitorManager) saveAddressTransactionToDB(conn *PoolConnection, tx *rpc.GetParsedTransactionResult, swapTx *SwapTransaction) {
+	record, changes := buildAddressRecords(conn, tx, swapTx)
+
+	inserted := false
+	err := dbconfig.DB.Transaction(func(db *gorm.DB) error {
+		result := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "signature"}}, DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		inserted = true
+		if len(changes) == 0 {
+			return nil
+		}
+		return db.Create(&changes).Error
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"signature":    swapTx.Signature,
+			"pool_address": conn.Address,
+			"error":        err.Error(),
+		}).Error("Failed to save address transaction to database")
+		return
+	}
+	if !inserted {
+		log.WithFields(log.Fields{
+			"signature": swapTx.Signature,
+		}).Debug("Address transaction already exists, skipping")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"signature":       swapTx.Signature,
+		"pool_address":    conn.Address,
+		"type":            record.Type,
+		"balance_changes": len(changes),
+	}).Debug("Saved address transaction to database")
+}
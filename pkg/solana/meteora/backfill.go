@@ -0,0 +1,237 @@
+package meteora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	log "github.com/sirupsen/logrus"
+
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+)
+
+const (
+	// BackfillAction is the PoolMonitorMessage action asking the worker to run a queued backfill
+	BackfillAction = "backfill"
+	// CancelBackfillAction is the PoolMonitorMessage action asking the worker to stop a backfill
+	CancelBackfillAction = "cancel_backfill"
+)
+
+// backfillPageSize is the number of signatures fetched per getSignaturesForAddress call (the RPC maximum)
+const backfillPageSize = 1000
+
+// runningBackfills holds the cancel function of every backfill running in this process
+var (
+	runningBackfills   = make(map[uint]context.CancelFunc)
+	runningBackfillsMu sync.Mutex
+)
+
+// CreateBackfill records a queued backfill of pool over [startSlot, endSlot]; call it when the backfill is enqueued
+func CreateBackfill(poolAddress string, platform models.PoolPlatform, startSlot, endSlot uint64) (*models.Backfill, error) {
+	backfill := models.Backfill{
+		PoolAddress:  poolAddress,
+		PoolPlatform: string(platform),
+		StartSlot:    startSlot,
+		EndSlot:      endSlot,
+		Status:       models.BackfillStatusQueued,
+	}
+	if err := dbconfig.DB.Create(&backfill).Error; err != nil {
+		return nil, err
+	}
+	return &backfill, nil
+}
+
+// StartBackfill marks a queued backfill running and returns a context that is cancelled when a
+// cancel_backfill message for it arrives. The processor must stop once ctx is done and call
+// FinishBackfill. It fails when the backfill was cancelled before it started.
+func StartBackfill(id uint) (context.Context, error) {
+	now := time.Now()
+	result := dbconfig.DB.Model(&models.Backfill{}).
+		Where("id = ? AND status = ?", id, models.BackfillStatusQueued).
+		Updates(map[string]interface{}{"status": models.BackfillStatusRunning, "started_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("backfill %d is not queued", id)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runningBackfillsMu.Lock()
+	runningBackfills[id] = cancel
+	runningBackfillsMu.Unlock()
+	return ctx, nil
+}
+
+// ReportBackfillProgress stores the slot reached and the number of transactions processed so far.
+// When the backfill is no longer running (its cancel was requested while another worker consumed the
+// cancel message) its context is cancelled here.
+func ReportBackfillProgress(id uint, processedSlot uint64, processedCount int64) {
+	result := dbconfig.DB.Model(&models.Backfill{}).
+		Where("id = ? AND status = ?", id, models.BackfillStatusRunning).
+		Updates(map[string]interface{}{"processed_slot": processedSlot, "processed_count": processedCount})
+	if result.Error != nil {
+		log.Warnf("Failed to record progress of backfill %d: %v", id, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		CancelBackfill(id)
+	}
+}
+
+// FinishBackfill records the outcome of a running backfill: cancelled when its context was cancelled,
+// otherwise failed when err is set and completed when it is not
+func FinishBackfill(id uint, err error) {
+	runningBackfillsMu.Lock()
+	cancel, ok := runningBackfills[id]
+	delete(runningBackfills, id)
+	runningBackfillsMu.Unlock()
+
+	updates := map[string]interface{}{"status": models.BackfillStatusCompleted, "finished_at": time.Now()}
+	switch {
+	case errors.Is(err, context.Canceled):
+		updates["status"] = models.BackfillStatusCancelled
+	case err != nil:
+		updates["status"] = models.BackfillStatusFailed
+		updates["error"] = err.Error()
+	}
+	if ok {
+		cancel()
+	}
+
+	if dbErr := dbconfig.DB.Model(&models.Backfill{}).Where("id = ?", id).Updates(updates).Error; dbErr != nil {
+		log.Warnf("Failed to record outcome of backfill %d: %v", id, dbErr)
+	}
+}
+
+// CancelBackfill handles a cancel_backfill message by cancelling the context of the backfill if it runs in
+// this process. It reports whether it did; queued backfills are cancelled by the API before publishing.
+func CancelBackfill(id uint) bool {
+	runningBackfillsMu.Lock()
+	cancel, running := runningBackfills[id]
+	runningBackfillsMu.Unlock()
+
+	if running {
+		cancel()
+		log.Infof("Cancelled running backfill %d", id)
+	}
+	return running
+}
+
+// RunBackfill runs a queued backfill: it walks the pool's signatures from end_slot down to start_slot and
+// stores every swap through the same parsing and filtering as the live monitor. Progress is reported after
+// each page of signatures and the run stops when the backfill is cancelled.
+func (m *PoolMonitorManager) RunBackfill(id uint, baseTokenMint, quoteTokenMint, meteoraDbcAuthority, meteoraCpmmAuthority string) {
+	ctx, err := StartBackfill(id)
+	if err != nil {
+		log.Warnf("Not starting backfill %d: %v", id, err)
+		return
+	}
+
+	var backfill models.Backfill
+	if err := dbconfig.DB.First(&backfill, id).Error; err != nil {
+		FinishBackfill(id, err)
+		return
+	}
+
+	roleAddressMap, err := m.loadRoleAddressMap()
+	if err != nil {
+		FinishBackfill(id, fmt.Errorf("load role addresses: %w", err))
+		return
+	}
+	conn := &PoolConnection{
+		Address:              backfill.PoolAddress,
+		BaseTokenMint:        baseTokenMint,
+		QuoteTokenMint:       quoteTokenMint,
+		MeteoraDbcAuthority:  meteoraDbcAuthority,
+		MeteoraCpmmAuthority: meteoraCpmmAuthority,
+		RPCClient:            rpc.New(m.rpcEndpoint),
+		roleAddressMap:       roleAddressMap,
+	}
+
+	log.WithFields(log.Fields{
+		"backfill_id":  id,
+		"pool_address": backfill.PoolAddress,
+		"start_slot":   backfill.StartSlot,
+		"end_slot":     backfill.EndSlot,
+	}).Info("Backfill started")
+	err = m.backfillSlotRange(ctx, conn, &backfill)
+	FinishBackfill(id, err)
+	log.WithFields(log.Fields{
+		"backfill_id":     id,
+		"processed_count": backfill.ProcessedCount,
+		"error":           err,
+	}).Info("Backfill finished")
+}
+
+// backfillSlotRange pages through the signatures of conn.Address newest first, processing those within
+// [StartSlot, EndSlot]. Failed transactions are stored too, like in the live monitor.
+func (m *PoolMonitorManager) backfillSlotRange(ctx context.Context, conn *PoolConnection, backfill *models.Backfill) error {
+	pool, err := solana.PublicKeyFromBase58(conn.Address)
+	if err != nil {
+		return fmt.Errorf("invalid pool address: %w", err)
+	}
+
+	limit := backfillPageSize
+	var before solana.Signature
+	for {
+		signatures, err := conn.RPCClient.GetSignaturesForAddressWithOpts(ctx, pool, &rpc.GetSignaturesForAddressOpts{
+			Limit:  &limit,
+			Before: before,
+		})
+		if err != nil {
+			return fmt.Errorf("get signatures: %w", err)
+		}
+		if len(signatures) == 0 {
+			return nil
+		}
+
+		for _, sig := range signatures {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if sig.Slot > backfill.EndSlot {
+				continue
+			}
+			if sig.Slot < backfill.StartSlot {
+				ReportBackfillProgress(backfill.ID, backfill.StartSlot, backfill.ProcessedCount)
+				return nil
+			}
+
+			signature := sig.Signature.String()
+			tx, err := m.getTransactionWithRetry(ctx, conn, sig.Signature, signature)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if !strings.Contains(strings.ToLower(err.Error()), "not found") {
+					return fmt.Errorf("get transaction %s: %w", signature, err)
+				}
+				tx = nil
+			}
+			if tx != nil {
+				if swapTx := m.decodeSwapTransaction(conn, tx, signature, ""); swapTx != nil {
+					m.saveSwapTransactionToDB(swapTx, conn)
+					if m.persistAddressTransactions() {
+						m.saveAddressTransactionToDB(conn, tx, swapTx)
+					}
+				}
+			}
+			backfill.ProcessedSlot = sig.Slot
+			backfill.ProcessedCount++
+		}
+
+		// 每页上报一次进度；被取消的回填会在这里发现并停止
+		ReportBackfillProgress(backfill.ID, backfill.ProcessedSlot, backfill.ProcessedCount)
+		if len(signatures) < limit {
+			return nil
+		}
+		before = signatures[len(signatures)-1].Signature
+	}
+}
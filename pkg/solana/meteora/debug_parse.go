@@ -0,0 +1,56 @@
+package meteora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ErrNotSwap is returned by ParseSwapBySignature when the monitor would not produce a swap for the
+// transaction (no transaction body, no authority or an empty payer)
+var ErrNotSwap = errors.New("transaction does not parse to a swap")
+
+// ParseSwapParams are the parameters a pool address is monitored with (see PoolMonitorMessage)
+type ParseSwapParams struct {
+	PoolAddress          string
+	BaseTokenMint        string
+	QuoteTokenMint       string
+	MeteoraDbcAuthority  string
+	MeteoraCpmmAuthority string
+}
+
+// ParseSwapBySignature fetches a transaction and runs it through the same decoding the pool monitor uses,
+// without persisting anything or touching monitor state. It is meant for debugging wrong swap records.
+func ParseSwapBySignature(ctx context.Context, client *rpc.Client, signature string, params ParseSwapParams) (*SwapTransaction, error) {
+	sig, err := solana.SignatureFromBase58(signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	m := &PoolMonitorManager{}
+	conn := &PoolConnection{
+		Address:              params.PoolAddress,
+		BaseTokenMint:        params.BaseTokenMint,
+		QuoteTokenMint:       params.QuoteTokenMint,
+		MeteoraDbcAuthority:  params.MeteoraDbcAuthority,
+		MeteoraCpmmAuthority: params.MeteoraCpmmAuthority,
+		RPCClient:            client,
+	}
+
+	tx, err := m.getTransactionWithRetry(ctx, conn, sig, signature)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", signature)
+	}
+
+	swapTx := m.decodeSwapTransaction(conn, tx, signature, "")
+	if swapTx == nil {
+		return nil, ErrNotSwap
+	}
+	return swapTx, nil
+}
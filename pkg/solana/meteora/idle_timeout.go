@@ -0,0 +1,98 @@
+package meteora
+
+import (
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+// IdleTimeoutEnv bounds how long a subscription may stay silent (no notification and no pong) before it is
+// treated as dead: the read fails, the error count is incremented and the address is resubscribed. "0" disables it.
+const IdleTimeoutEnv = "METEORA_WS_IDLE_TIMEOUT"
+
+const (
+	defaultIdleTimeout = 2 * time.Minute
+	pingWriteTimeout   = 10 * time.Second
+)
+
+func idleTimeoutFromEnv() time.Duration {
+	raw := os.Getenv(IdleTimeoutEnv)
+	if raw == "" {
+		return defaultIdleTimeout
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil || parsed < 0 {
+		log.Warnf("Invalid %s=%q, using %s", IdleTimeoutEnv, raw, defaultIdleTimeout)
+		return defaultIdleTimeout
+	}
+	return parsed
+}
+
+// SetIdleTimeout overrides METEORA_WS_IDLE_TIMEOUT for subscriptions started afterwards; 0 disables the check
+func (m *PoolMonitorManager) SetIdleTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idleTimeout = timeout
+}
+
+func (m *PoolMonitorManager) getIdleTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.idleTimeout
+}
+
+// touch records that a message arrived on c and, when idle is set, pushes its read deadline out by idle.
+// It must run on the goroutine reading c (pong handlers do).
+func (conn *PoolConnection) touch(c *websocket.Conn, idle time.Duration) {
+	now := time.Now()
+	conn.mu.Lock()
+	conn.LastMessage = now
+	conn.mu.Unlock()
+	if idle > 0 {
+		c.SetReadDeadline(now.Add(idle))
+	}
+}
+
+// keepAlive pings c every idle/3 so a pool without swaps still produces pongs; a connection that answers
+// neither pings nor notifications hits its read deadline. It returns when done or the connection's StopCh closes.
+func (m *PoolMonitorManager) keepAlive(conn *PoolConnection, c *websocket.Conn, idle time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(idle / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-conn.StopCh:
+			return
+		case <-ticker.C:
+			if err := c.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteTimeout)); err != nil {
+				log.WithFields(log.Fields{
+					"pool_address": conn.Address,
+					"error":        err.Error(),
+				}).Debug("Failed to send ping, waiting for the idle timeout")
+				return
+			}
+		}
+	}
+}
+
+// ConnectionInfo is the live state of one monitored address
+type ConnectionInfo struct {
+	Status      string
+	LastMessage time.Time // zero until the first message (or pong) arrives
+}
+
+// GetConnectionInfos returns the status and last message time of every monitored address
+func (m *PoolMonitorManager) GetConnectionInfos() map[string]ConnectionInfo {
+	result := make(map[string]ConnectionInfo)
+	m.connections.Range(func(key, value interface{}) bool {
+		conn := value.(*PoolConnection)
+		conn.mu.RLock()
+		result[key.(string)] = ConnectionInfo{Status: conn.Status, LastMessage: conn.LastMessage}
+		conn.mu.RUnlock()
+		return true
+	})
+	return result
+}
@@ -0,0 +1,22 @@
+package meteora
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleTimeoutFromEnv(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":      defaultIdleTimeout,
+		"30s":   30 * time.Second,
+		"0":     0,
+		"-1m":   defaultIdleTimeout,
+		"bogus": defaultIdleTimeout,
+	}
+	for raw, want := range cases {
+		t.Setenv(IdleTimeoutEnv, raw)
+		assert.Equal(t, want, idleTimeoutFromEnv(), raw)
+	}
+}
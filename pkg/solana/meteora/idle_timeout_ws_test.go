@@ -0,0 +1,147 @@
+package meteora
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIdleTimeout = 150 * time.Millisecond
+
+// newTestWSServer starts a websocket server whose connections either read (and so answer pings with pongs)
+// or stay completely silent until the test ends
+func newTestWSServer(t *testing.T, answerPings bool) string {
+	t.Helper()
+	release := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		if answerPings {
+			// the default ping handler replies with a pong while ReadMessage runs
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+		<-release
+	}))
+	t.Cleanup(func() {
+		close(release)
+		srv.Close()
+	})
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func dialTestConnection(t *testing.T, endpoint string) *PoolConnection {
+	t.Helper()
+	c, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+	require.NoError(t, err)
+	return &PoolConnection{
+		Address:     "PoolAddress111111111111111111111111111111111",
+		Conn:        c,
+		Status:      StateConnected,
+		ReconnectCh: make(chan bool, 1),
+		StopCh:      make(chan bool, 1),
+	}
+}
+
+func TestReadMessagesResubscribesAfterIdleTimeout(t *testing.T) {
+	m := &PoolMonitorManager{}
+	m.SetIdleTimeout(testIdleTimeout)
+	conn := dialTestConnection(t, newTestWSServer(t, false))
+
+	start := time.Now()
+	go m.readMessages(conn)
+
+	select {
+	case <-conn.ReconnectCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("silent connection was not resubscribed")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), testIdleTimeout)
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	assert.Equal(t, StateDisconnected, conn.Status)
+	assert.Equal(t, 1, conn.errorCount)
+}
+
+func TestReadMessagesPongsExtendDeadline(t *testing.T) {
+	m := &PoolMonitorManager{}
+	m.SetIdleTimeout(testIdleTimeout)
+	conn := dialTestConnection(t, newTestWSServer(t, true))
+
+	go m.readMessages(conn)
+
+	// several idle periods pass without a notification, but keepAlive's pings are answered
+	select {
+	case <-conn.ReconnectCh:
+		t.Fatal("connection answering pings was resubscribed")
+	case <-time.After(4 * testIdleTimeout):
+	}
+
+	conn.mu.RLock()
+	lastMessage := conn.LastMessage
+	status := conn.Status
+	conn.mu.RUnlock()
+	assert.Equal(t, StateConnected, status)
+	assert.WithinDuration(t, time.Now(), lastMessage, testIdleTimeout, "pongs should refresh LastMessage")
+
+	close(conn.StopCh)
+	conn.Conn.Close()
+	select {
+	case <-conn.ReconnectCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("readMessages did not return after the connection closed")
+	}
+}
+
+func TestKeepAliveStops(t *testing.T) {
+	m := &PoolMonitorManager{}
+
+	t.Run("done closed", func(t *testing.T) {
+		conn := dialTestConnection(t, newTestWSServer(t, true))
+		defer conn.Conn.Close()
+		done := make(chan struct{})
+		returned := make(chan struct{})
+		go func() {
+			m.keepAlive(conn, conn.Conn, testIdleTimeout, done)
+			close(returned)
+		}()
+
+		close(done)
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Fatal("keepAlive did not return after done closed")
+		}
+	})
+
+	t.Run("StopCh closed", func(t *testing.T) {
+		conn := dialTestConnection(t, newTestWSServer(t, true))
+		defer conn.Conn.Close()
+		returned := make(chan struct{})
+		go func() {
+			m.keepAlive(conn, conn.Conn, testIdleTimeout, make(chan struct{}))
+			close(returned)
+		}()
+
+		close(conn.StopCh)
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Fatal("keepAlive did not return after StopCh closed")
+		}
+	})
+}
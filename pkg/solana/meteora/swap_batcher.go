@@ -0,0 +1,208 @@
+package meteora
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm/clause"
+
+	"marketcontrol/internal/models"
+	dbconfig "marketcontrol/pkg/config"
+)
+
+// SwapBatchSizeEnv enables buffered swap persistence: swaps are written per pool in batches of this
+// size (default: 0, every swap is written individually)
+const SwapBatchSizeEnv = "METEORA_SWAP_BATCH_SIZE"
+
+// SwapBatchIntervalEnv is the longest a buffered swap waits before being flushed (default: 1s)
+const SwapBatchIntervalEnv = "METEORA_SWAP_BATCH_INTERVAL"
+
+const defaultSwapBatchInterval = time.Second
+
+// swapBatcher buffers SwapTransaction rows per pool and flushes a pool when it holds size rows,
+// and all pools every interval, whichever comes first
+type swapBatcher struct {
+	size     int
+	interval time.Duration
+	write    func(rows []models.SwapTransaction) error
+
+	mu      sync.Mutex
+	pending map[string][]models.SwapTransaction
+	stopped bool
+
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// swapBatcherFromEnv returns nil when batching is not enabled
+func swapBatcherFromEnv() *swapBatcher {
+	size, _ := strconv.Atoi(os.Getenv(SwapBatchSizeEnv))
+	if size <= 1 {
+		return nil
+	}
+	interval := defaultSwapBatchInterval
+	if raw := os.Getenv(SwapBatchIntervalEnv); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Warnf("Invalid %s=%q, using %s", SwapBatchIntervalEnv, raw, interval)
+		}
+	}
+	return newSwapBatcher(size, interval, writeSwapBatch)
+}
+
+func newSwapBatcher(size int, interval time.Duration, write func(rows []models.SwapTransaction) error) *swapBatcher {
+	b := &swapBatcher{
+		size:     size,
+		interval: interval,
+		write:    write,
+		pending:  make(map[string][]models.SwapTransaction),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+// writeSwapBatch inserts rows in one statement; signatures already stored are skipped
+func writeSwapBatch(rows []models.SwapTransaction) error {
+	return dbconfig.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "signature"}}, DoNothing: true}).
+		CreateInBatches(&rows, len(rows)).Error
+}
+
+func (b *swapBatcher) loop() {
+	defer close(b.doneCh)
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+			b.flushAll()
+		}
+	}
+}
+
+// add buffers a row for pool, flushing the pool once it reaches the batch size.
+// After stop rows are written immediately.
+func (b *swapBatcher) add(pool string, row models.SwapTransaction) {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		b.flushRows(pool, []models.SwapTransaction{row})
+		return
+	}
+	b.pending[pool] = append(b.pending[pool], row)
+	var rows []models.SwapTransaction
+	if len(b.pending[pool]) >= b.size {
+		rows = b.pending[pool]
+		delete(b.pending, pool)
+	}
+	b.mu.Unlock()
+
+	if rows != nil {
+		b.flushRows(pool, rows)
+	}
+}
+
+// flushPool writes the buffered rows of one pool, e.g. when its monitoring stops
+func (b *swapBatcher) flushPool(pool string) {
+	b.mu.Lock()
+	rows := b.pending[pool]
+	delete(b.pending, pool)
+	b.mu.Unlock()
+
+	if len(rows) > 0 {
+		b.flushRows(pool, rows)
+	}
+}
+
+func (b *swapBatcher) flushAll() {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[string][]models.SwapTransaction)
+	b.mu.Unlock()
+
+	for pool, rows := range pending {
+		b.flushRows(pool, rows)
+	}
+}
+
+// flushRows writes rows as one batch; if the batch fails each row is retried on its own so
+// a single bad row does not drop the rest
+func (b *swapBatcher) flushRows(pool string, rows []models.SwapTransaction) {
+	err := b.write(rows)
+	if err == nil {
+		log.WithFields(log.Fields{
+			"pool_address": pool,
+			"count":        len(rows),
+		}).Debug("Flushed swap transaction batch to database")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"pool_address": pool,
+		"count":        len(rows),
+		"error":        err.Error(),
+	}).Warn("Batch insert of swap transactions failed, retrying individually")
+	for _, row := range rows {
+		if err := b.write([]models.SwapTransaction{row}); err != nil {
+			log.WithFields(log.Fields{
+				"signature": row.Signature,
+				"error":     err.Error(),
+			}).Error("Failed to save swap transaction to database")
+		}
+	}
+}
+
+// stop ends the interval flushes and writes everything still buffered
+func (b *swapBatcher) stop() {
+	b.stopOnce.Do(func() {
+		close(b.stopCh)
+		<-b.doneCh
+
+		b.mu.Lock()
+		b.stopped = true
+		b.mu.Unlock()
+		b.flushAll()
+	})
+}
+
+// SetSwapBatching overrides the env configuration: swaps are written in batches of size or every
+// interval. A size of 0 or 1 restores per-swap writes. Buffered swaps are flushed first.
+func (m *PoolMonitorManager) SetSwapBatching(size int, interval time.Duration) {
+	var batcher *swapBatcher
+	if size > 1 {
+		if interval <= 0 {
+			interval = defaultSwapBatchInterval
+		}
+		batcher = newSwapBatcher(size, interval, writeSwapBatch)
+	}
+
+	m.mu.Lock()
+	previous := m.swapBatcher
+	m.swapBatcher = batcher
+	m.mu.Unlock()
+
+	if previous != nil {
+		previous.stop()
+	}
+}
+
+func (m *PoolMonitorManager) getSwapBatcher() *swapBatcher {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.swapBatcher
+}
+
+// Shutdown flushes buffered swaps. Call it before the worker exits.
+func (m *PoolMonitorManager) Shutdown() {
+	if batcher := m.getSwapBatcher(); batcher != nil {
+		batcher.stop()
+	}
+}
@@ -0,0 +1,84 @@
+package meteora
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"marketcontrol/internal/models"
+)
+
+type recordingWriter struct {
+	mu      sync.Mutex
+	batches [][]string
+	failOn  int // batches of this size fail
+}
+
+func (w *recordingWriter) write(rows []models.SwapTransaction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failOn > 0 && len(rows) == w.failOn {
+		return errors.New("batch failed")
+	}
+	signatures := make([]string, len(rows))
+	for i, row := range rows {
+		signatures[i] = row.Signature
+	}
+	w.batches = append(w.batches, signatures)
+	return nil
+}
+
+func (w *recordingWriter) snapshot() [][]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([][]string(nil), w.batches...)
+}
+
+func TestSwapBatcherFlushesOnSize(t *testing.T) {
+	w := &recordingWriter{}
+	b := newSwapBatcher(2, time.Hour, w.write)
+	defer b.stop()
+
+	b.add("poolA", models.SwapTransaction{Signature: "a1"})
+	b.add("poolB", models.SwapTransaction{Signature: "b1"})
+	assert.Empty(t, w.snapshot())
+
+	b.add("poolA", models.SwapTransaction{Signature: "a2"})
+	assert.Equal(t, [][]string{{"a1", "a2"}}, w.snapshot())
+}
+
+func TestSwapBatcherFlushesOnInterval(t *testing.T) {
+	w := &recordingWriter{}
+	b := newSwapBatcher(100, 20*time.Millisecond, w.write)
+	defer b.stop()
+
+	b.add("poolA", models.SwapTransaction{Signature: "a1"})
+	assert.Eventually(t, func() bool { return len(w.snapshot()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSwapBatcherStopFlushesPending(t *testing.T) {
+	w := &recordingWriter{}
+	b := newSwapBatcher(100, time.Hour, w.write)
+
+	b.add("poolA", models.SwapTransaction{Signature: "a1"})
+	b.add("poolA", models.SwapTransaction{Signature: "a2"})
+	b.stop()
+	assert.Equal(t, [][]string{{"a1", "a2"}}, w.snapshot())
+
+	// rows added after stop are written immediately
+	b.add("poolA", models.SwapTransaction{Signature: "a3"})
+	assert.Equal(t, [][]string{{"a1", "a2"}, {"a3"}}, w.snapshot())
+}
+
+func TestSwapBatcherRetriesRowsIndividually(t *testing.T) {
+	w := &recordingWriter{failOn: 2}
+	b := newSwapBatcher(2, time.Hour, w.write)
+	defer b.stop()
+
+	b.add("poolA", models.SwapTransaction{Signature: "a1"})
+	b.add("poolA", models.SwapTransaction{Signature: "a2"})
+	assert.Equal(t, [][]string{{"a1"}, {"a2"}}, w.snapshot())
+}
@@ -1469,7 +1469,7 @@ func MigrateHolder(db *gorm.DB, meteoradbcConfig models.MeteoradbcConfig) error
 func ModifyProjectConfig(cfg models.MeteoradbcConfig) error {
 	// 查询 PoolPlatform 为 meteora_dbc 且 pool_id 为 cfg.ID 的项目
 	var projectConfigs []models.ProjectConfig
-	if err := config.DB.Where("pool_platform = ? AND pool_id = ?", "meteora_dbc", cfg.ID).Find(&projectConfigs).Error; err != nil {
+	if err := config.DB.Where("pool_platform = ? AND pool_id = ?", models.PoolPlatformMeteoraDbc, cfg.ID).Find(&projectConfigs).Error; err != nil {
 		return fmt.Errorf("failed to query ProjectConfig: %v", err)
 	}
 
@@ -1489,7 +1489,7 @@ func ModifyProjectConfig(cfg models.MeteoradbcConfig) error {
 
 	// 更新所有匹配的 ProjectConfig
 	for _, projectConfig := range projectConfigs {
-		projectConfig.PoolPlatform = "meteora_cpmm"
+		projectConfig.PoolPlatform = models.PoolPlatformMeteoraCpmm
 		projectConfig.PoolID = meteoracpmmConfig.ID
 		if err := config.DB.Save(&projectConfig).Error; err != nil {
 			logrus.Errorf("Failed to update ProjectConfig ID %d: %v", projectConfig.ID, err)
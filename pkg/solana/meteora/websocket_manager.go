@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"sync"
@@ -91,12 +92,43 @@ const (
 	retryBackoffMultiplier = 2.0                    // Exponential backoff multiplier
 )
 
+const (
+	// PoolMonitorQueue is the queue consumed by the worker to start/stop pool monitoring
+	PoolMonitorQueue = "meteora_pool_monitor"
+
+	// Default pool authorities, overridable via METEORA_DBC_AUTHORITY / METEORA_CPMM_AUTHORITY
+	DefaultMeteoraDbcAuthority  = "FhVo3mqL8PW5pH5U2CN4XE33DokiyZnUwuGpH2hmHLuM"
+	DefaultMeteoraCpmmAuthority = "HLnpSz9h2S4hiLQ43rnSD9XkcUThA7B8hQMKmDaiTLcC"
+)
+
+// AddressQueueName is the dedicated queue that may exist for a single monitored pool address
+func AddressQueueName(address string) string {
+	return fmt.Sprintf("%s_%s", PoolMonitorQueue, address)
+}
+
+// DbcAuthority returns the Meteora DBC pool authority
+func DbcAuthority() string {
+	if authority := os.Getenv("METEORA_DBC_AUTHORITY"); authority != "" {
+		return authority
+	}
+	return DefaultMeteoraDbcAuthority
+}
+
+// CpmmAuthority returns the Meteora CPMM (DAMM v2) pool authority
+func CpmmAuthority() string {
+	if authority := os.Getenv("METEORA_CPMM_AUTHORITY"); authority != "" {
+		return authority
+	}
+	return DefaultMeteoraCpmmAuthority
+}
+
 // PoolMonitorMessage represents a message for starting pool monitoring
 type PoolMonitorMessage struct {
 	Action             string `json:"action"`
 	MeteoradbcAddress  string `json:"meteoradbc_address,omitempty"`
 	MeteoracpmmAddress string `json:"meteoracpmm_address,omitempty"`
 	ProjectID          uint   `json:"project_id,omitempty"`
+	BackfillID         uint   `json:"backfill_id,omitempty"` // backfill, cancel_backfill
 	// Token information
 	BaseTokenMint        string `json:"base_token_mint,omitempty"`
 	QuoteTokenMint       string `json:"quote_token_mint,omitempty"`
@@ -158,6 +190,11 @@ type PoolMonitorManager struct {
 	wsEndpoint  string
 	rpcEndpoint string
 	mu          sync.RWMutex
+	cleanupFunc func(address string) // per-address resource cleanup run by StopMonitoring; nil uses cleanupRabbitMQResources
+
+	persistAddressTx bool          // also store AddressTransaction/AddressBalanceChange rows (METEORA_PERSIST_ADDRESS_TX)
+	swapBatcher      *swapBatcher  // buffered SwapTransaction writes (METEORA_SWAP_BATCH_SIZE); nil writes each swap
+	idleTimeout      time.Duration // silence after which a subscription is resubscribed (METEORA_WS_IDLE_TIMEOUT); 0 disables
 }
 
 // NewPoolMonitorManager creates a new pool monitor manager
@@ -174,8 +211,11 @@ func NewPoolMonitorManager() (*PoolMonitorManager, error) {
 	}
 
 	return &PoolMonitorManager{
-		wsEndpoint:  wsEndpoint,
-		rpcEndpoint: rpcEndpoint,
+		wsEndpoint:       wsEndpoint,
+		rpcEndpoint:      rpcEndpoint,
+		persistAddressTx: persistAddressTransactionsFromEnv(),
+		swapBatcher:      swapBatcherFromEnv(),
+		idleTimeout:      idleTimeoutFromEnv(),
 	}, nil
 }
 
@@ -232,26 +272,53 @@ func (m *PoolMonitorManager) StartMonitoring(address, baseTokenMint, quoteTokenM
 	return nil
 }
 
-// StopMonitoring stops monitoring a pool address
+// SetCleanupFunc replaces the per-address cleanup run by StopMonitoring (by default the
+// address queue is deleted through config.DeleteQueue)
+func (m *PoolMonitorManager) SetCleanupFunc(fn func(address string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cleanupFunc = fn
+}
+
+// StopMonitoring stops monitoring a pool address and deletes its per-address RabbitMQ queue.
+// It is idempotent: stopping an address that is not (or no longer) monitored still runs the
+// cleanup and returns nil, so repeated or concurrent stop requests are safe.
 func (m *PoolMonitorManager) StopMonitoring(address string) error {
-	value, exists := m.connections.Load(address)
-	if !exists {
-		return fmt.Errorf("connection for address %s not found", address)
+	// LoadAndDelete guarantees only one caller closes StopCh
+	if value, exists := m.connections.LoadAndDelete(address); exists {
+		conn := value.(*PoolConnection)
+		close(conn.StopCh)
+		log.WithFields(log.Fields{
+			"pool_address": address,
+		}).Info("Swap交易监控已停止")
+	} else {
+		log.WithFields(log.Fields{
+			"pool_address": address,
+		}).Debug("Address is not monitored, only cleaning up RabbitMQ resources")
 	}
 
-	conn := value.(*PoolConnection)
-	close(conn.StopCh)
-	m.connections.Delete(address)
-	log.WithFields(log.Fields{
-		"pool_address": address,
-	}).Info("Swap交易监控已停止")
+	// 停止监控前先写入该池子缓冲中的交易
+	if batcher := m.getSwapBatcher(); batcher != nil {
+		batcher.flushPool(address)
+	}
 
-	// Clean up RabbitMQ resources
-	m.cleanupRabbitMQResources(address)
+	m.mu.RLock()
+	cleanup := m.cleanupFunc
+	m.mu.RUnlock()
+	if cleanup == nil {
+		cleanup = m.cleanupRabbitMQResources
+	}
+	cleanup(address)
 
 	return nil
 }
 
+// IsMonitoring reports whether an address currently has an active monitoring connection
+func (m *PoolMonitorManager) IsMonitoring(address string) bool {
+	_, exists := m.connections.Load(address)
+	return exists
+}
+
 // incrementErrorCount increments the error count and checks if threshold is reached
 // Returns true if error count exceeds threshold and monitoring should be stopped
 func (m *PoolMonitorManager) incrementErrorCount(conn *PoolConnection) bool {
@@ -293,7 +360,7 @@ func (m *PoolMonitorManager) resetErrorCount(conn *PoolConnection) {
 
 // cleanupRabbitMQResources cleans up RabbitMQ resources for a pool address
 func (m *PoolMonitorManager) cleanupRabbitMQResources(address string) {
-	if dbconfig.RabbitMQ == nil {
+	if dbconfig.CurrentRabbitMQ() == nil {
 		log.WithFields(log.Fields{
 			"pool_address": address,
 		}).Debug("RabbitMQ not initialized, skipping cleanup")
@@ -302,18 +369,19 @@ func (m *PoolMonitorManager) cleanupRabbitMQResources(address string) {
 
 	// Try to delete queue named after the address (if it exists)
 	// This handles cases where a dedicated queue was created for this address
-	queueName := fmt.Sprintf("meteora_pool_monitor_%s", address)
+	// DeleteQueue applies RABBITMQ_QUEUE_PREFIX itself, so pass the bare name
+	queueName := AddressQueueName(address)
 	if err := dbconfig.DeleteQueue(queueName); err != nil {
 		// Queue might not exist, which is fine - log as debug
 		log.WithFields(log.Fields{
 			"pool_address": address,
-			"queue_name":   queueName,
+			"queue_name":   dbconfig.QueueName(queueName),
 			"error":        err.Error(),
 		}).Debug("Queue does not exist or failed to delete")
 	} else {
 		log.WithFields(log.Fields{
 			"pool_address": address,
-			"queue_name":   queueName,
+			"queue_name":   dbconfig.QueueName(queueName),
 		}).Info("Deleted RabbitMQ queue")
 	}
 
@@ -478,6 +546,22 @@ func (m *PoolMonitorManager) readMessages(conn *PoolConnection) {
 		}
 	}()
 
+	// 空闲超时：一段时间内既没有通知也没有 pong 时读取失败，按错误处理并重新订阅
+	idle := m.getIdleTimeout()
+	conn.mu.RLock()
+	ws := conn.Conn
+	conn.mu.RUnlock()
+	if ws != nil && idle > 0 {
+		ws.SetPongHandler(func(string) error {
+			conn.touch(ws, idle)
+			return nil
+		})
+		conn.touch(ws, idle)
+		done := make(chan struct{})
+		defer close(done)
+		go m.keepAlive(conn, ws, idle, done)
+	}
+
 	for {
 		conn.mu.RLock()
 		c := conn.Conn
@@ -489,10 +573,17 @@ func (m *PoolMonitorManager) readMessages(conn *PoolConnection) {
 
 		_, message, err := c.ReadMessage()
 		if err != nil {
-			log.WithFields(log.Fields{
-				"pool_address": conn.Address,
-				"error":        err.Error(),
-			}).Error("Error reading message")
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				log.WithFields(log.Fields{
+					"pool_address": conn.Address,
+					"idle_timeout": idle.String(),
+				}).Error("No message within the idle timeout, resubscribing")
+			} else {
+				log.WithFields(log.Fields{
+					"pool_address": conn.Address,
+					"error":        err.Error(),
+				}).Error("Error reading message")
+			}
 			// Increment error count and check if we should stop
 			if m.incrementErrorCount(conn) {
 				log.WithFields(log.Fields{
@@ -506,9 +597,7 @@ func (m *PoolMonitorManager) readMessages(conn *PoolConnection) {
 		// Reset error count on successful message read
 		m.resetErrorCount(conn)
 
-		conn.mu.Lock()
-		conn.LastMessage = time.Now()
-		conn.mu.Unlock()
+		conn.touch(c, idle)
 
 		// Process message
 		var msg map[string]interface{}
@@ -781,6 +870,35 @@ func (m *PoolMonitorManager) processTransactionWithError(conn *PoolConnection, s
 		return
 	}
 
+	// Parse swap transaction (even if failed, we still try to extract information)
+	swapTx := m.decodeSwapTransaction(conn, tx, signature, txError)
+	if swapTx != nil {
+		// Save to database with filtering
+		go m.saveSwapTransactionToDB(swapTx, conn)
+		if m.persistAddressTransactions() {
+			go m.saveAddressTransactionToDB(conn, tx, swapTx)
+		}
+
+		// Call callback if provided
+		if conn.SwapCallback != nil {
+			conn.SwapCallback(swapTx)
+		}
+
+		// If action is "remove liquidity" and transaction succeeded, stop monitoring
+		if swapTx.Action == "remove liquidity" && swapTx.Success {
+			log.WithFields(log.Fields{
+				"pool_address": conn.Address,
+				"signature":    swapTx.Signature,
+				"action":       swapTx.Action,
+			}).Info("Remove liquidity detected, stopping monitor")
+			m.StopMonitoring(conn.Address)
+		}
+	}
+}
+
+// decodeSwapTransaction derives the success flag, error and serialized meta of a fetched transaction and
+// parses it into a SwapTransaction. txError is the error reported by the log notification, if any.
+func (m *PoolMonitorManager) decodeSwapTransaction(conn *PoolConnection, tx *rpc.GetParsedTransactionResult, signature string, txError string) *SwapTransaction {
 	// Check if transaction failed (from RPC response or from log notification)
 	isSuccess := txError == ""
 	if tx.Meta != nil && tx.Meta.Err != nil {
@@ -809,27 +927,7 @@ func (m *PoolMonitorManager) processTransactionWithError(conn *PoolConnection, s
 		}
 	}
 
-	// Parse swap transaction (even if failed, we still try to extract information)
-	swapTx := m.parseSwapTransaction(conn, tx, signature, isSuccess, txError, txMeta)
-	if swapTx != nil {
-		// Save to database with filtering
-		go m.saveSwapTransactionToDB(swapTx, conn)
-
-		// Call callback if provided
-		if conn.SwapCallback != nil {
-			conn.SwapCallback(swapTx)
-		}
-
-		// If action is "remove liquidity" and transaction succeeded, stop monitoring
-		if swapTx.Action == "remove liquidity" && swapTx.Success {
-			log.WithFields(log.Fields{
-				"pool_address": conn.Address,
-				"signature":    swapTx.Signature,
-				"action":       swapTx.Action,
-			}).Info("Remove liquidity detected, stopping monitor")
-			m.StopMonitoring(conn.Address)
-		}
-	}
+	return m.parseSwapTransaction(conn, tx, signature, isSuccess, txError, txMeta)
 }
 
 // parseSwapTransaction parses a transaction to extract swap information
@@ -1179,21 +1277,24 @@ func (m *PoolMonitorManager) saveSwapTransactionToDB(swapTx *SwapTransaction, co
 	}
 
 	// Convert meteora.SwapTransaction to models.SwapTransaction
-	// Check if transaction already exists
-	var existingTx models.SwapTransaction
-	err := dbconfig.DB.Where("signature = ?", swapTx.Signature).First(&existingTx).Error
-	if err == nil {
-		log.WithFields(log.Fields{
-			"signature": swapTx.Signature,
-		}).Debug("Swap transaction already exists, skipping")
-		return
-	}
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		log.WithFields(log.Fields{
-			"signature": swapTx.Signature,
-			"error":     err.Error(),
-		}).Error("Failed to check if swap transaction exists")
-		return
+	// Check if transaction already exists; batched writes skip duplicates with ON CONFLICT instead
+	batcher := m.getSwapBatcher()
+	if batcher == nil {
+		var existingTx models.SwapTransaction
+		err := dbconfig.DB.Where("signature = ?", swapTx.Signature).First(&existingTx).Error
+		if err == nil {
+			log.WithFields(log.Fields{
+				"signature": swapTx.Signature,
+			}).Debug("Swap transaction already exists, skipping")
+			return
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.WithFields(log.Fields{
+				"signature": swapTx.Signature,
+				"error":     err.Error(),
+			}).Error("Failed to check if swap transaction exists")
+			return
+		}
 	}
 
 	// Determine payer type based on action
@@ -1235,6 +1336,11 @@ func (m *PoolMonitorManager) saveSwapTransactionToDB(swapTx *SwapTransaction, co
 		TxError:     swapTx.Error,
 	}
 
+	if batcher != nil {
+		batcher.add(conn.Address, dbSwapTx)
+		return
+	}
+
 	// Save to database
 	if err := dbconfig.DB.Create(&dbSwapTx).Error; err != nil {
 		log.WithFields(log.Fields{
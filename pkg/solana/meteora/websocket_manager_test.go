@@ -0,0 +1,62 @@
+package meteora
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopMonitoringIsIdempotent(t *testing.T) {
+	const address = "PoolAddress111111111111111111111111111111111"
+
+	m := &PoolMonitorManager{}
+	var mu sync.Mutex
+	cleaned := map[string]int{}
+	m.SetCleanupFunc(func(address string) {
+		mu.Lock()
+		cleaned[address]++
+		mu.Unlock()
+	})
+
+	conn := &PoolConnection{Address: address, StopCh: make(chan bool, 1)}
+	m.connections.Store(address, conn)
+	require.True(t, m.IsMonitoring(address))
+
+	t.Run("first stop closes the connection", func(t *testing.T) {
+		require.NoError(t, m.StopMonitoring(address))
+		assert.False(t, m.IsMonitoring(address))
+		_, open := <-conn.StopCh
+		assert.False(t, open, "StopCh should be closed")
+		assert.Equal(t, 1, cleaned[address])
+	})
+
+	t.Run("second stop is a no-op", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			require.NoError(t, m.StopMonitoring(address))
+		})
+		assert.Equal(t, 2, cleaned[address], "cleanup still runs so leftover queues are removed")
+	})
+
+	t.Run("unknown address is not an error", func(t *testing.T) {
+		require.NoError(t, m.StopMonitoring("UnknownAddress"))
+		assert.Equal(t, 1, cleaned["UnknownAddress"])
+	})
+
+	t.Run("concurrent stops close once", func(t *testing.T) {
+		conn := &PoolConnection{Address: address, StopCh: make(chan bool, 1)}
+		m.connections.Store(address, conn)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, m.StopMonitoring(address))
+			}()
+		}
+		wg.Wait()
+		assert.False(t, m.IsMonitoring(address))
+	})
+}
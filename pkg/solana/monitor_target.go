@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"marketcontrol/internal/models"
+)
+
+// Pool program IDs not covered by the raydium and pump helpers
+var (
+	RAYDIUM_AMM_V4_PROGRAM  = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	METEORA_DBC_PROGRAM     = solana.MustPublicKeyFromBase58("dbcij3LWUppWqq96dh6gJWwBifmcGfLSB5D4DuSMaqN")
+	METEORA_DAMM_V2_PROGRAM = solana.MustPublicKeyFromBase58("cpamdpZCGKUy5JxQXB4dcpGPiikHawvSWAd6mEn1sGG")
+)
+
+// ExpectedMonitorOwners returns the programs allowed to own a monitored address on the given platform.
+// pumpfun_internal monitors the associated bonding curve, a token account, so the token programs are accepted too.
+func ExpectedMonitorOwners(platform string) ([]solana.PublicKey, bool) {
+	switch models.PoolPlatform(platform) {
+	case models.PoolPlatformRaydium:
+		return []solana.PublicKey{RAYDIUM_AMM_V4_PROGRAM}, true
+	case models.PoolPlatformPumpfunInternal:
+		return []solana.PublicKey{PumpFunProgramID, solana.TokenProgramID, solana.Token2022ProgramID}, true
+	case models.PoolPlatformPumpfunAmm:
+		return []solana.PublicKey{PumpAmmProgramID}, true
+	case models.PoolPlatformRaydiumLaunchpad:
+		return []solana.PublicKey{LAUNCHPAD_PROGRAM}, true
+	case models.PoolPlatformRaydiumCpmm:
+		return []solana.PublicKey{CREATE_CPMM_POOL_PROGRAM}, true
+	case models.PoolPlatformMeteoraDbc:
+		return []solana.PublicKey{METEORA_DBC_PROGRAM}, true
+	case models.PoolPlatformMeteoraCpmm:
+		return []solana.PublicKey{METEORA_DAMM_V2_PROGRAM}, true
+	}
+	return nil, false
+}
+
+// MonitorTargetResult describes whether an address is a valid monitoring target
+type MonitorTargetResult struct {
+	Address        string   `json:"address"`
+	Platform       string   `json:"platform"`
+	OK             bool     `json:"ok"`
+	Reason         string   `json:"reason,omitempty"`
+	Owner          string   `json:"owner,omitempty"`
+	ExpectedOwners []string `json:"expected_owners"`
+	Lamports       uint64   `json:"lamports"`
+}
+
+// ValidateMonitorTarget fetches the account and checks it exists and is owned by the platform's program.
+// A non-nil error is only returned for RPC failures; validation failures are reported in the result.
+func ValidateMonitorTarget(ctx context.Context, client *rpc.Client, address, platform string) (*MonitorTargetResult, error) {
+	result := &MonitorTargetResult{Address: address, Platform: platform, ExpectedOwners: []string{}}
+
+	expected, ok := ExpectedMonitorOwners(platform)
+	if !ok {
+		result.Reason = fmt.Sprintf("unsupported platform: %s", platform)
+		return result, nil
+	}
+	for _, owner := range expected {
+		result.ExpectedOwners = append(result.ExpectedOwners, owner.String())
+	}
+
+	pubkey, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		result.Reason = fmt.Sprintf("invalid address: %v", err)
+		return result, nil
+	}
+
+	account, err := client.GetAccountInfo(ctx, pubkey)
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			result.Reason = "account not found on chain"
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to get account info: %w", err)
+	}
+	if account == nil || account.Value == nil {
+		result.Reason = "account not found on chain"
+		return result, nil
+	}
+
+	result.Owner = account.Value.Owner.String()
+	result.Lamports = account.Value.Lamports
+	for _, owner := range expected {
+		if account.Value.Owner.Equals(owner) {
+			result.OK = true
+			return result, nil
+		}
+	}
+	result.Reason = fmt.Sprintf("account owner %s does not match expected program for %s", result.Owner, platform)
+	return result, nil
+}
@@ -521,6 +521,54 @@ func GetPumpFunInternalPoolStat(
 	return stat, nil
 }
 
+// PumpFunInternalPDAs holds the pool accounts stored on a pumpfun internal config
+type PumpFunInternalPDAs struct {
+	BondingCurvePDA        string `json:"bonding_curve_pda"`
+	AssociatedBondingCurve string `json:"associated_bonding_curve"`
+	CreatorVaultPDA        string `json:"creator_vault_pda"`
+	Creator                string `json:"creator"`
+}
+
+// GetPumpFunInternalPDAs derives the bonding curve accounts for a mint.
+// The bonding curve and its token account are derived locally; the creator
+// vault needs the creator read from the on-chain bonding curve state.
+func GetPumpFunInternalPDAs(client *rpc.Client, mint solana.PublicKey) (*PumpFunInternalPDAs, error) {
+	bondingPDA, _, err := GetBondingCurvePDA(mint)
+	if err != nil {
+		return nil, err
+	}
+
+	associatedBondingCurve, _, err := solana.FindAssociatedTokenAddress(bondingPDA, mint)
+	if err != nil {
+		return nil, err
+	}
+
+	accountInfo, err := client.GetAccountInfo(context.Background(), bondingPDA)
+	if err != nil {
+		return nil, err
+	}
+	if accountInfo == nil || accountInfo.Value == nil {
+		return nil, errors.New("bonding PDA not found")
+	}
+
+	state, err := DecodeBondingState(accountInfo.Value.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+
+	creatorVaultPDA, _, err := GetCreatorVaultPDA(state.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PumpFunInternalPDAs{
+		BondingCurvePDA:        bondingPDA.String(),
+		AssociatedBondingCurve: associatedBondingCurve.String(),
+		CreatorVaultPDA:        creatorVaultPDA.String(),
+		Creator:                state.Creator.String(),
+	}, nil
+}
+
 // PDAResult represents a PDA calculation result
 type PDAResult struct {
 	Address solana.PublicKey `json:"address"`
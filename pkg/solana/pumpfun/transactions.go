@@ -1675,7 +1675,7 @@ func UpdateProjectConfig(cfg models.PumpfuninternalConfig, pumpfunAmmPoolConfig
 	// 3. 更新所有符合条件的 ProjectConfig
 	updatedCount := 0
 	for i := range projectConfigs {
-		projectConfigs[i].PoolPlatform = "pumpfun_amm"
+		projectConfigs[i].PoolPlatform = models.PoolPlatformPumpfunAmm
 		projectConfigs[i].PoolID = pumpfunAmmPoolConfig.ID
 
 		if err := db.Save(&projectConfigs[i]).Error; err != nil {
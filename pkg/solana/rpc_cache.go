@@ -0,0 +1,225 @@
+package solana
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Cached read methods. The TTL of each can be overridden with SOLANA_RPC_CACHE_TTL_<METHOD>
+// (a Go duration such as "5s"; "0" disables caching for that method).
+const (
+	CacheMethodSolBalance    = "sol_balance"
+	CacheMethodTokenBalance  = "token_balance"
+	CacheMethodTokenMetadata = "token_metadata"
+	CacheMethodLaunchpadPool = "launchpad_pool"
+	CacheMethodCpmmPool      = "cpmm_pool"
+)
+
+// RPCCacheMethodsEnv limits caching to a comma-separated allowlist of methods
+// (default: all methods; "none" disables the cache)
+const RPCCacheMethodsEnv = "SOLANA_RPC_CACHE_METHODS"
+
+// rpcCacheTTLEnvPrefix is followed by the upper-cased method name
+const rpcCacheTTLEnvPrefix = "SOLANA_RPC_CACHE_TTL_"
+
+// rpcCacheMaxEntries bounds memory; expired entries are swept when it is reached
+const rpcCacheMaxEntries = 10000
+
+// defaultRPCCacheTTLs 余额/储备变化快，只做短缓存以合并刷新请求；metadata 基本不变
+var defaultRPCCacheTTLs = map[string]time.Duration{
+	CacheMethodSolBalance:    5 * time.Second,
+	CacheMethodTokenBalance:  5 * time.Second,
+	CacheMethodTokenMetadata: 10 * time.Minute,
+	CacheMethodLaunchpadPool: 3 * time.Second,
+	CacheMethodCpmmPool:      3 * time.Second,
+}
+
+type rpcCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+type rpcCache struct {
+	mu      sync.Mutex
+	ttls    map[string]time.Duration
+	entries map[string]rpcCacheEntry
+}
+
+var (
+	defaultRPCCache     *rpcCache
+	defaultRPCCacheOnce sync.Once
+)
+
+// newRPCCacheFromEnv builds the cache TTLs from the defaults, the allowlist and the per-method overrides
+func newRPCCacheFromEnv() *rpcCache {
+	ttls := make(map[string]time.Duration, len(defaultRPCCacheTTLs))
+	for method, ttl := range defaultRPCCacheTTLs {
+		ttls[method] = ttl
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(RPCCacheMethodsEnv)); raw != "" {
+		allowed := make(map[string]bool)
+		for _, method := range strings.Split(raw, ",") {
+			allowed[strings.TrimSpace(method)] = true
+		}
+		for method := range ttls {
+			if !allowed[method] {
+				delete(ttls, method)
+			}
+		}
+	}
+
+	for method := range ttls {
+		raw := os.Getenv(rpcCacheTTLEnvPrefix + strings.ToUpper(method))
+		if raw == "" {
+			continue
+		}
+		ttl, err := time.ParseDuration(raw)
+		if err != nil || ttl < 0 {
+			log.Warnf("Invalid %s%s=%q, using default TTL %s", rpcCacheTTLEnvPrefix, strings.ToUpper(method), raw, ttls[method])
+			continue
+		}
+		ttls[method] = ttl
+	}
+
+	return &rpcCache{ttls: ttls, entries: make(map[string]rpcCacheEntry)}
+}
+
+func getRPCCache() *rpcCache {
+	defaultRPCCacheOnce.Do(func() {
+		defaultRPCCache = newRPCCacheFromEnv()
+	})
+	return defaultRPCCache
+}
+
+func (c *rpcCache) get(key string, now time.Time) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *rpcCache) set(key string, value interface{}, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= rpcCacheMaxEntries {
+		now := time.Now()
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= rpcCacheMaxEntries {
+			c.entries = make(map[string]rpcCacheEntry)
+		}
+	}
+	c.entries[key] = rpcCacheEntry{value: value, expires: expires}
+}
+
+// cachedRead returns the cached result of (method, account) or calls fetch and caches a successful result.
+// Errors are never cached; methods outside the allowlist or with a zero TTL always call fetch.
+func cachedRead[T any](c *rpcCache, method, account string, fetch func() (T, error)) (T, error) {
+	ttl := c.ttls[method]
+	if ttl <= 0 {
+		return fetch()
+	}
+
+	key := method + ":" + account
+	if value, ok := c.get(key, time.Now()); ok {
+		return value.(T), nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	c.set(key, value, time.Now().Add(ttl))
+	return value, nil
+}
+
+type cachedBalance struct {
+	amount    uint64
+	fetchedAt time.Time
+}
+
+// CachedSolBalance is GetSolBalance behind the RPC read cache; the returned time is when the balance was fetched
+func CachedSolBalance(client *rpc.Client, owner solana.PublicKey) (uint64, time.Time, error) {
+	result, err := cachedRead(getRPCCache(), CacheMethodSolBalance, owner.String(), func() (cachedBalance, error) {
+		amount, fetchedAt, err := GetSolBalance(client, owner)
+		return cachedBalance{amount, fetchedAt}, err
+	})
+	return result.amount, result.fetchedAt, err
+}
+
+// CachedTokenBalance is GetTokenBalance behind the RPC read cache
+func CachedTokenBalance(db *gorm.DB, client *rpc.Client, owner solana.PublicKey, mint string) (uint64, time.Time, error) {
+	result, err := cachedRead(getRPCCache(), CacheMethodTokenBalance, owner.String()+"/"+mint, func() (cachedBalance, error) {
+		amount, fetchedAt, err := GetTokenBalance(db, client, owner, mint)
+		return cachedBalance{amount, fetchedAt}, err
+	})
+	return result.amount, result.fetchedAt, err
+}
+
+// CachedTokenMetadata is GetTokenMetadata behind the RPC read cache. Every call returns its own copy.
+func CachedTokenMetadata(client *rpc.Client, mint solana.PublicKey) (*TokenMetadata, error) {
+	metadata, err := cachedRead(getRPCCache(), CacheMethodTokenMetadata, mint.String(), func() (*TokenMetadata, error) {
+		return GetTokenMetadata(client, mint)
+	})
+	if err != nil || metadata == nil {
+		return metadata, err
+	}
+	copied := *metadata
+	return &copied, nil
+}
+
+// CachedLaunchpadPoolInfo is GetLaunchpadPoolInfo behind the RPC read cache, keyed by endpoint and pool
+// since callers choose the endpoint. Every call returns its own copy.
+func CachedLaunchpadPoolInfo(rpcEndpoint string, poolId solana.PublicKey) (*LaunchpadPoolInfo, error) {
+	info, err := cachedRead(getRPCCache(), CacheMethodLaunchpadPool, rpcEndpoint+"/"+poolId.String(), func() (*LaunchpadPoolInfo, error) {
+		return GetLaunchpadPoolInfo(rpcEndpoint, poolId)
+	})
+	if err != nil || info == nil {
+		return info, err
+	}
+	copied := *info
+	return &copied, nil
+}
+
+// CachedCpmmPoolInfo fetches a single CPMM pool through GetCpmmPoolInfoBatch behind the RPC read cache.
+// It returns nil when the pool was not found; otherwise every call returns its own copy.
+func CachedCpmmPoolInfo(poolId string) (*CpmmPoolInfo, error) {
+	info, err := cachedRead(getRPCCache(), CacheMethodCpmmPool, poolId, func() (*CpmmPoolInfo, error) {
+		infos, err := GetCpmmPoolInfoBatch([]string{poolId})
+		if err != nil || len(infos) == 0 {
+			return nil, err
+		}
+		return &infos[0], nil
+	})
+	if err != nil || info == nil {
+		return info, err
+	}
+	return cloneCpmmPoolInfo(info)
+}
+
+// cloneCpmmPoolInfo deep-copies info (it holds slices and maps) through JSON, which it was decoded from
+func cloneCpmmPoolInfo(info *CpmmPoolInfo) (*CpmmPoolInfo, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	var copied CpmmPoolInfo
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
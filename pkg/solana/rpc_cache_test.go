@@ -0,0 +1,83 @@
+package solana
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRead(t *testing.T) {
+	cache := &rpcCache{
+		ttls:    map[string]time.Duration{CacheMethodSolBalance: time.Minute},
+		entries: make(map[string]rpcCacheEntry),
+	}
+	calls := 0
+	fetch := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	t.Run("Caches by method and account", func(t *testing.T) {
+		v, err := cachedRead(cache, CacheMethodSolBalance, "a", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 1, v)
+		v, _ = cachedRead(cache, CacheMethodSolBalance, "a", fetch)
+		assert.Equal(t, 1, v)
+		v, _ = cachedRead(cache, CacheMethodSolBalance, "b", fetch)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("Methods outside the allowlist are not cached", func(t *testing.T) {
+		before := calls
+		cachedRead(cache, CacheMethodTokenMetadata, "a", fetch)
+		cachedRead(cache, CacheMethodTokenMetadata, "a", fetch)
+		assert.Equal(t, before+2, calls)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		_, err := cachedRead(cache, CacheMethodSolBalance, "c", func() (int, error) { return 0, errors.New("rpc down") })
+		assert.Error(t, err)
+		v, err := cachedRead(cache, CacheMethodSolBalance, "c", func() (int, error) { return 7, nil })
+		require.NoError(t, err)
+		assert.Equal(t, 7, v)
+	})
+
+	t.Run("Expired entries are refetched", func(t *testing.T) {
+		cache.set(CacheMethodSolBalance+":d", 1, time.Now().Add(-time.Second))
+		v, _ := cachedRead(cache, CacheMethodSolBalance, "d", func() (int, error) { return 9, nil })
+		assert.Equal(t, 9, v)
+	})
+}
+
+func TestNewRPCCacheFromEnv(t *testing.T) {
+	t.Setenv(RPCCacheMethodsEnv, "sol_balance, token_metadata")
+	t.Setenv("SOLANA_RPC_CACHE_TTL_TOKEN_METADATA", "30s")
+	t.Setenv("SOLANA_RPC_CACHE_TTL_SOL_BALANCE", "bogus")
+
+	cache := newRPCCacheFromEnv()
+	assert.Equal(t, 30*time.Second, cache.ttls[CacheMethodTokenMetadata])
+	assert.Equal(t, defaultRPCCacheTTLs[CacheMethodSolBalance], cache.ttls[CacheMethodSolBalance])
+	_, ok := cache.ttls[CacheMethodCpmmPool]
+	assert.False(t, ok)
+}
+
+func TestCloneCpmmPoolInfo(t *testing.T) {
+	info := &CpmmPoolInfo{
+		Id:       "pool",
+		PoolType: []string{"OpenBookMarket"},
+		MintA:    TokenInfo{Tags: []string{"a"}, Extensions: map[string]interface{}{"k": "v"}},
+	}
+	copied, err := cloneCpmmPoolInfo(info)
+	require.NoError(t, err)
+	assert.Equal(t, info, copied)
+
+	copied.PoolType[0] = "changed"
+	copied.MintA.Tags[0] = "changed"
+	copied.MintA.Extensions["k"] = "changed"
+	assert.Equal(t, "OpenBookMarket", info.PoolType[0])
+	assert.Equal(t, "a", info.MintA.Tags[0])
+	assert.Equal(t, "v", info.MintA.Extensions["k"])
+}
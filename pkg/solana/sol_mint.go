@@ -0,0 +1,36 @@
+package solana
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NativeSolMint is the sentinel recorded as mint for native SOL amounts
+// (wallet token stats, fund transfer records, balance changes)
+const NativeSolMint = "sol"
+
+// IsSolMint reports whether mint denotes SOL: the wrapped-SOL mint or the "sol" sentinel.
+// Both are stored across tables, so SOL-denominated sums must treat them as one asset.
+func IsSolMint(mint string) bool {
+	return mint == WSolMint.String() || strings.EqualFold(mint, NativeSolMint)
+}
+
+// SolMints returns every stored mint value that denotes SOL, for `mint IN ?` filters
+func SolMints() []string {
+	return []string{NativeSolMint, WSolMint.String()}
+}
+
+// CanonicalMint maps every SOL representation to NativeSolMint and returns other mints unchanged
+func CanonicalMint(mint string) string {
+	if IsSolMint(mint) {
+		return NativeSolMint
+	}
+	return mint
+}
+
+// CanonicalMintSQL is the SQL counterpart of CanonicalMint for column, for grouping and filtering
+// SOL amounts stored as "sol" and as WSOL as one asset. column must be a trusted identifier.
+func CanonicalMintSQL(column string) string {
+	return fmt.Sprintf("(CASE WHEN LOWER(%s) = '%s' OR %s = '%s' THEN '%s' ELSE %s END)",
+		column, NativeSolMint, column, WSolMint.String(), NativeSolMint, column)
+}
@@ -0,0 +1,29 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSolMint(t *testing.T) {
+	assert.True(t, IsSolMint("sol"))
+	assert.True(t, IsSolMint("SOL"))
+	assert.True(t, IsSolMint("So11111111111111111111111111111111111111112"))
+	assert.False(t, IsSolMint(""))
+	assert.False(t, IsSolMint("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"))
+
+	for _, mint := range SolMints() {
+		assert.True(t, IsSolMint(mint))
+	}
+}
+
+func TestCanonicalMint(t *testing.T) {
+	assert.Equal(t, NativeSolMint, CanonicalMint("SOL"))
+	assert.Equal(t, NativeSolMint, CanonicalMint(WSolMint.String()))
+	assert.Equal(t, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", CanonicalMint("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"))
+
+	assert.Equal(t,
+		"(CASE WHEN LOWER(mint) = 'sol' OR mint = 'So11111111111111111111111111111111111111112' THEN 'sol' ELSE mint END)",
+		CanonicalMintSQL("mint"))
+}
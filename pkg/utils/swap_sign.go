@@ -0,0 +1,21 @@
+package utils
+
+// Swap sign convention
+//
+// All stored swap changes are signed from the point of view of the account they belong to:
+// a positive value means the account received the asset, a negative value means it paid it.
+// A buy therefore has TraderBaseChange > 0 and TraderQuoteChange < 0, and the pool sees the
+// opposite: PoolBaseChange < 0, PoolQuoteChange > 0.
+//
+// When only the trader side is recorded (e.g. SwapTransaction), the pool side is derived with
+// PoolSideChange, ignoring fees that leave the pool/trader pair.
+
+// PoolSideChange derives the pool-side change from a trader-side change
+func PoolSideChange(traderChange float64) float64 {
+	return -traderChange
+}
+
+// TraderSideChange derives the trader-side change from a pool-side change
+func TraderSideChange(poolChange float64) float64 {
+	return -poolChange
+}
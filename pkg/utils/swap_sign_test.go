@@ -0,0 +1,25 @@
+package utils
+
+import "testing"
+
+func TestSwapSideChange(t *testing.T) {
+	cases := []struct {
+		name   string
+		trader float64
+		pool   float64
+	}{
+		{"buy base", 1000, -1000},
+		{"pay quote", -1.5, 1.5},
+		{"zero", 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PoolSideChange(tc.trader); got != tc.pool {
+				t.Errorf("PoolSideChange(%v) = %v, want %v", tc.trader, got, tc.pool)
+			}
+			if got := TraderSideChange(tc.pool); got != tc.trader {
+				t.Errorf("TraderSideChange(%v) = %v, want %v", tc.pool, got, tc.trader)
+			}
+		})
+	}
+}
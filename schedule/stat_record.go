@@ -21,9 +21,9 @@ func getZeroSecondTime(t time.Time) time.Time {
 func RecordProjectSettle() error {
 	logger.Info("> 开始记录项目结算数据")
 
-	// 1. 获取所有活跃项目
+	// 1. 获取所有开启了统计更新的项目
 	var projects []models.ProjectConfig
-	if err := dbconfig.DB.Preload("Token").Find(&projects).Error; err != nil {
+	if err := dbconfig.DB.Preload("Token").Where("update_stat_enabled = ?", true).Find(&projects).Error; err != nil {
 		logger.Errorf("> 查询项目失败: %v", err)
 		return err
 	}
@@ -89,7 +89,7 @@ func RecordProjectSettle() error {
 		now := time.Now()
 		record := models.ProjectSettleRecord{
 			ProjectID:                      project.ID,
-			PoolPlatform:                   project.PoolPlatform,
+			PoolPlatform:                   string(project.PoolPlatform),
 			PoolPrice:                      poolPrice,
 			PoolSlot:                       poolSlot,
 			PoolUpdatedAt:                  poolUpdatedAt,
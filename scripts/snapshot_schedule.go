@@ -31,7 +31,7 @@ func main() {
 	for {
 		time.Sleep(900 * time.Second)
 		var projects []models.ProjectConfig
-		if err := config.DB.Preload("Token").Where("is_active = ? AND snapshot_enabled = ?", true, true).Find(&projects).Error; err != nil {
+		if err := config.DB.Preload("Token").Where("is_active = ? AND snapshot_enabled = ? AND update_stat_enabled = ?", true, true, true).Find(&projects).Error; err != nil {
 			log.Errorf("> 查询项目失败: %v", err)
 		}
 
@@ -68,6 +68,7 @@ func TestAddressManageAPI(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&addresses)
 		require.NoError(t, err)
 		assert.NotEmpty(t, addresses)
+		assert.Empty(t, addresses[0].PrivateKey)
 	})
 
 	// Test Case 3: Get Address